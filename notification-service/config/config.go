@@ -1,6 +1,11 @@
 package config
 
-import "github.com/spf13/viper"
+import (
+	"strconv"
+	"strings"
+
+	"github.com/spf13/viper"
+)
 
 type App struct {
 	AppPort string `json:"app_port"`
@@ -40,12 +45,19 @@ type EmailConfig struct {
 	IsTLS    bool   `json:"is_tls"`
 }
 
+// AdminAlert berisi penerima alert operasional, diisi dengan daftar yang dipisahkan koma.
+type AdminAlert struct {
+	Emails  []string `json:"emails"`
+	UserIDs []int    `json:"user_ids"`
+}
+
 type Config struct {
-	App       App         `json:"app"`
-	Psql      PsqlDB      `json:"psql"`
-	RabbitMQ  RabbitMQ    `json:"rabbitmq"`
-	EmailConf EmailConfig `json:"email_conf"`
-	Redis     Redis       `json:"redis"`
+	App        App         `json:"app"`
+	Psql       PsqlDB      `json:"psql"`
+	RabbitMQ   RabbitMQ    `json:"rabbitmq"`
+	EmailConf  EmailConfig `json:"email_conf"`
+	Redis      Redis       `json:"redis"`
+	AdminAlert AdminAlert  `json:"admin_alert"`
 }
 
 func NewConfig() *Config {
@@ -83,5 +95,33 @@ func NewConfig() *Config {
 			Host: viper.GetString("REDIS_HOST"),
 			Port: viper.GetString("REDIS_PORT"),
 		},
+		AdminAlert: AdminAlert{
+			Emails:  splitList(viper.GetString("ADMIN_ALERT_EMAILS")),
+			UserIDs: splitIntList(viper.GetString("ADMIN_ALERT_USER_IDS")),
+		},
+	}
+}
+
+func splitList(value string) []string {
+	results := []string{}
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			results = append(results, item)
+		}
+	}
+
+	return results
+}
+
+func splitIntList(value string) []int {
+	results := []int{}
+	for _, item := range splitList(value) {
+		id, err := strconv.Atoi(item)
+		if err != nil {
+			continue
+		}
+		results = append(results, id)
 	}
+
+	return results
 }
@@ -3,6 +3,7 @@ package rabbitmq
 import (
 	"context"
 	"encoding/json"
+	"fmt"
 	"notification-service/config"
 	"notification-service/internal/adapter/message"
 	"notification-service/internal/adapter/repository"
@@ -14,6 +15,7 @@ import (
 
 type ConsumeRabbitMQInterface interface {
 	ConsumeMessage(queueName string) error
+	ConsumeStockAlert(queueName string) error
 }
 
 type consumeRabbitMQ struct {
@@ -70,6 +72,91 @@ func (c *consumeRabbitMQ) ConsumeMessage(queueName string) error {
 	return nil
 }
 
+// ConsumeStockAlert implements ConsumeRabbitMQInterface.
+// Satu event stok dikirim sebagai email dan push notification ke setiap admin pada konfigurasi ADMIN_ALERT.
+func (c *consumeRabbitMQ) ConsumeStockAlert(queueName string) error {
+	cfg := config.NewConfig()
+	conn, err := cfg.NewRabbitMQ()
+	if err != nil {
+		log.Errorf("[ConsumeStockAlert-1] Failed to connect to RabbitMQ: %v", err)
+		return err
+	}
+
+	defer conn.Close()
+
+	ch, err := conn.Channel()
+	if err != nil {
+		log.Errorf("[ConsumeStockAlert-2] Failed to open a channel: %v", err)
+		return err
+	}
+
+	defer ch.Close()
+	q, err := ch.QueueDeclare(queueName, true, false, false, false, nil)
+	if err != nil {
+		log.Errorf("[ConsumeStockAlert-3] Failed to declare queue: %v", err)
+		return err
+	}
+
+	msgs, err := ch.Consume(q.Name, "", true, false, false, false, nil)
+	if err != nil {
+		log.Errorf("[ConsumeStockAlert-4] Failed to consume messages: %v", err)
+		return err
+	}
+
+	for d := range msgs {
+		var stockAlert entity.StockAlertEntity
+		if err = json.Unmarshal(d.Body, &stockAlert); err != nil {
+			log.Errorf("[ConsumeStockAlert-5] Failed to unmarshal JSON: %v", err)
+			continue
+		}
+
+		subject := fmt.Sprintf("Low stock: %s", stockAlert.ProductName)
+		body := fmt.Sprintf("Stock of %s (ID %d) is %d, at or below the threshold of %d.", stockAlert.ProductName, stockAlert.ProductID, stockAlert.Stock, stockAlert.LowStockThreshold)
+		if stockAlert.AlertType == "out_of_stock" {
+			subject = fmt.Sprintf("Out of stock: %s", stockAlert.ProductName)
+			body = fmt.Sprintf("%s (ID %d) is out of stock.", stockAlert.ProductName, stockAlert.ProductID)
+		}
+
+		notifications := []entity.NotificationEntity{}
+		for _, email := range cfg.AdminAlert.Emails {
+			receiverEmail := email
+			notifications = append(notifications, entity.NotificationEntity{
+				NotificationType: "EMAIL",
+				Subject:          &subject,
+				Message:          body,
+				ReceiverEmail:    &receiverEmail,
+				Status:           "SENT",
+			})
+		}
+		for _, userID := range cfg.AdminAlert.UserIDs {
+			receiverID := userID
+			notifications = append(notifications, entity.NotificationEntity{
+				NotificationType: "PUSH",
+				ReceiverID:       &receiverID,
+				Subject:          &subject,
+				Message:          body,
+				Status:           "PENDING",
+			})
+		}
+
+		if len(notifications) == 0 {
+			log.Warnf("[ConsumeStockAlert-6] No admin alert receiver configured for product %d", stockAlert.ProductID)
+			continue
+		}
+
+		for _, notification := range notifications {
+			if err = c.notifRepository.CreateNotification(context.Background(), notification); err != nil {
+				log.Errorf("[ConsumeStockAlert-7] Failed to create notification: %v", err)
+				continue
+			}
+
+			go c.SendNotification(notification)
+		}
+	}
+
+	return nil
+}
+
 func (c *consumeRabbitMQ) SendNotification(notificationEntity entity.NotificationEntity) {
 	switch notificationEntity.NotificationType {
 	case "EMAIL":
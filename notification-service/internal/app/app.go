@@ -64,6 +64,13 @@ func RunServer() {
 		}
 	}()
 
+	go func() {
+		err = rabbitMQAdapter.ConsumeMessage(utils.NOTIF_EMAIL_PAYMENT_REFUNDED)
+		if err != nil {
+			e.Logger.Errorf("Failed to consume RabbitMQ for %s: %v", utils.NOTIF_EMAIL_PAYMENT_REFUNDED, err)
+		}
+	}()
+
 	go func() {
 		err = rabbitMQAdapter.ConsumeMessage(utils.PUSH_NOTIF)
 		if err != nil {
@@ -71,6 +78,13 @@ func RunServer() {
 		}
 	}()
 
+	go func() {
+		err = rabbitMQAdapter.ConsumeStockAlert(utils.STOCK_ALERT)
+		if err != nil {
+			e.Logger.Errorf("Failed to consume RabbitMQ for %s: %v", utils.STOCK_ALERT, err)
+		}
+	}()
+
 	handlers.NewNotificationHandler(notifService, e, cfg)
 
 	go func() {
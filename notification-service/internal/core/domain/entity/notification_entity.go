@@ -16,6 +16,14 @@ type NotificationEntity struct {
 	ID               uint       `json:"id"`
 }
 
+type StockAlertEntity struct {
+	ProductID         int64  `json:"product_id"`
+	ProductName       string `json:"product_name"`
+	Stock             int    `json:"stock"`
+	LowStockThreshold int    `json:"low_stock_threshold"`
+	AlertType         string `json:"alert_type"`
+}
+
 type NotifyQueryString struct {
 	Page      int64
 	Limit     int64
@@ -6,5 +6,7 @@ const (
 	NOTIF_EMAIL_CREATE_CUSTOMER     = "create_customer"
 	NOTIF_EMAIL_UPDATE_CUSTOMER     = "update_customer"
 	NOTIF_EMAIL_UPDATE_STATUS_ORDER = "email-update-status-order"
+	NOTIF_EMAIL_PAYMENT_REFUNDED    = "email-payment-refunded"
 	PUSH_NOTIF                      = "push-notif"
+	STOCK_ALERT                     = "stock-alert"
 )
@@ -0,0 +1,17 @@
+package cmd
+
+import (
+	"fmt"
+	"order-service/internal/adapter/message"
+
+	"github.com/spf13/cobra"
+)
+
+var workerPaymentRefundCmd = &cobra.Command{
+	Use:   "worker-payment-refund",
+	Short: "Menjalankan worker untuk consume event refund payment dan update ke Elasticsearch",
+	Run: func(cmd *cobra.Command, args []string) {
+		fmt.Println("Worker untuk Payment Refund sedang berjalan...")
+		message.ConsumePaymentRefund()
+	},
+}
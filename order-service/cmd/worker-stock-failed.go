@@ -0,0 +1,17 @@
+package cmd
+
+import (
+	"fmt"
+	"order-service/internal/adapter/message"
+
+	"github.com/spf13/cobra"
+)
+
+var workerStockFailedCmd = &cobra.Command{
+	Use:   "worker-stock-failed",
+	Short: "Menjalankan worker untuk consume event stok gagal dari product-service dan membatalkan order",
+	Run: func(cmd *cobra.Command, args []string) {
+		fmt.Println("Worker untuk Stock Failed sedang berjalan...")
+		message.ConsumeStockFailed()
+	},
+}
@@ -9,10 +9,10 @@ import (
 
 var workerUpdatePaymentOrderCmd = &cobra.Command{
 	Use:   "worker-update-payment-order",
-	Short: "Menjalankan worker untuk consume RabbitMQ dan index ke Elasticsearch",
+	Short: "Menjalankan worker untuk consume event payment, update status order dan index ke Elasticsearch",
 	Run: func(cmd *cobra.Command, args []string) {
 		fmt.Println("Worker untuk Order Indexing sedang berjalan...")
-		message.ConsumePaymentSuccess()
+		message.ConsumePaymentEvents()
 	},
 }
 
@@ -15,6 +15,11 @@ type App struct {
 	LatitudeRef  string `json:"latitude_ref"`
 	LongitudeRef string `json:"longitude_ref"`
 	MaxDistance  int    `json:"max_distance"`
+
+	TaxInclusive bool `json:"tax_inclusive"`
+
+	// TrustedProxies berisi CIDR proxy yang dipercaya, dipisahkan koma
+	TrustedProxies string `json:"trusted_proxies"`
 }
 
 type PsqlDB struct {
@@ -44,8 +49,13 @@ type PublisherName struct {
 	OrderPublish            string `json:"order_publish"`
 	EmailUpdateStatus       string `json:"email_update_status"`
 	PublisherDeleteOrder    string `json:"publisher_delete_order"`
+	PublisherPaymentCreated string `json:"publisher_payment_created"`
 	PublisherPaymentSuccess string `json:"publisher_payment_success"`
+	PublisherPaymentFailed  string `json:"publisher_payment_failed"`
+	PublisherPaymentExpired string `json:"publisher_payment_expired"`
 	PublisherUpdateStatus   string `json:"publisher_update_status"`
+	PublisherPaymentRefund  string `json:"publisher_payment_refund"`
+	ProductStockFailed      string `json:"product_stock_failed"`
 }
 
 type ElasticSearch struct {
@@ -74,6 +84,8 @@ func NewConfig() *Config {
 			LatitudeRef:       viper.GetString("LATITUDE_REF"),
 			LongitudeRef:      viper.GetString("LONGITUDE_REF"),
 			MaxDistance:       viper.GetInt("MAX_DISTANCE"),
+			TaxInclusive:      viper.GetBool("TAX_PRICE_INCLUSIVE"),
+			TrustedProxies:    viper.GetString("TRUSTED_PROXIES"),
 		},
 		Psql: PsqlDB{
 			Host:      viper.GetString("DATABASE_HOST"),
@@ -99,8 +111,13 @@ func NewConfig() *Config {
 			OrderPublish:            viper.GetString("ORDER_PUBLISH_NAME"),
 			EmailUpdateStatus:       viper.GetString("EMAIL_UPDATE_STATUS_NAME"),
 			PublisherDeleteOrder:    viper.GetString("PUBLISHER_DELETE_ORDER"),
+			PublisherPaymentCreated: viper.GetString("PUBLISHER_PAYMENT_CREATED"),
 			PublisherPaymentSuccess: viper.GetString("PUBLISHER_PAYMENT_SUCCESS"),
+			PublisherPaymentFailed:  viper.GetString("PUBLISHER_PAYMENT_FAILED"),
+			PublisherPaymentExpired: viper.GetString("PUBLISHER_PAYMENT_EXPIRED"),
 			PublisherUpdateStatus:   viper.GetString("PUBLISHER_UPDATE_STATUS"),
+			PublisherPaymentRefund:  viper.GetString("PUBLISHER_PAYMENT_REFUNDED"),
+			ProductStockFailed:      viper.GetString("PRODUCT_STOCK_FAILED"),
 		},
 		ElasticSearch: ElasticSearch{
 			Host: viper.GetString("ELASTICSEARCH_HOST"),
@@ -0,0 +1,7 @@
+ALTER TABLE orders ADD COLUMN tax_amount DECIMAL(10, 2) NOT NULL DEFAULT 0;
+ALTER TABLE orders ADD COLUMN tax_inclusive BOOLEAN NOT NULL DEFAULT FALSE;
+
+ALTER TABLE order_items ADD COLUMN price DECIMAL(10, 2) NOT NULL DEFAULT 0;
+ALTER TABLE order_items ADD COLUMN subtotal DECIMAL(10, 2) NOT NULL DEFAULT 0;
+ALTER TABLE order_items ADD COLUMN tax_rate DECIMAL(5, 2) NOT NULL DEFAULT 0;
+ALTER TABLE order_items ADD COLUMN tax_amount DECIMAL(10, 2) NOT NULL DEFAULT 0;
\ No newline at end of file
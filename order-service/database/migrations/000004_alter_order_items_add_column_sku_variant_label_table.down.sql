@@ -0,0 +1,2 @@
+ALTER TABLE order_items DROP COLUMN IF EXISTS variant_label;
+ALTER TABLE order_items DROP COLUMN IF EXISTS sku;
\ No newline at end of file
@@ -0,0 +1,2 @@
+ALTER TABLE order_items ADD COLUMN sku VARCHAR(64) NULL;
+ALTER TABLE order_items ADD COLUMN variant_label VARCHAR(255) NULL;
\ No newline at end of file
@@ -0,0 +1,6 @@
+DROP INDEX IF EXISTS idx_orders_buyer_phone_created_at;
+DROP INDEX IF EXISTS idx_orders_client_ip_created_at;
+DROP INDEX IF EXISTS idx_orders_buyer_id_created_at;
+
+ALTER TABLE orders DROP COLUMN IF EXISTS buyer_phone;
+ALTER TABLE orders DROP COLUMN IF EXISTS client_ip;
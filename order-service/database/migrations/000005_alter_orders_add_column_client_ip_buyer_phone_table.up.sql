@@ -0,0 +1,6 @@
+ALTER TABLE orders ADD COLUMN client_ip VARCHAR(45);
+ALTER TABLE orders ADD COLUMN buyer_phone VARCHAR(20);
+
+CREATE INDEX IF NOT EXISTS idx_orders_buyer_id_created_at ON orders (buyer_id, created_at);
+CREATE INDEX IF NOT EXISTS idx_orders_client_ip_created_at ON orders (client_ip, created_at);
+CREATE INDEX IF NOT EXISTS idx_orders_buyer_phone_created_at ON orders (buyer_phone, created_at);
@@ -10,6 +10,8 @@ import (
 	"order-service/internal/core/domain/entity"
 	"order-service/internal/core/service"
 	"order-service/utils/conv"
+	"strings"
+	"time"
 
 	"github.com/labstack/echo/v4"
 	"github.com/labstack/echo/v4/middleware"
@@ -26,12 +28,67 @@ type OrderHandlerInterface interface {
 	DeleteByID(c echo.Context) error
 	GetOrderByOrderCode(c echo.Context) error
 	GetPublicOrderByOrderCode(c echo.Context) error
+	GetCompletedOrderByProducts(c echo.Context) error
+	GetOrderVelocity(c echo.Context) error
 }
 
 type orderHandler struct {
 	orderService service.OrderServiceInterface
 }
 
+// GetCompletedOrderByProducts dipakai product-service untuk memverifikasi
+// bahwa customer sudah menyelesaikan order yang berisi produk tertentu.
+func (o *orderHandler) GetCompletedOrderByProducts(c echo.Context) error {
+	var (
+		ctx         = c.Request().Context()
+		jwtUserData = entity.JwtUserData{}
+		productIDs  = []int64{}
+	)
+
+	user := c.Get("user").(string)
+	if user == "" {
+		log.Errorf("[OrderHandler-1] GetCompletedOrderByProducts: %s", "data token not found")
+		return c.JSON(http.StatusUnauthorized, response.ResponseError("data token not found"))
+	}
+
+	err := json.Unmarshal([]byte(user), &jwtUserData)
+	if err != nil {
+		log.Errorf("[OrderHandler-2] GetCompletedOrderByProducts: %v", err)
+		return c.JSON(http.StatusBadRequest, response.ResponseError(err.Error()))
+	}
+
+	for _, val := range strings.Split(c.QueryParam("product_ids"), ",") {
+		val = strings.TrimSpace(val)
+		if val == "" {
+			continue
+		}
+		productID, err := conv.StringToInt64(val)
+		if err != nil {
+			log.Errorf("[OrderHandler-3] GetCompletedOrderByProducts: %v", err)
+			return c.JSON(http.StatusBadRequest, response.ResponseError("invalid product_ids"))
+		}
+		productIDs = append(productIDs, productID)
+	}
+
+	if len(productIDs) == 0 {
+		log.Errorf("[OrderHandler-4] GetCompletedOrderByProducts: %s", "product_ids is required")
+		return c.JSON(http.StatusBadRequest, response.ResponseError("product_ids is required"))
+	}
+
+	orderID, err := o.orderService.GetCompletedOrderIDByProducts(ctx, jwtUserData.UserID, productIDs)
+	if err != nil {
+		log.Errorf("[OrderHandler-5] GetCompletedOrderByProducts: %v", err)
+		if err.Error() == "404" {
+			return c.JSON(http.StatusNotFound, response.ResponseError("data not found"))
+		}
+		return c.JSON(http.StatusInternalServerError, response.ResponseError(err.Error()))
+	}
+
+	return c.JSON(http.StatusOK, response.ResponseSuccess("success", map[string]int64{
+		"orderID": orderID,
+	}))
+}
+
 func (o *orderHandler) GetPublicOrderByOrderCode(c echo.Context) error {
 	var (
 		ctx = c.Request().Context()
@@ -90,6 +147,8 @@ func (o *orderHandler) GetOrderByOrderCode(c echo.Context) error {
 	respOrder.TotalAmount = order.TotalAmount
 	respOrder.OrderDatetime = order.OrderDate
 	respOrder.ShippingFee = order.ShippingFee
+	respOrder.TaxAmount = order.TaxAmount
+	respOrder.TaxInclusive = order.TaxInclusive
 	respOrder.Remarks = order.Remarks
 	respOrder.PaymentMethod = order.PaymentMethod
 	respOrder.Customer = response.CustomerOrder{
@@ -103,9 +162,14 @@ func (o *orderHandler) GetOrderByOrderCode(c echo.Context) error {
 	for _, item := range order.OrderItems {
 		respOrder.OrderDetail = append(respOrder.OrderDetail, response.OrderDetail{
 			ProductName:  item.ProductName,
+			SKU:          item.SKU,
+			VariantLabel: item.VariantLabel,
 			ProductImage: item.ProductImage,
 			ProductPrice: item.Price,
 			Quantity:     item.Quantity,
+			Subtotal:     item.Subtotal,
+			TaxRate:      item.TaxRate,
+			TaxAmount:    item.TaxAmount,
 		})
 	}
 
@@ -186,6 +250,8 @@ func (o *orderHandler) GetDetailCustomer(c echo.Context) error {
 	respOrder.TotalAmount = order.TotalAmount
 	respOrder.OrderDatetime = order.OrderDate
 	respOrder.ShippingFee = order.ShippingFee
+	respOrder.TaxAmount = order.TaxAmount
+	respOrder.TaxInclusive = order.TaxInclusive
 	respOrder.ShippingType = order.ShippingType
 	respOrder.Remarks = order.Remarks
 	respOrder.Customer = response.CustomerOrder{
@@ -199,9 +265,14 @@ func (o *orderHandler) GetDetailCustomer(c echo.Context) error {
 	for _, item := range order.OrderItems {
 		respOrder.OrderDetail = append(respOrder.OrderDetail, response.OrderDetail{
 			ProductName:  item.ProductName,
+			SKU:          item.SKU,
+			VariantLabel: item.VariantLabel,
 			ProductImage: item.ProductImage,
 			ProductPrice: item.Price,
 			Quantity:     item.Quantity,
+			Subtotal:     item.Subtotal,
+			TaxRate:      item.TaxRate,
+			TaxAmount:    item.TaxAmount,
 		})
 	}
 
@@ -374,12 +445,14 @@ func (o *orderHandler) CreateOrder(c echo.Context) error {
 		ShippingType: req.ShippingType,
 		Remarks:      req.Remarks,
 		OrderTime:    req.OrderTime,
+		ClientIP:     c.RealIP(),
 	}
 
 	orderDetails := []entity.OrderItemEntity{}
 	for _, val := range req.OrderDetails {
 		orderDetails = append(orderDetails, entity.OrderItemEntity{
 			ProductID: val.ProductID,
+			VariantID: val.VariantID,
 			Quantity:  val.Quantity,
 		})
 	}
@@ -389,6 +462,9 @@ func (o *orderHandler) CreateOrder(c echo.Context) error {
 	orderID, err := o.orderService.CreateOrder(ctx, reqEntity, user)
 	if err != nil {
 		log.Errorf("[OrderHandler-4] CreateOrder: %v", err)
+		if err.Error() == "Variant does not belong to product" {
+			return c.JSON(http.StatusBadRequest, response.ResponseError(err.Error()))
+		}
 		return c.JSON(http.StatusInternalServerError, response.ResponseError(err.Error()))
 	}
 
@@ -437,6 +513,8 @@ func (o *orderHandler) GetByIDAdmin(c echo.Context) error {
 	respOrder.TotalAmount = order.TotalAmount
 	respOrder.OrderDatetime = order.OrderDate
 	respOrder.ShippingFee = order.ShippingFee
+	respOrder.TaxAmount = order.TaxAmount
+	respOrder.TaxInclusive = order.TaxInclusive
 	respOrder.Remarks = order.Remarks
 	respOrder.Customer = response.CustomerOrder{
 		CustomerName:    order.BuyerName,
@@ -449,9 +527,14 @@ func (o *orderHandler) GetByIDAdmin(c echo.Context) error {
 	for _, item := range order.OrderItems {
 		respOrder.OrderDetail = append(respOrder.OrderDetail, response.OrderDetail{
 			ProductName:  item.ProductName,
+			SKU:          item.SKU,
+			VariantLabel: item.VariantLabel,
 			ProductImage: item.ProductImage,
 			ProductPrice: item.Price,
 			Quantity:     item.Quantity,
+			Subtotal:     item.Subtotal,
+			TaxRate:      item.TaxRate,
+			TaxAmount:    item.TaxAmount,
 		})
 	}
 
@@ -528,6 +611,54 @@ func (o *orderHandler) GetAllAdmin(c echo.Context) error {
 	return c.JSON(http.StatusOK, response.ResponseSuccessWithPagination("success", respOrders, page, totalData, totalPage, perPage))
 }
 
+// GetOrderVelocity dipakai payment-service untuk rule fraud velocity,
+// jumlah order lain dari pembeli, IP dan nomor telepon yang sama dalam rentang menit tertentu.
+func (o *orderHandler) GetOrderVelocity(c echo.Context) error {
+	var (
+		ctx         = c.Request().Context()
+		jwtUserData = entity.JwtUserData{}
+		minutes     = int64(60)
+	)
+
+	user := c.Get("user").(string)
+	if user == "" {
+		log.Errorf("[OrderHandler-1] GetOrderVelocity: %s", "data token not found")
+		return c.JSON(http.StatusUnauthorized, response.ResponseError("data token not found"))
+	}
+
+	err := json.Unmarshal([]byte(user), &jwtUserData)
+	if err != nil {
+		log.Errorf("[OrderHandler-2] GetOrderVelocity: %v", err)
+		return c.JSON(http.StatusBadRequest, response.ResponseError(err.Error()))
+	}
+
+	orderID, err := conv.StringToInt64(c.Param("orderID"))
+	if err != nil {
+		log.Errorf("[OrderHandler-3] GetOrderVelocity: %v", err)
+		return c.JSON(http.StatusBadRequest, response.ResponseError("invalid orderID"))
+	}
+
+	if c.QueryParam("minutes") != "" {
+		minutes, err = conv.StringToInt64(c.QueryParam("minutes"))
+		if err != nil || minutes <= 0 {
+			log.Errorf("[OrderHandler-4] GetOrderVelocity: invalid minutes %s", c.QueryParam("minutes"))
+			return c.JSON(http.StatusBadRequest, response.ResponseError("invalid minutes"))
+		}
+	}
+
+	since := time.Now().Add(-time.Duration(minutes) * time.Minute)
+	result, err := o.orderService.GetOrderVelocity(ctx, orderID, jwtUserData.UserID, since)
+	if err != nil {
+		log.Errorf("[OrderHandler-5] GetOrderVelocity: %v", err)
+		if err.Error() == "404" {
+			return c.JSON(http.StatusNotFound, response.ResponseError("data not found"))
+		}
+		return c.JSON(http.StatusInternalServerError, response.ResponseError(err.Error()))
+	}
+
+	return c.JSON(http.StatusOK, response.ResponseSuccess("success", result))
+}
+
 func NewOrderHandler(orderService service.OrderServiceInterface, e *echo.Echo, cfg *config.Config) OrderHandlerInterface {
 	ordHandler := &orderHandler{orderService: orderService}
 
@@ -537,7 +668,9 @@ func NewOrderHandler(orderService service.OrderServiceInterface, e *echo.Echo, c
 	authGroup := e.Group("auth", mid.CheckToken())
 	authGroup.POST("/orders", ordHandler.CreateOrder, mid.DistanceCheck())
 	authGroup.GET("/orders", ordHandler.GetAllCustomer)
+	authGroup.GET("/orders/completed", ordHandler.GetCompletedOrderByProducts)
 	authGroup.GET("/orders/:orderID", ordHandler.GetDetailCustomer)
+	authGroup.GET("/orders/:orderID/velocity", ordHandler.GetOrderVelocity)
 	authGroup.GET("/orders/:orderCode/code", ordHandler.GetOrderByOrderCode)
 
 	adminGroup := e.Group("/admin", mid.CheckToken())
@@ -13,6 +13,7 @@ type CreateOrderRequest struct {
 
 type OrderDetailRequest struct {
 	ProductID int64 `json:"product_id" validate:"required"`
+	VariantID int64 `json:"variant_id"`
 	Quantity  int64 `json:"quantity" validate:"required"`
 }
 
@@ -20,6 +20,8 @@ type OrderAdminDetail struct {
 	ShippingFee   int64         `json:"shipping_fee"`
 	ShippingType  string        `json:"shipping_type"`
 	Remarks       string        `json:"remarks"`
+	TaxAmount     int64         `json:"tax_amount"`
+	TaxInclusive  bool          `json:"tax_inclusive"`
 	TotalAmount   int64         `json:"total_amount"`
 	Customer      CustomerOrder `json:"customer"`
 	OrderDetail   []OrderDetail `json:"order_detail"`
@@ -48,8 +50,13 @@ type CustomerOrder struct {
 }
 
 type OrderDetail struct {
-	ProductName  string `json:"product_name"`
-	ProductImage string `json:"product_image"`
-	ProductPrice int64  `json:"product_price"`
-	Quantity     int64  `json:"quantity"`
+	ProductName  string  `json:"product_name"`
+	SKU          string  `json:"sku"`
+	VariantLabel string  `json:"variant_label"`
+	ProductImage string  `json:"product_image"`
+	ProductPrice int64   `json:"product_price"`
+	Quantity     int64   `json:"quantity"`
+	Subtotal     int64   `json:"subtotal"`
+	TaxRate      float64 `json:"tax_rate"`
+	TaxAmount    int64   `json:"tax_amount"`
 }
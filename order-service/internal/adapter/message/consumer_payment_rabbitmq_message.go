@@ -0,0 +1,203 @@
+package message
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"order-service/config"
+	"order-service/internal/adapter/repository"
+	"order-service/internal/core/domain/entity"
+	"order-service/utils"
+	"strconv"
+
+	"github.com/elastic/go-elasticsearch/v7"
+	"github.com/labstack/gommon/log"
+)
+
+// ConsumePaymentEvents menjalankan consumer untuk seluruh event payment dari payment-service.
+func ConsumePaymentEvents() {
+	go ConsumePaymentCreated()
+	go ConsumePaymentSuccess()
+	go ConsumePaymentFailed()
+	ConsumePaymentExpired()
+}
+
+// ConsumePaymentCreated hanya mencatat metode pembayaran, order tetap Pending sampai pembayaran selesai.
+// Order COD langsung dikonfirmasi karena uang tunai baru ditagih kurir saat pengiriman.
+func ConsumePaymentCreated() {
+	cfg := config.NewConfig()
+	consumePaymentEvent(cfg, cfg.PublisherName.PublisherPaymentCreated, "")
+}
+
+func ConsumePaymentSuccess() {
+	cfg := config.NewConfig()
+	consumePaymentEvent(cfg, cfg.PublisherName.PublisherPaymentSuccess, "Confirmed")
+}
+
+// ConsumePaymentFailed tidak membatalkan order karena customer masih bisa membuat percobaan pembayaran baru.
+func ConsumePaymentFailed() {
+	cfg := config.NewConfig()
+	consumePaymentEvent(cfg, cfg.PublisherName.PublisherPaymentFailed, "")
+}
+
+// ConsumePaymentExpired tidak membatalkan order karena customer masih bisa membuat percobaan pembayaran baru.
+func ConsumePaymentExpired() {
+	cfg := config.NewConfig()
+	consumePaymentEvent(cfg, cfg.PublisherName.PublisherPaymentExpired, "")
+}
+
+func consumePaymentEvent(cfg *config.Config, queueName, orderStatus string) {
+	conn, err := cfg.NewRabbitMQ()
+	if err != nil {
+		log.Fatalf("[consumePaymentEvent-1] Failed to connect to RabbitMQ: %v", err)
+	}
+
+	defer conn.Close()
+
+	ch, err := conn.Channel()
+	if err != nil {
+		log.Fatalf("[consumePaymentEvent-2] Failed to open a channel: %v", err)
+	}
+
+	defer ch.Close()
+
+	q, err := ch.QueueDeclare(
+		queueName,
+		true,
+		false,
+		false,
+		false,
+		nil,
+	)
+	if err != nil {
+		log.Fatalf("[consumePaymentEvent-3] Failed to declare queue: %v", err)
+	}
+
+	msgs, err := ch.Consume(
+		q.Name,
+		"",
+		true,
+		false,
+		false,
+		false,
+		nil,
+	)
+	if err != nil {
+		log.Fatalf("[consumePaymentEvent-4] Failed to register consumer: %v", err)
+	}
+
+	db, err := cfg.ConnectionPostgres()
+	if err != nil {
+		log.Fatalf("[consumePaymentEvent-5] Failed to connect to database: %v", err)
+	}
+
+	esClient, err := cfg.InitElasticsearch()
+	if err != nil {
+		log.Errorf("[consumePaymentEvent-6] Failed initialize Elasticsearch client: %v", err)
+	}
+
+	orderRepo := repository.NewOrderRepository(db.DB)
+	publisher := NewPublisherRabbitMQ(cfg)
+
+	log.Infof("RabbitMQ Consumer %s started...", queueName)
+
+	for msg := range msgs {
+		var payment map[string]string
+		if err := json.Unmarshal(msg.Body, &payment); err != nil {
+			log.Errorf("[consumePaymentEvent-7] Error decoding message: %v", err)
+			continue
+		}
+
+		orderID, err := strconv.ParseInt(payment["orderID"], 10, 64)
+		if err != nil {
+			log.Errorf("[consumePaymentEvent-8] Invalid order ID format: %v", payment["orderID"])
+			continue
+		}
+
+		var (
+			buyerID   int64
+			newStatus string
+			orderCode string
+		)
+		targetStatus := orderStatus
+		if targetStatus == "" && payment["paymentStatus"] == "AwaitingCollection" {
+			targetStatus = "Confirmed"
+		}
+		if targetStatus != "" {
+			buyerID, newStatus, orderCode, err = applyPaymentOrderStatus(orderRepo, orderID, targetStatus)
+			if err != nil {
+				log.Errorf("[consumePaymentEvent-9] order %d: %v", orderID, err)
+			}
+		}
+
+		updatePaymentOrderIndex(esClient, payment, newStatus)
+
+		if newStatus != "" {
+			message := fmt.Sprintf("Hello,\n\nYour order with ID %s has been updated to status: %s.\n\nThank you for shopping with us!", orderCode, newStatus)
+			if err := publisher.PublishSendPushNotifUpdateStatus(message, utils.PUSH_NOTIF, buyerID); err != nil {
+				log.Errorf("[consumePaymentEvent-10] order %d: %v", orderID, err)
+			}
+		}
+	}
+}
+
+// applyPaymentOrderStatus memindahkan order yang masih Pending, event yang terlambat atau duplikat diabaikan.
+func applyPaymentOrderStatus(orderRepo repository.OrderRepositoryInterface, orderID int64, orderStatus string) (int64, string, string, error) {
+	order, err := orderRepo.GetByID(context.Background(), orderID)
+	if err != nil {
+		return 0, "", "", err
+	}
+
+	if order.Status != "Pending" {
+		log.Infof("[applyPaymentOrderStatus-1] order %d already %s, skip %s", orderID, order.Status, orderStatus)
+		return 0, "", "", nil
+	}
+
+	return orderRepo.UpdateStatus(context.Background(), entity.OrderEntity{
+		ID:      orderID,
+		Status:  orderStatus,
+		Remarks: order.Remarks,
+	})
+}
+
+func updatePaymentOrderIndex(esClient *elasticsearch.Client, payment map[string]string, orderStatus string) {
+	if esClient == nil {
+		return
+	}
+
+	source := "ctx._source.payment_method = params.payment_method; ctx._source.payment_status = params.payment_status"
+	params := map[string]interface{}{
+		"payment_method": payment["paymentMethod"],
+		"payment_status": payment["paymentStatus"],
+	}
+	if orderStatus != "" {
+		source += "; ctx._source.status = params.status"
+		params["status"] = orderStatus
+	}
+
+	updateScript := map[string]interface{}{
+		"script": map[string]interface{}{
+			"source": source,
+			"lang":   "painless",
+			"params": params,
+		},
+	}
+
+	paymentJson, err := json.Marshal(updateScript)
+	if err != nil {
+		log.Errorf("[updatePaymentOrderIndex-1] Error encoding payment to JSON: %v", err)
+		return
+	}
+
+	res, err := esClient.Update("orders", payment["orderID"], bytes.NewReader(paymentJson))
+	if err != nil {
+		log.Errorf("[updatePaymentOrderIndex-2] Failed to update order in Elasticsearch: %v", err)
+		return
+	}
+	defer res.Body.Close()
+
+	bodyBytes, _ := io.ReadAll(res.Body)
+	log.Infof("[updatePaymentOrderIndex-3] Elasticsearch response: %s", string(bodyBytes))
+}
@@ -6,8 +6,10 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
+	"math"
 	"order-service/config"
 	"order-service/internal/core/domain/entity"
+	"strconv"
 
 	"github.com/labstack/gommon/log"
 )
@@ -112,23 +114,23 @@ func ConsumeUpdateStatus() {
 	<-forever
 }
 
-func ConsumePaymentSuccess() {
+func ConsumePaymentRefund() {
 	conn, err := config.NewConfig().NewRabbitMQ()
 	if err != nil {
-		log.Errorf("[consumePaymentSuccess-1] Failed to connect to RabbitMQ: %v", err)
+		log.Errorf("[ConsumePaymentRefund-1] Failed to connect to RabbitMQ: %v", err)
 	}
 
 	defer conn.Close()
 
 	ch, err := conn.Channel()
 	if err != nil {
-		log.Errorf("[consumePaymentSuccess-2] Failed to open a channel: %v", err)
+		log.Errorf("[ConsumePaymentRefund-2] Failed to open a channel: %v", err)
 	}
 
 	defer ch.Close()
 
 	q, err := ch.QueueDeclare(
-		config.NewConfig().PublisherName.PublisherPaymentSuccess,
+		config.NewConfig().PublisherName.PublisherPaymentRefund,
 		true,
 		false,
 		false,
@@ -136,7 +138,7 @@ func ConsumePaymentSuccess() {
 		nil,
 	)
 	if err != nil {
-		log.Fatalf("[consumePaymentSuccess-3] Failed to declare queue: %v", err)
+		log.Fatalf("[ConsumePaymentRefund-3] Failed to declare queue: %v", err)
 	}
 
 	msgs, err := ch.Consume(
@@ -149,66 +151,63 @@ func ConsumePaymentSuccess() {
 		nil,
 	)
 	if err != nil {
-		log.Fatalf("[consumePaymentSuccess-4] Failed to register consumer: %v", err)
+		log.Fatalf("[ConsumePaymentRefund-4] Failed to register consumer: %v", err)
 	}
 
-	log.Info("RabbitMQ Consumer order started...")
+	log.Info("RabbitMQ Consumer payment refund started...")
 
 	esClient, err := config.NewConfig().InitElasticsearch()
 	if err != nil {
-		log.Errorf("[StartOrderConsumer-5] Failed initialize Elasticsearch client: %v", err)
+		log.Errorf("[ConsumePaymentRefund-5] Failed initialize Elasticsearch client: %v", err)
 	}
 
 	forever := make(chan bool)
 	go func() {
 
 		for msqg := range msgs {
-			var payment map[string]interface{}
-			err := json.Unmarshal(msqg.Body, &payment)
+			var refund map[string]string
+			err := json.Unmarshal(msqg.Body, &refund)
 			if err != nil {
-				log.Errorf("[consumePaymentSuccess-5] Error decoding message: %v", err)
+				log.Errorf("[ConsumePaymentRefund-6] Error decoding message: %v", err)
 				continue
 			}
 
-			pm, ok := payment["paymentMethod"].(string)
-			if !ok || pm == "" {
-				log.Errorf("[consumePaymentSuccess-6] Invalid or missing paymentMethod: %v", payment["paymentMethod"])
+			orderIDStr := refund["orderID"]
+			refundAmount, err := strconv.ParseFloat(refund["refundAmount"], 64)
+			if orderIDStr == "" || err != nil {
+				log.Errorf("[ConsumePaymentRefund-7] Invalid refund message: %v", refund)
 				continue
 			}
 
 			updateScript := map[string]interface{}{
 				"script": map[string]interface{}{
-					"source": "ctx._source.PaymentMethod = params.payment_method",
+					"source": "ctx._source.payment_status = params.payment_status; if (ctx._source.refunded_amount == null) { ctx._source.refunded_amount = 0 } ctx._source.refunded_amount += params.refund_amount",
 					"lang":   "painless",
 					"params": map[string]interface{}{
-						"payment_method": pm,
+						"payment_status": refund["paymentStatus"],
+						"refund_amount":  int64(math.Round(refundAmount)),
 					},
 				},
 			}
 
-			paymentJson, err := json.Marshal(updateScript)
+			refundJson, err := json.Marshal(updateScript)
 			if err != nil {
-				log.Errorf("[consumePaymentSuccess-7] Error encoding payment to JSON: %v", err)
+				log.Errorf("[ConsumePaymentRefund-8] Error encoding refund to JSON: %v", err)
 				continue
 			}
 
-			orderIDStr, ok := payment["orderID"].(string)
-			if !ok {
-				log.Errorf("[consumePaymentSuccess-7] Invalid order ID format: %v", payment["orderID"])
-				continue
-			}
-
-			res, err := esClient.Update("orders", orderIDStr, bytes.NewReader(paymentJson))
+			res, err := esClient.Update("orders", orderIDStr, bytes.NewReader(refundJson))
 			if err != nil {
-				log.Errorf("[consumePaymentSuccess-8] Failed to update payment method in Elasticsearch: %v", err)
+				log.Errorf("[ConsumePaymentRefund-9] Failed to update refund in Elasticsearch: %v", err)
+				continue
 			}
-			defer res.Body.Close()
 			bodyBytes, _ := io.ReadAll(res.Body)
-			log.Infof("[consumePaymentSuccess-9] Elasticsearch response: %s", string(bodyBytes))
+			res.Body.Close()
+			log.Infof("[ConsumePaymentRefund-10] Elasticsearch response: %s", string(bodyBytes))
 		}
 	}()
 
-	log.Infof("[consumePaymentSuccess-8] Waiting for messages. To exit press CTRL+C")
+	log.Infof("[ConsumePaymentRefund-11] Waiting for messages. To exit press CTRL+C")
 	<-forever
 }
 
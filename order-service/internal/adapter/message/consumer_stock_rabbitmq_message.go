@@ -0,0 +1,132 @@
+package message
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"order-service/config"
+	"order-service/internal/adapter/repository"
+	"order-service/internal/core/domain/entity"
+	"order-service/utils"
+	"time"
+
+	"github.com/labstack/gommon/log"
+)
+
+// ConsumeStockFailed membatalkan order Pending yang stok produknya tidak mencukupi saat diproses product-service.
+// Stok item lain yang sudah terpotong dikembalikan lewat event cancellation.
+func ConsumeStockFailed() {
+	cfg := config.NewConfig()
+	conn, err := cfg.NewRabbitMQ()
+	if err != nil {
+		log.Fatalf("[ConsumeStockFailed-1] Failed to connect to RabbitMQ: %v", err)
+	}
+
+	defer conn.Close()
+
+	ch, err := conn.Channel()
+	if err != nil {
+		log.Fatalf("[ConsumeStockFailed-2] Failed to open a channel: %v", err)
+	}
+
+	defer ch.Close()
+
+	q, err := ch.QueueDeclare(
+		cfg.PublisherName.ProductStockFailed,
+		true,
+		false,
+		false,
+		false,
+		nil,
+	)
+	if err != nil {
+		log.Fatalf("[ConsumeStockFailed-3] Failed to declare queue: %v", err)
+	}
+
+	msgs, err := ch.Consume(
+		q.Name,
+		"",
+		false,
+		false,
+		false,
+		false,
+		nil,
+	)
+	if err != nil {
+		log.Fatalf("[ConsumeStockFailed-4] Failed to register consumer: %v", err)
+	}
+
+	db, err := cfg.ConnectionPostgres()
+	if err != nil {
+		log.Fatalf("[ConsumeStockFailed-5] Failed to connect to database: %v", err)
+	}
+
+	orderRepo := repository.NewOrderRepository(db.DB)
+	publisher := NewPublisherRabbitMQ(cfg)
+
+	log.Infof("RabbitMQ Consumer %s started...", q.Name)
+
+	for msg := range msgs {
+		var stockFailed entity.StockFailedEntity
+		if err := json.Unmarshal(msg.Body, &stockFailed); err != nil {
+			log.Errorf("[ConsumeStockFailed-6] Error decoding message: %v", err)
+			msg.Nack(false, false)
+			continue
+		}
+
+		if err := cancelOrderStockFailed(cfg, orderRepo, publisher, stockFailed); err != nil {
+			log.Errorf("[ConsumeStockFailed-7] order %s: %v", stockFailed.Reference, err)
+			time.Sleep(2 * time.Second)
+			msg.Nack(false, true)
+			continue
+		}
+
+		msg.Ack(false)
+	}
+}
+
+func cancelOrderStockFailed(cfg *config.Config, orderRepo repository.OrderRepositoryInterface, publisher PublishRabbitMQInterface, stockFailed entity.StockFailedEntity) error {
+	ctx := context.Background()
+	order, err := orderRepo.GetOrderByOrderCode(ctx, stockFailed.Reference)
+	if err != nil {
+		if err.Error() == "404" {
+			return nil
+		}
+		return err
+	}
+
+	// Order Confirmed sudah dibayar dan pembatalannya butuh refund, sehingga diserahkan ke admin
+	if order.Status == "Confirmed" {
+		log.Errorf("[cancelOrderStockFailed-1] order %s already paid, %s for product %d needs manual handling", order.OrderCode, stockFailed.Reason, stockFailed.ProductID)
+		return nil
+	}
+
+	// Order yang sudah diproses atau sudah dibatalkan oleh event sebelumnya tidak diubah
+	if order.Status != "Pending" {
+		log.Infof("[cancelOrderStockFailed-2] order %s already %s, skip", order.OrderCode, order.Status)
+		return nil
+	}
+
+	remarks := fmt.Sprintf("%s for product %d", stockFailed.Reason, stockFailed.ProductID)
+	buyerID, newStatus, orderCode, err := orderRepo.UpdateStatus(ctx, entity.OrderEntity{
+		ID:      order.ID,
+		Status:  "Cancelled",
+		Remarks: remarks,
+	})
+	if err != nil {
+		return err
+	}
+
+	publisher.PublishOrderStock(order.OrderItems, "cancellation", orderCode)
+
+	if err := publisher.PublishUpdateStatus(cfg.PublisherName.PublisherUpdateStatus, order.ID, newStatus); err != nil {
+		log.Errorf("[cancelOrderStockFailed-3] order %s: %v", orderCode, err)
+	}
+
+	message := fmt.Sprintf("Hello,\n\nYour order with ID %s has been cancelled: %s.\n\nWe apologize for the inconvenience.", orderCode, remarks)
+	if err := publisher.PublishSendPushNotifUpdateStatus(message, utils.PUSH_NOTIF, buyerID); err != nil {
+		log.Errorf("[cancelOrderStockFailed-4] order %s: %v", orderCode, err)
+	}
+
+	return nil
+}
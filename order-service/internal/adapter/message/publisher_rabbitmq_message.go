@@ -12,7 +12,8 @@ import (
 )
 
 type PublishRabbitMQInterface interface {
-	PublishUpdateStock(productID int64, quantity int64)
+	PublishUpdateStock(productID int64, quantity int64, movementType, reference string)
+	PublishOrderStock(orderItems []entity.OrderItemEntity, movementType, reference string)
 	PublishOrderToQueue(order entity.OrderEntity) error
 	PublishSendEmailUpdateStatus(email, message, queuename string, userID int64) error
 	PublishDeleteOrderFromQueue(orderID int64) error
@@ -307,8 +308,26 @@ func (p *PublishRabbitMQ) PublishOrderToQueue(order entity.OrderEntity) error {
 	return nil
 }
 
+// PublishOrderStock implements PublishRabbitMQInterface.
+// Ledger stok di product-service unik per produk, tipe dan kode order, sehingga kuantitas item
+// dengan produk yang sama dalam satu order dijumlahkan menjadi satu pesan.
+func (p *PublishRabbitMQ) PublishOrderStock(orderItems []entity.OrderItemEntity, movementType, reference string) {
+	productIDs := []int64{}
+	quantities := map[int64]int64{}
+	for _, val := range orderItems {
+		if _, ok := quantities[val.ProductID]; !ok {
+			productIDs = append(productIDs, val.ProductID)
+		}
+		quantities[val.ProductID] += val.Quantity
+	}
+
+	for _, productID := range productIDs {
+		p.PublishUpdateStock(productID, quantities[productID], movementType, reference)
+	}
+}
+
 // PublishUpdateStock implements PublishRabbitMQInterface.
-func (p *PublishRabbitMQ) PublishUpdateStock(productID int64, quantity int64) {
+func (p *PublishRabbitMQ) PublishUpdateStock(productID int64, quantity int64, movementType, reference string) {
 	conn, err := p.cfg.NewRabbitMQ()
 	if err != nil {
 		log.Errorf("[PublishUpdateStock-1] Failed to connect to RabbitMQ: %v", err)
@@ -332,8 +351,10 @@ func (p *PublishRabbitMQ) PublishUpdateStock(productID int64, quantity int64) {
 	}
 
 	order := entity.PublishOrderItemEntity{
-		ProductID: productID,
-		Quantity:  quantity,
+		ProductID:    productID,
+		Quantity:     quantity,
+		MovementType: movementType,
+		Reference:    reference,
 	}
 
 	data, err := json.Marshal(order)
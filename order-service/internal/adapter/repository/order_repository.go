@@ -20,6 +20,8 @@ type OrderRepositoryInterface interface {
 	DeleteOrder(ctx context.Context, orderID int64) error
 
 	GetOrderByOrderCode(ctx context.Context, orderCode string) (*entity.OrderEntity, error)
+	GetCompletedOrderIDByProducts(ctx context.Context, buyerID int64, productIDs []int64) (int64, error)
+	CountRecentOrders(ctx context.Context, order entity.OrderEntity, since time.Time) (*entity.OrderVelocityEntity, error)
 }
 
 type orderRepository struct {
@@ -43,9 +45,15 @@ func (o *orderRepository) GetOrderByOrderCode(ctx context.Context, orderCode str
 	orderItemEntities := []entity.OrderItemEntity{}
 	for _, item := range modelOrder.OrderItems {
 		orderItemEntities = append(orderItemEntities, entity.OrderItemEntity{
-			ID:        item.ID,
-			ProductID: item.ProductID,
-			Quantity:  item.Quantity,
+			ID:           item.ID,
+			ProductID:    item.ProductID,
+			SKU:          item.SKU,
+			VariantLabel: item.VariantLabel,
+			Quantity:     item.Quantity,
+			Price:        int64(item.Price),
+			Subtotal:     int64(item.Subtotal),
+			TaxRate:      item.TaxRate,
+			TaxAmount:    int64(item.TaxAmount),
 		})
 	}
 
@@ -60,6 +68,8 @@ func (o *orderRepository) GetOrderByOrderCode(ctx context.Context, orderCode str
 		Remarks:      modelOrder.Remarks,
 		ShippingType: modelOrder.ShippingType,
 		ShippingFee:  int64(modelOrder.ShippingFee),
+		TaxAmount:    int64(modelOrder.TaxAmount),
+		TaxInclusive: modelOrder.TaxInclusive,
 	}, nil
 }
 
@@ -74,8 +84,14 @@ func (o *orderRepository) CreateOrder(ctx context.Context, req entity.OrderEntit
 	var orderItems []model.OrderItem
 	for _, item := range req.OrderItems {
 		orderItem := model.OrderItem{
-			ProductID: item.ProductID,
-			Quantity:  item.Quantity,
+			ProductID:    item.ProductID,
+			SKU:          item.SKU,
+			VariantLabel: item.VariantLabel,
+			Quantity:     item.Quantity,
+			Price:        float64(item.Price),
+			Subtotal:     float64(item.Subtotal),
+			TaxRate:      item.TaxRate,
+			TaxAmount:    float64(item.TaxAmount),
 		}
 		orderItems = append(orderItems, orderItem)
 	}
@@ -89,7 +105,11 @@ func (o *orderRepository) CreateOrder(ctx context.Context, req entity.OrderEntit
 		TotalAmount:  float64(req.TotalAmount),
 		ShippingType: req.ShippingType,
 		ShippingFee:  float64(req.ShippingFee),
+		TaxAmount:    float64(req.TaxAmount),
+		TaxInclusive: req.TaxInclusive,
 		Remarks:      req.Remarks,
+		ClientIP:     req.ClientIP,
+		BuyerPhone:   req.BuyerPhone,
 		OrderItems:   orderItems,
 	}
 
@@ -101,6 +121,37 @@ func (o *orderRepository) CreateOrder(ctx context.Context, req entity.OrderEntit
 	return modelOrder.ID, nil
 }
 
+// CountRecentOrders implements OrderRepositoryInterface.
+// Order yang sudah dihapus tetap dihitung karena tetap merupakan percobaan order
+func (o *orderRepository) CountRecentOrders(ctx context.Context, order entity.OrderEntity, since time.Time) (*entity.OrderVelocityEntity, error) {
+	result := entity.OrderVelocityEntity{}
+
+	velocities := []struct {
+		column string
+		value  interface{}
+		count  *int64
+	}{
+		{"buyer_id", order.BuyerId, &result.ByUser},
+		{"client_ip", order.ClientIP, &result.ByIP},
+		{"buyer_phone", order.BuyerPhone, &result.ByPhone},
+	}
+	for _, velocity := range velocities {
+		if velocity.value == "" {
+			continue
+		}
+
+		err := o.db.WithContext(ctx).Unscoped().Model(&model.Order{}).
+			Where(velocity.column+" = ? AND id <> ? AND created_at >= ?", velocity.value, order.ID, since).
+			Count(velocity.count).Error
+		if err != nil {
+			log.Errorf("[OrderRepository-1] CountRecentOrders: %v", err)
+			return nil, err
+		}
+	}
+
+	return &result, nil
+}
+
 // DeleteOrder implements OrderRepositoryInterface.
 func (o *orderRepository) DeleteOrder(ctx context.Context, orderID int64) error {
 	modelOrder := model.Order{}
@@ -203,9 +254,15 @@ func (o *orderRepository) GetAll(ctx context.Context, queryString entity.QuerySt
 		orderItemEntities := []entity.OrderItemEntity{}
 		for _, item := range val.OrderItems {
 			orderItemEntities = append(orderItemEntities, entity.OrderItemEntity{
-				ID:        item.ID,
-				ProductID: item.ProductID,
-				Quantity:  item.Quantity,
+				ID:           item.ID,
+				ProductID:    item.ProductID,
+				SKU:          item.SKU,
+				VariantLabel: item.VariantLabel,
+				Quantity:     item.Quantity,
+				Price:        int64(item.Price),
+				Subtotal:     int64(item.Subtotal),
+				TaxRate:      item.TaxRate,
+				TaxAmount:    int64(item.TaxAmount),
 			})
 		}
 		entities = append(entities, entity.OrderEntity{
@@ -214,6 +271,7 @@ func (o *orderRepository) GetAll(ctx context.Context, queryString entity.QuerySt
 			Status:      val.Status,
 			OrderDate:   val.OrderDate.Format("2006-01-02 15:04:05"),
 			TotalAmount: int64(val.TotalAmount),
+			TaxAmount:   int64(val.TaxAmount),
 			OrderItems:  orderItemEntities,
 			BuyerId:     val.BuyerId,
 		})
@@ -239,9 +297,15 @@ func (o *orderRepository) GetByID(ctx context.Context, orderID int64) (*entity.O
 	orderItemEntities := []entity.OrderItemEntity{}
 	for _, item := range modelOrder.OrderItems {
 		orderItemEntities = append(orderItemEntities, entity.OrderItemEntity{
-			ID:        item.ID,
-			ProductID: item.ProductID,
-			Quantity:  item.Quantity,
+			ID:           item.ID,
+			ProductID:    item.ProductID,
+			SKU:          item.SKU,
+			VariantLabel: item.VariantLabel,
+			Quantity:     item.Quantity,
+			Price:        int64(item.Price),
+			Subtotal:     int64(item.Subtotal),
+			TaxRate:      item.TaxRate,
+			TaxAmount:    int64(item.TaxAmount),
 		})
 	}
 
@@ -256,9 +320,37 @@ func (o *orderRepository) GetByID(ctx context.Context, orderID int64) (*entity.O
 		Remarks:      modelOrder.Remarks,
 		ShippingType: modelOrder.ShippingType,
 		ShippingFee:  int64(modelOrder.ShippingFee),
+		TaxAmount:    int64(modelOrder.TaxAmount),
+		TaxInclusive: modelOrder.TaxInclusive,
+		ClientIP:     modelOrder.ClientIP,
+		BuyerPhone:   modelOrder.BuyerPhone,
 	}, nil
 }
 
+// GetCompletedOrderIDByProducts implements OrderRepositoryInterface.
+// Mengembalikan order terbaru berstatus Done milik buyer yang berisi salah satu produk.
+func (o *orderRepository) GetCompletedOrderIDByProducts(ctx context.Context, buyerID int64, productIDs []int64) (int64, error) {
+	var modelOrder model.Order
+
+	err := o.db.WithContext(ctx).
+		Joins("JOIN order_items ON order_items.order_id = orders.id AND order_items.deleted_at IS NULL").
+		Where("orders.buyer_id = ? AND orders.status = ? AND order_items.product_id IN ?", buyerID, "Done", productIDs).
+		Order("orders.order_date DESC").
+		Select("orders.id").
+		First(&modelOrder).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			err = errors.New("404")
+			log.Infof("[OrderRepository-1] GetCompletedOrderIDByProducts: Order not found")
+			return 0, err
+		}
+		log.Errorf("[OrderRepository-2] GetCompletedOrderIDByProducts: %v", err)
+		return 0, err
+	}
+
+	return modelOrder.ID, nil
+}
+
 func NewOrderRepository(db *gorm.DB) OrderRepositoryInterface {
 	return &orderRepository{db: db}
 }
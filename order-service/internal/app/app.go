@@ -9,8 +9,10 @@ import (
 	"order-service/internal/adapter/repository"
 	"order-service/internal/core/service"
 	"order-service/utils/validator"
+	"net"
 	"os"
 	"os/signal"
+	"strings"
 	"syscall"
 	"time"
 
@@ -45,6 +47,7 @@ func RunServer() {
 
 	e := echo.New()
 	e.Use(middleware.CORS())
+	e.IPExtractor = newIPExtractor(cfg.App.TrustedProxies)
 
 	customValidator := validator.NewValidator()
 	en.RegisterDefaultTranslations(customValidator.Validator, customValidator.Translator)
@@ -79,3 +82,27 @@ func RunServer() {
 
 	e.Shutdown(ctx)
 }
+
+// newIPExtractor menentukan IP client yang disimpan pada order untuk pengecekan fraud di payment-service.
+// Header X-Forwarded-For hanya dibaca jika request datang dari proxy pada TRUSTED_PROXIES.
+func newIPExtractor(trustedProxies string) echo.IPExtractor {
+	if trustedProxies == "" {
+		return echo.ExtractIPDirect()
+	}
+
+	options := []echo.TrustOption{
+		echo.TrustLoopback(false),
+		echo.TrustLinkLocal(false),
+		echo.TrustPrivateNet(false),
+	}
+	for _, val := range strings.Split(trustedProxies, ",") {
+		_, ipRange, err := net.ParseCIDR(strings.TrimSpace(val))
+		if err != nil {
+			log.Errorf("[newIPExtractor-1] invalid trusted proxy %s: %v", val, err)
+			continue
+		}
+		options = append(options, echo.TrustIPRange(ipRange))
+	}
+
+	return echo.ExtractIPFromXFFHeader(options...)
+}
@@ -12,6 +12,8 @@ type OrderEntity struct {
 	PaymentMethod string            `json:"payment_method"`
 	ShippingType  string            `json:"shipping_type"`
 	ShippingFee   int64             `json:"shipping_fee"`
+	TaxAmount     int64             `json:"tax_amount"`
+	TaxInclusive  bool              `json:"tax_inclusive"`
 	OrderTime     string            `json:"order_time"`
 	Remarks       string            `json:"remarks"`
 	CreatedAt     time.Time         `json:"created_at"`
@@ -22,6 +24,14 @@ type OrderEntity struct {
 	BuyerAddress  string            `json:"buyer_address"`
 	BuyerLat      string            `json:"buyer_lat"`
 	BuyerLng      string            `json:"buyer_lng"`
+	ClientIP      string            `json:"-"`
+}
+
+// OrderVelocityEntity jumlah order terbaru per pembeli, IP dan nomor telepon untuk rule fraud
+type OrderVelocityEntity struct {
+	ByUser  int64 `json:"by_user"`
+	ByIP    int64 `json:"by_ip"`
+	ByPhone int64 `json:"by_phone"`
 }
 
 type QueryStringEntity struct {
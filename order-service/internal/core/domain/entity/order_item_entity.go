@@ -1,19 +1,34 @@
 package entity
 
 type OrderItemEntity struct {
-	ID            int64  `json:"id"`
-	OrderID       int64  `json:"order_id"`
-	ProductID     int64  `json:"product_id"`
-	Quantity      int64  `json:"quantity"`
-	OrderCode     string `json:"order_code"`
-	ProductName   string `json:"product_name"`
-	ProductImage  string `json:"product_image"`
-	Price         int64  `json:"price"`
-	ProductUnit   string `json:"product_unit"`
-	ProductWeight int64  `json:"product_weight"`
+	ID            int64   `json:"id"`
+	OrderID       int64   `json:"order_id"`
+	ProductID     int64   `json:"product_id"`
+	VariantID     int64   `json:"variant_id"`
+	SKU           string  `json:"sku"`
+	VariantLabel  string  `json:"variant_label"`
+	Quantity      int64   `json:"quantity"`
+	OrderCode     string  `json:"order_code"`
+	ProductName   string  `json:"product_name"`
+	ProductImage  string  `json:"product_image"`
+	Price         int64   `json:"price"`
+	Subtotal      int64   `json:"subtotal"`
+	TaxRate       float64 `json:"tax_rate"`
+	TaxAmount     int64   `json:"tax_amount"`
+	ProductUnit   string  `json:"product_unit"`
+	ProductWeight int64   `json:"product_weight"`
 }
 
 type PublishOrderItemEntity struct {
-	ProductID int64 `json:"product_id"`
-	Quantity  int64 `json:"quantity"`
+	ProductID    int64  `json:"product_id"`
+	Quantity     int64  `json:"quantity"`
+	MovementType string `json:"movement_type"`
+	Reference    string `json:"reference"`
+}
+
+type StockFailedEntity struct {
+	ProductID int64  `json:"product_id"`
+	Quantity  int64  `json:"quantity"`
+	Reference string `json:"reference"`
+	Reason    string `json:"reason"`
 }
@@ -8,19 +8,23 @@ type ProductHttpClientResponse struct {
 }
 
 type ChildProductResponseEntity struct {
-	ID           int     `json:"id"`
-	Weight       int     `json:"weight"`
-	Stock        int     `json:"stock"`
-	RegulerPrice float64 `json:"reguler_price"`
-	SalePrice    float64 `json:"sale_price"`
-	Unit         string  `json:"unit"`
-	Image        string  `json:"image"`
+	ID           int      `json:"id"`
+	SKU          string   `json:"sku"`
+	OptionValues []string `json:"option_values"`
+	Weight       int      `json:"weight"`
+	Stock        int      `json:"stock"`
+	RegulerPrice float64  `json:"reguler_price"`
+	SalePrice    float64  `json:"sale_price"`
+	Unit         string   `json:"unit"`
+	Image        string   `json:"image"`
 }
 
 type ProductResponseEntity struct {
 	ID            int                          `json:"id"`
 	ProductName   string                       `json:"product_name"`
 	ParentID      int                          `json:"parent_id"`
+	SKU           string                       `json:"sku"`
+	OptionValues  []string                     `json:"option_values"`
 	ProductImage  string                       `json:"product_image"`
 	CategoryName  string                       `json:"category_name"`
 	ProductStatus string                       `json:"product_status"`
@@ -30,5 +34,6 @@ type ProductResponseEntity struct {
 	Unit          string                       `json:"unit"`
 	Weight        int                          `json:"weight"`
 	Stock         int                          `json:"stock"`
+	TaxRate       float64                      `json:"tax_rate"`
 	Child         []ChildProductResponseEntity `json:"child"`
 }
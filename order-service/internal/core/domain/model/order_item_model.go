@@ -7,12 +7,18 @@ import (
 )
 
 type OrderItem struct {
-	ID        int64          `gorm:"primaryKey"`
-	OrderID   int64          `gorm:"column:order_id;not null;references:orders.id;onDelete:CASCADE"`
-	ProductID int64          `gorm:"column:product_id;not null"` // You might have a Product struct
-	Quantity  int64          `gorm:"column:quantity;not null;default:1"`
-	CreatedAt time.Time      `gorm:"column:created_at;default:CURRENT_TIMESTAMP"`
-	UpdatedAt *time.Time     `gorm:"column:updated_at"`
-	DeletedAt gorm.DeletedAt `gorm:"column:deleted_at;index"`
-	Order     Order          `gorm:"constraint:OnUpdate:CASCADE,OnDelete:SET NULL;"`
+	ID           int64          `gorm:"primaryKey"`
+	OrderID      int64          `gorm:"column:order_id;not null;references:orders.id;onDelete:CASCADE"`
+	ProductID    int64          `gorm:"column:product_id;not null"` // You might have a Product struct
+	SKU          string         `gorm:"column:sku;size:64"`
+	VariantLabel string         `gorm:"column:variant_label;size:255"`
+	Quantity     int64          `gorm:"column:quantity;not null;default:1"`
+	Price        float64        `gorm:"column:price;not null;default:0"`
+	Subtotal     float64        `gorm:"column:subtotal;not null;default:0"`
+	TaxRate      float64        `gorm:"column:tax_rate;not null;default:0"`
+	TaxAmount    float64        `gorm:"column:tax_amount;not null;default:0"`
+	CreatedAt    time.Time      `gorm:"column:created_at;default:CURRENT_TIMESTAMP"`
+	UpdatedAt    *time.Time     `gorm:"column:updated_at"`
+	DeletedAt    gorm.DeletedAt `gorm:"column:deleted_at;index"`
+	Order        Order          `gorm:"constraint:OnUpdate:CASCADE,OnDelete:SET NULL;"`
 }
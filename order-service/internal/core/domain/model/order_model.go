@@ -15,8 +15,12 @@ type Order struct {
 	TotalAmount  float64        `gorm:"column:total_amount;not null;default:0"`
 	ShippingType string         `gorm:"column:shipping_type;not null;default:'PICKUP';size:20"`
 	ShippingFee  float64        `gorm:"column:shipping_fee;not null;default:0"`
+	TaxAmount    float64        `gorm:"column:tax_amount;not null;default:0"`
+	TaxInclusive bool           `gorm:"column:tax_inclusive;not null;default:false"`
 	OrderTime    string         `gorm:"column:order_time"`
 	Remarks      string         `gorm:"column:remarks"`
+	ClientIP     string         `gorm:"column:client_ip;size:45"`
+	BuyerPhone   string         `gorm:"column:buyer_phone;size:20"`
 	CreatedAt    time.Time      `gorm:"column:created_at;default:CURRENT_TIMESTAMP"`
 	UpdatedAt    *time.Time     `gorm:"column:updated_at"`
 	DeletedAt    gorm.DeletedAt `gorm:"column:deleted_at;index"`
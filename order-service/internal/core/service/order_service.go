@@ -3,6 +3,7 @@ package service
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"order-service/config"
@@ -12,7 +13,10 @@ import (
 	"order-service/internal/core/domain/entity"
 	"order-service/utils"
 	"order-service/utils/conv"
+	"order-service/utils/tax"
 	"strconv"
+	"strings"
+	"time"
 
 	"github.com/labstack/gommon/log"
 )
@@ -27,6 +31,8 @@ type OrderServiceInterface interface {
 	DeleteByID(ctx context.Context, orderID int64) error
 	GetOrderByOrderCode(ctx context.Context, orderCode, accessToken string) (*entity.OrderEntity, error)
 	GetPublicOrderIDByOrderCode(ctx context.Context, orderCode string) (int64, error)
+	GetCompletedOrderIDByProducts(ctx context.Context, buyerID int64, productIDs []int64) (int64, error)
+	GetOrderVelocity(ctx context.Context, orderID, buyerID int64, since time.Time) (*entity.OrderVelocityEntity, error)
 }
 
 type orderService struct {
@@ -37,6 +43,34 @@ type orderService struct {
 	elasticRepo       repository.ElasticRepositoryInterface
 }
 
+// GetOrderVelocity implements OrderServiceInterface.
+// Menghitung order lain dari pembeli, IP dan nomor telepon yang sama sejak waktu tertentu
+func (o *orderService) GetOrderVelocity(ctx context.Context, orderID, buyerID int64, since time.Time) (*entity.OrderVelocityEntity, error) {
+	result, err := o.repo.GetByID(ctx, orderID)
+	if err != nil {
+		log.Errorf("[OrderService-1] GetOrderVelocity: %v", err)
+		return nil, err
+	}
+
+	if result.BuyerId != buyerID {
+		log.Infof("[OrderService-2] GetOrderVelocity: order %d does not belong to buyer %d", orderID, buyerID)
+		return nil, errors.New("404")
+	}
+
+	return o.repo.CountRecentOrders(ctx, *result, since)
+}
+
+// GetCompletedOrderIDByProducts implements OrderServiceInterface.
+func (o *orderService) GetCompletedOrderIDByProducts(ctx context.Context, buyerID int64, productIDs []int64) (int64, error) {
+	orderID, err := o.repo.GetCompletedOrderIDByProducts(ctx, buyerID, productIDs)
+	if err != nil {
+		log.Errorf("[OrderService-1] GetCompletedOrderIDByProducts: %v", err)
+		return 0, err
+	}
+
+	return orderID, nil
+}
+
 // GetPublicOrderIDByOrderCode implements OrderServiceInterface.
 func (o *orderService) GetPublicOrderIDByOrderCode(ctx context.Context, orderCode string) (int64, error) {
 	result, err := o.repo.GetOrderByOrderCode(ctx, orderCode)
@@ -88,7 +122,9 @@ func (o *orderService) GetOrderByOrderCode(ctx context.Context, orderCode string
 
 		result.OrderItems[key].ProductImage = productResponse.ProductImage
 		result.OrderItems[key].ProductName = productResponse.ProductName
-		result.OrderItems[key].Price = int64(productResponse.SalePrice)
+		if val.Price == 0 {
+			result.OrderItems[key].Price = int64(productResponse.SalePrice)
+		}
 	}
 
 	return result, nil
@@ -149,7 +185,9 @@ func (o *orderService) GetDetailCustomer(ctx context.Context, orderID int64, acc
 			result.OrderItems[key].ProductImage = productResponse.Child[0].Image
 		}
 		result.OrderItems[key].ProductName = productResponse.ProductName
-		result.OrderItems[key].Price = int64(productResponse.SalePrice)
+		if val.Price == 0 {
+			result.OrderItems[key].Price = int64(productResponse.SalePrice)
+		}
 		result.OrderItems[key].ProductWeight = int64(productResponse.Weight)
 		result.OrderItems[key].ProductUnit = productResponse.Unit
 	}
@@ -201,7 +239,9 @@ func (o *orderService) GetAllCustomer(ctx context.Context, queryString entity.Qu
 
 			val.OrderItems[key2].ProductImage = productResponse.ProductImage
 			val.OrderItems[key2].ProductName = productResponse.ProductName
-			val.OrderItems[key2].Price = int64(productResponse.SalePrice)
+			if res.Price == 0 {
+				val.OrderItems[key2].Price = int64(productResponse.SalePrice)
+			}
 			val.OrderItems[key2].Quantity = res.Quantity
 			val.OrderItems[key2].ProductUnit = productResponse.Unit
 			val.OrderItems[key2].ProductWeight = int64(productResponse.Weight)
@@ -236,6 +276,17 @@ func (o *orderService) UpdateStatus(ctx context.Context, req entity.OrderEntity,
 	go o.publisherRabbitMQ.PublishSendPushNotifUpdateStatus(message, utils.PUSH_NOTIF, buyerID)
 	go o.publisherRabbitMQ.PublishUpdateStatus(o.cfg.PublisherName.PublisherUpdateStatus, req.ID, req.Status)
 
+	if statusOrder == "Cancelled" {
+		order, err := o.repo.GetOrderByOrderCode(ctx, orderCode)
+		if err != nil {
+			log.Errorf("[OrderService-4] UpdateStatus: %v", err)
+			return nil
+		}
+
+		// Stok dikembalikan ke product-service, pembatalan ganda diabaikan oleh ledger stok
+		o.publisherRabbitMQ.PublishOrderStock(order.OrderItems, "cancellation", orderCode)
+	}
+
 	return nil
 }
 
@@ -248,24 +299,83 @@ func (o *orderService) CreateOrder(ctx context.Context, req entity.OrderEntity,
 	}
 	req.ShippingFee = int64(shippingFee)
 	req.Status = "Pending"
-	orderID, err := o.repo.CreateOrder(ctx, req)
+
+	var token map[string]interface{}
+	err := json.Unmarshal([]byte(accessToken), &token)
 	if err != nil {
 		log.Errorf("[OrderService-1] CreateOrder: %v", err)
 		return 0, err
 	}
 
+	isCustomer := false
+	if token["role_name"].(string) != "Super Admin" {
+		isCustomer = true
+	}
+
+	var taxAmount int64
+	for key, val := range req.OrderItems {
+		productResponse, err := o.httpClientProductService(val.ProductID, token["token"].(string), isCustomer)
+		if err != nil {
+			log.Errorf("[OrderService-2] CreateOrder: %v", err)
+			return 0, err
+		}
+
+		// Varian adalah baris produk child sehingga harga, stok dan SKU diambil dari baris varian
+		if val.VariantID != 0 && val.VariantID != val.ProductID {
+			productResponse, err = o.httpClientProductService(val.VariantID, token["token"].(string), isCustomer)
+			if err != nil {
+				log.Errorf("[OrderService-3] CreateOrder: %v", err)
+				return 0, err
+			}
+
+			if int64(productResponse.ParentID) != val.ProductID {
+				log.Errorf("[OrderService-4] CreateOrder: variant %d does not belong to product %d", val.VariantID, val.ProductID)
+				return 0, errors.New("Variant does not belong to product")
+			}
+			req.OrderItems[key].ProductID = val.VariantID
+		}
+		req.OrderItems[key].SKU = productResponse.SKU
+		req.OrderItems[key].VariantLabel = strings.Join(productResponse.OptionValues, " / ")
+
+		price := int64(productResponse.SalePrice)
+		subtotal, lineTax := tax.CalculateLine(price, val.Quantity, productResponse.TaxRate, o.cfg.App.TaxInclusive)
+		req.OrderItems[key].Price = price
+		req.OrderItems[key].Subtotal = subtotal
+		req.OrderItems[key].TaxRate = productResponse.TaxRate
+		req.OrderItems[key].TaxAmount = lineTax
+		taxAmount += lineTax
+	}
+
+	req.TaxAmount = taxAmount
+	req.TaxInclusive = o.cfg.App.TaxInclusive
+	if !req.TaxInclusive {
+		req.TotalAmount += taxAmount
+	}
+
+	// Nomor telepon pembeli disimpan pada order untuk velocity check fraud di payment-service
+	userResponse, err := o.httpClientUserService(req.BuyerId, token["token"].(string), isCustomer)
+	if err != nil {
+		log.Errorf("[OrderService-5] CreateOrder: %v", err)
+	} else {
+		req.BuyerPhone = userResponse.Phone
+	}
+
+	orderID, err := o.repo.CreateOrder(ctx, req)
+	if err != nil {
+		log.Errorf("[OrderService-6] CreateOrder: %v", err)
+		return 0, err
+	}
+
 	resultData, err := o.GetByID(ctx, orderID, accessToken)
 	if err != nil {
-		log.Errorf("[OrderService-2] CreateOrder: %v", err)
+		log.Errorf("[OrderService-7] CreateOrder: %v", err)
 	}
 
 	if err := o.publisherRabbitMQ.PublishOrderToQueue(*resultData); err != nil {
-		log.Errorf("[OrderService-3] CreateOrder: %v", err)
+		log.Errorf("[OrderService-8] CreateOrder: %v", err)
 	}
 
-	for _, orderItem := range req.OrderItems {
-		o.publisherRabbitMQ.PublishUpdateStock(orderItem.ProductID, orderItem.Quantity)
-	}
+	o.publisherRabbitMQ.PublishOrderStock(req.OrderItems, "sale", req.OrderCode)
 
 	return orderID, nil
 }
@@ -310,7 +420,9 @@ func (o *orderService) GetByID(ctx context.Context, orderID int64, accessToken s
 
 		result.OrderItems[key].ProductImage = productResponse.ProductImage
 		result.OrderItems[key].ProductName = productResponse.ProductName
-		result.OrderItems[key].Price = int64(productResponse.SalePrice)
+		if val.Price == 0 {
+			result.OrderItems[key].Price = int64(productResponse.SalePrice)
+		}
 	}
 
 	return result, nil
@@ -0,0 +1,19 @@
+package tax
+
+import "math"
+
+// CalculateLine menghitung subtotal dan pajak satu baris order dengan tarif dalam persen.
+// Harga inclusive sudah termasuk pajak, harga exclusive dikenakan pajak di atas subtotal.
+func CalculateLine(price, quantity int64, rate float64, inclusive bool) (int64, int64) {
+	subtotal := price * quantity
+	if rate <= 0 {
+		return subtotal, 0
+	}
+
+	if inclusive {
+		base := float64(subtotal) / (1 + rate/100)
+		return subtotal, int64(math.Round(float64(subtotal) - base))
+	}
+
+	return subtotal, int64(math.Round(float64(subtotal) * rate / 100))
+}
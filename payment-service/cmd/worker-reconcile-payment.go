@@ -0,0 +1,21 @@
+package cmd
+
+import (
+	"fmt"
+	"payment-service/internal/app"
+
+	"github.com/spf13/cobra"
+)
+
+var workerReconcilePaymentCmd = &cobra.Command{
+	Use:   "worker-reconcile-payment",
+	Short: "Menjalankan worker untuk rekonsiliasi status payment ke payment gateway",
+	Run: func(cmd *cobra.Command, args []string) {
+		fmt.Println("Worker untuk rekonsiliasi payment sedang berjalan...")
+		app.RunReconcileWorker()
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(workerReconcilePaymentCmd)
+}
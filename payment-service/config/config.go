@@ -12,6 +12,15 @@ type App struct {
 	ProductServiceUrl string `json:"product_service_url"`
 	UserServiceUrl    string `json:"user_service_url"`
 	OrderServiceUrl   string `json:"order_service_url"`
+
+	ReconcileInterval int `json:"reconcile_interval"`
+	ReconcileLookback int `json:"reconcile_lookback"`
+
+	// TrustedProxies berisi CIDR proxy yang dipercaya, dipisahkan koma
+	TrustedProxies string `json:"trusted_proxies"`
+
+	WebhookRetryInterval int `json:"webhook_retry_interval"`
+	WebhookMaxAttempts   int `json:"webhook_max_attempts"`
 }
 
 type PsqlDB struct {
@@ -37,12 +46,65 @@ type Redis struct {
 }
 
 type Midtrans struct {
-	ServerKey   string `json:"server_key"`
-	Environment int    `json:"environment"`
+	ServerKey         string `json:"server_key"`
+	Environment       int    `json:"environment"`
+	BaseUrl           string `json:"base_url"`
+	Driver            string `json:"driver"`
+	SandboxWebhookUrl string `json:"sandbox_webhook_url"`
+}
+
+type BankTransfer struct {
+	BankName      string `json:"bank_name"`
+	AccountNumber string `json:"account_number"`
+	AccountName   string `json:"account_name"`
+}
+
+type Supabase struct {
+	URL    string `json:"url"`
+	Key    string `json:"key"`
+	Bucket string `json:"bucket"`
+}
+
+type LocalStorage struct {
+	Path       string `json:"path"`
+	BaseURL    string `json:"base_url"`
+	SigningKey string `json:"signing_key"`
+}
+
+type S3Storage struct {
+	Endpoint  string `json:"endpoint"`
+	Region    string `json:"region"`
+	AccessKey string `json:"access_key"`
+	SecretKey string `json:"secret_key"`
+	Bucket    string `json:"bucket"`
+	PublicURL string `json:"public_url"`
+}
+
+// Fraud berisi batas setiap rule fraud, batas 0 berarti rule tidak dijalankan.
+// Action rule bernilai review atau block.
+type Fraud struct {
+	Enabled             bool    `json:"enabled"`
+	MaxOrdersPerUser    int     `json:"max_orders_per_user"`
+	MaxOrdersPerIP      int     `json:"max_orders_per_ip"`
+	MaxOrdersPerPhone   int     `json:"max_orders_per_phone"`
+	VelocityAction      string  `json:"velocity_action"`
+	ReviewAmount        float64 `json:"review_amount"`
+	BlockAmount         float64 `json:"block_amount"`
+	MinAccountAgeHours  int     `json:"min_account_age_hours"`
+	AccountAgeAction    string  `json:"account_age_action"`
+	MaxFailedPayments   int     `json:"max_failed_payments"`
+	FailedPaymentDays   int     `json:"failed_payment_days"`
+	FailedPaymentAction string  `json:"failed_payment_action"`
 }
 
 type PublisherName struct {
-	PaymentSuccess string `json:"payment_success"`
+	PaymentCreated       string `json:"payment_created"`
+	PaymentSuccess       string `json:"payment_success"`
+	PaymentFailed        string `json:"payment_failed"`
+	PaymentExpired       string `json:"payment_expired"`
+	PaymentRefunded      string `json:"payment_refunded"`
+	EmailPaymentRefunded string `json:"email_payment_refunded"`
+	PushNotif            string `json:"push_notif"`
 }
 
 type Config struct {
@@ -51,6 +113,12 @@ type Config struct {
 	RabbitMQ      RabbitMQ      `json:"rabbitmq"`
 	Redis         Redis         `json:"redis"`
 	Midtrans      Midtrans      `json:"midtrans"`
+	BankTransfer  BankTransfer  `json:"bank_transfer"`
+	StorageDriver string        `json:"storage_driver"`
+	Storage       Supabase      `json:"storage"`
+	LocalStorage  LocalStorage  `json:"local_storage"`
+	S3Storage     S3Storage     `json:"s3_storage"`
+	Fraud         Fraud         `json:"fraud"`
 	PublisherName PublisherName `json:"publisher_name"`
 }
 
@@ -65,6 +133,14 @@ func NewConfig() *Config {
 			ProductServiceUrl: viper.GetString("PRODUCT_SERVICE_URL"),
 			UserServiceUrl:    viper.GetString("USER_SERVICE_URL"),
 			OrderServiceUrl:   viper.GetString("ORDER_SERVICE_URL"),
+
+			ReconcileInterval: viper.GetInt("RECONCILE_INTERVAL_SECONDS"),
+			ReconcileLookback: viper.GetInt("RECONCILE_LOOKBACK_MINUTES"),
+
+			TrustedProxies: viper.GetString("TRUSTED_PROXIES"),
+
+			WebhookRetryInterval: viper.GetInt("WEBHOOK_RETRY_INTERVAL_SECONDS"),
+			WebhookMaxAttempts:   viper.GetInt("WEBHOOK_MAX_ATTEMPTS"),
 		},
 		Psql: PsqlDB{
 			Host:      viper.GetString("DATABASE_HOST"),
@@ -86,11 +162,58 @@ func NewConfig() *Config {
 			Port: viper.GetString("REDIS_PORT"),
 		},
 		Midtrans: Midtrans{
-			ServerKey:   viper.GetString("MIDTRANS_SERVER_KEY"),
-			Environment: viper.GetInt("MIDTRANS_ENVIRONMENT"),
+			ServerKey:         viper.GetString("MIDTRANS_SERVER_KEY"),
+			Environment:       viper.GetInt("MIDTRANS_ENVIRONMENT"),
+			BaseUrl:           viper.GetString("MIDTRANS_BASE_URL"),
+			Driver:            viper.GetString("MIDTRANS_DRIVER"),
+			SandboxWebhookUrl: viper.GetString("MIDTRANS_SANDBOX_WEBHOOK_URL"),
+		},
+		BankTransfer: BankTransfer{
+			BankName:      viper.GetString("BANK_TRANSFER_BANK_NAME"),
+			AccountNumber: viper.GetString("BANK_TRANSFER_ACCOUNT_NUMBER"),
+			AccountName:   viper.GetString("BANK_TRANSFER_ACCOUNT_NAME"),
+		},
+		Storage: Supabase{
+			URL:    viper.GetString("SUPABASE_STORAGE_URL"),
+			Key:    viper.GetString("SUPABASE_STORAGE_KEY"),
+			Bucket: viper.GetString("SUPABASE_STORAGE_BUCKET"),
+		},
+		StorageDriver: viper.GetString("STORAGE_DRIVER"),
+		LocalStorage: LocalStorage{
+			Path:       viper.GetString("LOCAL_STORAGE_PATH"),
+			BaseURL:    viper.GetString("LOCAL_STORAGE_URL"),
+			SigningKey: viper.GetString("LOCAL_STORAGE_SIGNING_KEY"),
+		},
+		S3Storage: S3Storage{
+			Endpoint:  viper.GetString("S3_ENDPOINT"),
+			Region:    viper.GetString("S3_REGION"),
+			AccessKey: viper.GetString("S3_ACCESS_KEY"),
+			SecretKey: viper.GetString("S3_SECRET_KEY"),
+			Bucket:    viper.GetString("S3_BUCKET"),
+			PublicURL: viper.GetString("S3_PUBLIC_URL"),
+		},
+		Fraud: Fraud{
+			Enabled:             viper.GetBool("FRAUD_ENABLED"),
+			MaxOrdersPerUser:    viper.GetInt("FRAUD_MAX_ORDERS_PER_USER_HOUR"),
+			MaxOrdersPerIP:      viper.GetInt("FRAUD_MAX_ORDERS_PER_IP_HOUR"),
+			MaxOrdersPerPhone:   viper.GetInt("FRAUD_MAX_ORDERS_PER_PHONE_HOUR"),
+			VelocityAction:      viper.GetString("FRAUD_VELOCITY_ACTION"),
+			ReviewAmount:        viper.GetFloat64("FRAUD_REVIEW_AMOUNT"),
+			BlockAmount:         viper.GetFloat64("FRAUD_BLOCK_AMOUNT"),
+			MinAccountAgeHours:  viper.GetInt("FRAUD_MIN_ACCOUNT_AGE_HOURS"),
+			AccountAgeAction:    viper.GetString("FRAUD_ACCOUNT_AGE_ACTION"),
+			MaxFailedPayments:   viper.GetInt("FRAUD_MAX_FAILED_PAYMENTS"),
+			FailedPaymentDays:   viper.GetInt("FRAUD_FAILED_PAYMENT_DAYS"),
+			FailedPaymentAction: viper.GetString("FRAUD_FAILED_PAYMENT_ACTION"),
 		},
 		PublisherName: PublisherName{
-			PaymentSuccess: viper.GetString("PUBLISHER_PAYMENT_SUCCESS"),
+			PaymentCreated:       viper.GetString("PUBLISHER_PAYMENT_CREATED"),
+			PaymentSuccess:       viper.GetString("PUBLISHER_PAYMENT_SUCCESS"),
+			PaymentFailed:        viper.GetString("PUBLISHER_PAYMENT_FAILED"),
+			PaymentExpired:       viper.GetString("PUBLISHER_PAYMENT_EXPIRED"),
+			PaymentRefunded:      viper.GetString("PUBLISHER_PAYMENT_REFUNDED"),
+			EmailPaymentRefunded: viper.GetString("PUBLISHER_EMAIL_PAYMENT_REFUNDED"),
+			PushNotif:            viper.GetString("PUBLISHER_PUSH_NOTIF"),
 		},
 	}
 }
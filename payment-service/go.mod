@@ -7,6 +7,7 @@ require (
 	github.com/labstack/gommon v0.4.2
 	github.com/spf13/viper v1.20.1
 	gorm.io/gorm v1.25.10
+	shared v0.0.0
 )
 
 require (
@@ -50,6 +51,7 @@ require (
 	github.com/spf13/pflag v1.0.6 // indirect
 	github.com/streadway/amqp v1.1.0
 	github.com/subosito/gotenv v1.6.0 // indirect
+	github.com/supabase-community/storage-go v0.7.0 // indirect
 	go.uber.org/atomic v1.9.0 // indirect
 	go.uber.org/multierr v1.9.0 // indirect
 	golang.org/x/sys v0.30.0 // indirect
@@ -57,3 +59,6 @@ require (
 	gopkg.in/yaml.v3 v3.0.1 // indirect
 	gorm.io/driver/postgres v1.5.11
 )
+
+// Paket bersama antar service, lihat folder shared di root repository
+replace shared => ../shared
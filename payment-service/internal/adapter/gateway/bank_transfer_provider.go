@@ -0,0 +1,58 @@
+package gateway
+
+import (
+	"context"
+	"fmt"
+	"payment-service/config"
+	"payment-service/internal/core/domain/entity"
+)
+
+// bankTransferProvider tidak memiliki API, status dikonfirmasi admin lewat bukti transfer yang diupload customer.
+type bankTransferProvider struct {
+	cfg *config.Config
+}
+
+// Name implements ProviderInterface.
+func (b *bankTransferProvider) Name() string {
+	return "bank_transfer"
+}
+
+// CreateTransaction implements ProviderInterface.
+func (b *bankTransferProvider) CreateTransaction(ctx context.Context, payment entity.PaymentEntity, customer entity.ProfileHttpResponse) (*entity.GatewayTransactionEntity, error) {
+	instructions := fmt.Sprintf("Transfer Rp %.0f ke rekening %s %s a.n. %s dengan berita %s, lalu upload bukti transfer.",
+		payment.GrossAmount,
+		b.cfg.BankTransfer.BankName,
+		b.cfg.BankTransfer.AccountNumber,
+		b.cfg.BankTransfer.AccountName,
+		payment.OrderCode,
+	)
+
+	return &entity.GatewayTransactionEntity{
+		PaymentStatus: "Pending",
+		Instructions:  instructions,
+	}, nil
+}
+
+// GetStatus implements ProviderInterface.
+func (b *bankTransferProvider) GetStatus(ctx context.Context, orderCode string) (*entity.GatewayNotificationEntity, error) {
+	return nil, ErrNotSupported
+}
+
+// Cancel implements ProviderInterface.
+func (b *bankTransferProvider) Cancel(ctx context.Context, orderCode string) error {
+	return nil
+}
+
+// Refund implements ProviderInterface.
+func (b *bankTransferProvider) Refund(ctx context.Context, orderCode string, refundKey string, amount int64, reason string) (string, error) {
+	return "", ErrNotSupported
+}
+
+// ParseWebhook implements ProviderInterface.
+func (b *bankTransferProvider) ParseWebhook(ctx context.Context, body []byte) (*entity.GatewayNotificationEntity, error) {
+	return nil, ErrNotSupported
+}
+
+func NewBankTransferProvider(cfg *config.Config) ProviderInterface {
+	return &bankTransferProvider{cfg: cfg}
+}
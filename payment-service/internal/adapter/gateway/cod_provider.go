@@ -0,0 +1,45 @@
+package gateway
+
+import (
+	"context"
+	"payment-service/internal/core/domain/entity"
+)
+
+type codProvider struct{}
+
+// Name implements ProviderInterface.
+func (c *codProvider) Name() string {
+	return "cod"
+}
+
+// CreateTransaction implements ProviderInterface.
+// Uang tunai baru diterima saat pengiriman, payment menunggu konfirmasi kurir atau admin.
+func (c *codProvider) CreateTransaction(ctx context.Context, payment entity.PaymentEntity, customer entity.ProfileHttpResponse) (*entity.GatewayTransactionEntity, error) {
+	return &entity.GatewayTransactionEntity{
+		PaymentStatus: "AwaitingCollection",
+	}, nil
+}
+
+// GetStatus implements ProviderInterface.
+func (c *codProvider) GetStatus(ctx context.Context, orderCode string) (*entity.GatewayNotificationEntity, error) {
+	return nil, ErrNotSupported
+}
+
+// Cancel implements ProviderInterface.
+func (c *codProvider) Cancel(ctx context.Context, orderCode string) error {
+	return nil
+}
+
+// Refund implements ProviderInterface.
+func (c *codProvider) Refund(ctx context.Context, orderCode string, refundKey string, amount int64, reason string) (string, error) {
+	return "", ErrNotSupported
+}
+
+// ParseWebhook implements ProviderInterface.
+func (c *codProvider) ParseWebhook(ctx context.Context, body []byte) (*entity.GatewayNotificationEntity, error) {
+	return nil, ErrNotSupported
+}
+
+func NewCodProvider() ProviderInterface {
+	return &codProvider{}
+}
@@ -0,0 +1,46 @@
+package gateway
+
+import (
+	"context"
+	"errors"
+	"payment-service/internal/core/domain/entity"
+)
+
+var ErrNotSupported = errors.New("Operation not supported by payment gateway")
+
+// ProviderInterface adalah kontrak untuk setiap metode pembayaran, baik gateway online maupun manual.
+type ProviderInterface interface {
+	Name() string
+	CreateTransaction(ctx context.Context, payment entity.PaymentEntity, customer entity.ProfileHttpResponse) (*entity.GatewayTransactionEntity, error)
+	GetStatus(ctx context.Context, orderCode string) (*entity.GatewayNotificationEntity, error)
+	Cancel(ctx context.Context, orderCode string) error
+	Refund(ctx context.Context, orderCode, refundKey string, amount int64, reason string) (string, error)
+	ParseWebhook(ctx context.Context, body []byte) (*entity.GatewayNotificationEntity, error)
+}
+
+type RegistryInterface interface {
+	Get(name string) (ProviderInterface, error)
+}
+
+type registry struct {
+	providers map[string]ProviderInterface
+}
+
+// Get implements RegistryInterface.
+func (r *registry) Get(name string) (ProviderInterface, error) {
+	provider, ok := r.providers[name]
+	if !ok {
+		return nil, errors.New("Invalid payment method")
+	}
+
+	return provider, nil
+}
+
+func NewRegistry(providers ...ProviderInterface) RegistryInterface {
+	r := &registry{providers: map[string]ProviderInterface{}}
+	for _, provider := range providers {
+		r.providers[provider.Name()] = provider
+	}
+
+	return r
+}
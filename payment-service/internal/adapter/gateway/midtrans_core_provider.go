@@ -0,0 +1,103 @@
+package gateway
+
+import (
+	"context"
+	"errors"
+	"payment-service/config"
+	httpclient "payment-service/internal/adapter/http_client"
+	"payment-service/internal/core/domain/entity"
+
+	"github.com/labstack/gommon/log"
+)
+
+// virtualAccountBanks adalah bank yang didukung charge bank_transfer Midtrans.
+var virtualAccountBanks = map[string]bool{
+	"bca":     true,
+	"bni":     true,
+	"bri":     true,
+	"permata": true,
+	"cimb":    true,
+}
+
+// midtransCoreProvider memakai core API Midtrans (charge langsung) untuk metode yang tidak memakai halaman Snap.
+// Notifikasi tetap dikirim Midtrans ke webhook yang sama, sehingga webhook diproses oleh provider midtrans.
+type midtransCoreProvider struct {
+	cfg         *config.Config
+	client      httpclient.MidtransClientInterface
+	name        string
+	paymentType string
+}
+
+// Name implements ProviderInterface.
+func (m *midtransCoreProvider) Name() string {
+	return m.name
+}
+
+// CreateTransaction implements ProviderInterface.
+func (m *midtransCoreProvider) CreateTransaction(ctx context.Context, payment entity.PaymentEntity, customer entity.ProfileHttpResponse) (*entity.GatewayTransactionEntity, error) {
+	charge := entity.GatewayChargeEntity{
+		OrderID:       payment.GatewayOrderID,
+		PaymentType:   m.paymentType,
+		GrossAmount:   int64(payment.GrossAmount),
+		CustomerName:  customer.Name,
+		CustomerEmail: customer.Email,
+	}
+
+	if m.paymentType == "bank_transfer" {
+		if !virtualAccountBanks[payment.PaymentChannel] {
+			log.Errorf("[MidtransCoreProvider-1] CreateTransaction: unsupported bank %s", payment.PaymentChannel)
+			return nil, errors.New("Invalid bank")
+		}
+		charge.Bank = payment.PaymentChannel
+	}
+
+	transaction, err := m.client.Charge(charge)
+	if err != nil {
+		log.Errorf("[MidtransCoreProvider-2] CreateTransaction: %v", err)
+		return nil, err
+	}
+
+	return transaction, nil
+}
+
+// GetStatus implements ProviderInterface.
+func (m *midtransCoreProvider) GetStatus(ctx context.Context, orderCode string) (*entity.GatewayNotificationEntity, error) {
+	status, err := m.client.GetTransactionStatus(orderCode)
+	if err != nil {
+		log.Errorf("[MidtransCoreProvider-1] GetStatus: %v", err)
+		return nil, err
+	}
+
+	status.Provider = m.Name()
+	status.PaymentStatus = mapMidtransStatus(status.TransactionStatus, status.FraudStatus)
+
+	return status, nil
+}
+
+// Cancel implements ProviderInterface.
+func (m *midtransCoreProvider) Cancel(ctx context.Context, orderCode string) error {
+	return m.client.CancelTransaction(orderCode)
+}
+
+// Refund implements ProviderInterface.
+// Midtrans tidak mendukung refund untuk bank_transfer, refund virtual account dilakukan manual oleh admin.
+func (m *midtransCoreProvider) Refund(ctx context.Context, orderCode string, refundKey string, amount int64, reason string) (string, error) {
+	if m.paymentType == "bank_transfer" {
+		return "", ErrNotSupported
+	}
+
+	return m.client.RefundTransaction(orderCode, refundKey, amount, reason)
+}
+
+// ParseWebhook implements ProviderInterface.
+func (m *midtransCoreProvider) ParseWebhook(ctx context.Context, body []byte) (*entity.GatewayNotificationEntity, error) {
+	return nil, ErrNotSupported
+}
+
+func NewVirtualAccountProvider(cfg *config.Config, client httpclient.MidtransClientInterface) ProviderInterface {
+	return &midtransCoreProvider{cfg: cfg, client: client, name: "virtual_account", paymentType: "bank_transfer"}
+}
+
+func NewQrisProvider(cfg *config.Config, client httpclient.MidtransClientInterface) ProviderInterface {
+	return &midtransCoreProvider{cfg: cfg, client: client, name: "qris", paymentType: "qris"}
+}
@@ -0,0 +1,204 @@
+package gateway
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"payment-service/config"
+	httpclient "payment-service/internal/adapter/http_client"
+	"payment-service/internal/core/domain/entity"
+	"strings"
+	"testing"
+)
+
+type chargeRequest struct {
+	PaymentType        string `json:"payment_type"`
+	TransactionDetails struct {
+		OrderID     string `json:"order_id"`
+		GrossAmount int64  `json:"gross_amount"`
+	} `json:"transaction_details"`
+	BankTransfer struct {
+		Bank string `json:"bank"`
+	} `json:"bank_transfer"`
+}
+
+// newCoreApiTestServer meniru endpoint charge dan status core API Midtrans untuk VA dan QRIS.
+// Order dengan awalan DUP- dibalas seperti order_id yang sudah pernah dipakai.
+func newCoreApiTestServer(t *testing.T, requests *[]chargeRequest) *httptest.Server {
+	t.Helper()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+
+		if r.Method == http.MethodGet && r.URL.Path == "/v2/QR-PAID/status" {
+			w.Write([]byte(`{"status_code":"200","order_id":"QR-PAID","transaction_id":"trx-qr","transaction_status":"settlement","payment_type":"qris","gross_amount":"25000.00"}`))
+			return
+		}
+
+		if r.Method != http.MethodPost || r.URL.Path != "/v2/charge" {
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"status_code":"404","status_message":"Not found"}`))
+			return
+		}
+
+		req := chargeRequest{}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Errorf("decode charge request: %v", err)
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		*requests = append(*requests, req)
+
+		if strings.HasPrefix(req.TransactionDetails.OrderID, "DUP-") {
+			w.Write([]byte(`{"status_code":"406","status_message":"The request could not be completed due to a conflict with the current state of the target resource, please try again"}`))
+			return
+		}
+
+		switch req.PaymentType {
+		case "bank_transfer":
+			w.Write([]byte(`{"status_code":"201","status_message":"Success, Bank Transfer transaction is created","transaction_id":"trx-va","transaction_status":"pending","va_numbers":[{"bank":"` + req.BankTransfer.Bank + `","va_number":"8123456789"}],"expiry_time":"2026-10-20 10:00:00"}`))
+		case "qris":
+			w.Write([]byte(`{"status_code":"201","status_message":"QRIS transaction is created","transaction_id":"trx-qr","transaction_status":"pending","qr_string":"00020101021226620014COM.GO-JEK.WWW","actions":[{"name":"generate-qr-code","url":"https://api.sandbox.midtrans.com/v2/qris/trx-qr/qr-code"}],"expiry_time":"2026-10-19 10:15:00"}`))
+		default:
+			w.Write([]byte(`{"status_code":"400","status_message":"Invalid payment type"}`))
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	return server
+}
+
+func newCoreApiTestConfig(baseUrl string) *config.Config {
+	return &config.Config{
+		App:      config.App{ServerTimeOut: 5},
+		Midtrans: config.Midtrans{ServerKey: "server-key", BaseUrl: baseUrl},
+	}
+}
+
+func TestVirtualAccountProviderCreateTransaction(t *testing.T) {
+	requests := []chargeRequest{}
+	server := newCoreApiTestServer(t, &requests)
+	cfg := newCoreApiTestConfig(server.URL)
+	provider := NewVirtualAccountProvider(cfg, httpclient.NewMidtransClient(cfg))
+	customer := entity.ProfileHttpResponse{Name: "Budi", Email: "budi@mail.com"}
+
+	t.Run("creates bank transfer charge", func(t *testing.T) {
+		result, err := provider.CreateTransaction(context.Background(), entity.PaymentEntity{
+			GatewayOrderID: "VA-1",
+			PaymentChannel: "bca",
+			GrossAmount:    150000,
+		}, customer)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		last := requests[len(requests)-1]
+		if last.PaymentType != "bank_transfer" || last.BankTransfer.Bank != "bca" {
+			t.Errorf("charge request = %+v, want bank_transfer via bca", last)
+		}
+		if last.TransactionDetails.OrderID != "VA-1" || last.TransactionDetails.GrossAmount != 150000 {
+			t.Errorf("transaction details = %+v", last.TransactionDetails)
+		}
+		if result.PaymentStatus != "Pending" {
+			t.Errorf("payment status = %q, want Pending", result.PaymentStatus)
+		}
+		if result.PaymentCode != "8123456789" {
+			t.Errorf("va number = %q, want 8123456789", result.PaymentCode)
+		}
+		if result.PaymentChannel != "bca" || result.PaymentGatewayID != "trx-va" || result.ExpiresAt == "" {
+			t.Errorf("unexpected transaction %+v", result)
+		}
+	})
+
+	t.Run("rejects unsupported bank before calling gateway", func(t *testing.T) {
+		before := len(requests)
+		_, err := provider.CreateTransaction(context.Background(), entity.PaymentEntity{
+			GatewayOrderID: "VA-2",
+			PaymentChannel: "mandiri",
+			GrossAmount:    150000,
+		}, customer)
+		if err == nil || err.Error() != "Invalid bank" {
+			t.Fatalf("error = %v, want Invalid bank", err)
+		}
+		if len(requests) != before {
+			t.Error("gateway was called for unsupported bank")
+		}
+	})
+
+	t.Run("returns error when gateway rejects charge", func(t *testing.T) {
+		if _, err := provider.CreateTransaction(context.Background(), entity.PaymentEntity{
+			GatewayOrderID: "DUP-VA-3",
+			PaymentChannel: "bni",
+			GrossAmount:    150000,
+		}, customer); err == nil {
+			t.Fatal("expected error for rejected charge")
+		}
+	})
+}
+
+func TestVirtualAccountProviderRefund(t *testing.T) {
+	requests := []chargeRequest{}
+	server := newCoreApiTestServer(t, &requests)
+	cfg := newCoreApiTestConfig(server.URL)
+	provider := NewVirtualAccountProvider(cfg, httpclient.NewMidtransClient(cfg))
+
+	if _, err := provider.Refund(context.Background(), "VA-1", "RF-1", 50000, "customer request"); !errors.Is(err, ErrNotSupported) {
+		t.Fatalf("error = %v, want ErrNotSupported", err)
+	}
+}
+
+func TestQrisProviderCreateTransaction(t *testing.T) {
+	requests := []chargeRequest{}
+	server := newCoreApiTestServer(t, &requests)
+	cfg := newCoreApiTestConfig(server.URL)
+	provider := NewQrisProvider(cfg, httpclient.NewMidtransClient(cfg))
+	customer := entity.ProfileHttpResponse{Name: "Budi", Email: "budi@mail.com"}
+
+	t.Run("creates qris charge", func(t *testing.T) {
+		result, err := provider.CreateTransaction(context.Background(), entity.PaymentEntity{
+			GatewayOrderID: "QR-1",
+			GrossAmount:    25000,
+		}, customer)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		last := requests[len(requests)-1]
+		if last.PaymentType != "qris" {
+			t.Errorf("payment type = %q, want qris", last.PaymentType)
+		}
+		if result.PaymentChannel != "qris" {
+			t.Errorf("payment channel = %q, want qris", result.PaymentChannel)
+		}
+		if result.PaymentCode != "00020101021226620014COM.GO-JEK.WWW" {
+			t.Errorf("qr string = %q", result.PaymentCode)
+		}
+		if result.PaymentURL != "https://api.sandbox.midtrans.com/v2/qris/trx-qr/qr-code" {
+			t.Errorf("qr url = %q", result.PaymentURL)
+		}
+		if result.PaymentStatus != "Pending" || result.PaymentGatewayID != "trx-qr" {
+			t.Errorf("unexpected transaction %+v", result)
+		}
+	})
+
+	t.Run("returns error when gateway rejects charge", func(t *testing.T) {
+		if _, err := provider.CreateTransaction(context.Background(), entity.PaymentEntity{
+			GatewayOrderID: "DUP-QR-2",
+			GrossAmount:    25000,
+		}, customer); err == nil {
+			t.Fatal("expected error for rejected charge")
+		}
+	})
+
+	t.Run("maps settled status", func(t *testing.T) {
+		status, err := provider.GetStatus(context.Background(), "QR-PAID")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if status.Provider != "qris" || status.PaymentStatus != "Success" {
+			t.Errorf("status = %+v, want qris Success", status)
+		}
+	})
+}
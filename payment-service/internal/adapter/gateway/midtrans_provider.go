@@ -0,0 +1,139 @@
+package gateway
+
+import (
+	"context"
+	"crypto/sha512"
+	"crypto/subtle"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"payment-service/config"
+	httpclient "payment-service/internal/adapter/http_client"
+	"payment-service/internal/core/domain/entity"
+	"strings"
+
+	"github.com/labstack/gommon/log"
+)
+
+type midtransNotification struct {
+	OrderID           string `json:"order_id"`
+	TransactionID     string `json:"transaction_id"`
+	TransactionStatus string `json:"transaction_status"`
+	FraudStatus       string `json:"fraud_status"`
+	PaymentType       string `json:"payment_type"`
+	StatusCode        string `json:"status_code"`
+	GrossAmount       string `json:"gross_amount"`
+	SignatureKey      string `json:"signature_key"`
+}
+
+type midtransProvider struct {
+	cfg    *config.Config
+	client httpclient.MidtransClientInterface
+}
+
+// Name implements ProviderInterface.
+func (m *midtransProvider) Name() string {
+	return "midtrans"
+}
+
+// CreateTransaction implements ProviderInterface.
+func (m *midtransProvider) CreateTransaction(ctx context.Context, payment entity.PaymentEntity, customer entity.ProfileHttpResponse) (*entity.GatewayTransactionEntity, error) {
+	token, err := m.client.CreateTransaction(payment.GatewayOrderID, int64(payment.GrossAmount), customer.Name, customer.Email)
+	if err != nil {
+		log.Errorf("[MidtransProvider-1] CreateTransaction: %v", err)
+		return nil, err
+	}
+
+	return &entity.GatewayTransactionEntity{
+		PaymentStatus:    "Pending",
+		PaymentGatewayID: token,
+	}, nil
+}
+
+// GetStatus implements ProviderInterface.
+func (m *midtransProvider) GetStatus(ctx context.Context, orderCode string) (*entity.GatewayNotificationEntity, error) {
+	status, err := m.client.GetTransactionStatus(orderCode)
+	if err != nil {
+		log.Errorf("[MidtransProvider-1] GetStatus: %v", err)
+		return nil, err
+	}
+
+	status.Provider = m.Name()
+	status.PaymentStatus = mapMidtransStatus(status.TransactionStatus, status.FraudStatus)
+
+	return status, nil
+}
+
+// Cancel implements ProviderInterface.
+func (m *midtransProvider) Cancel(ctx context.Context, orderCode string) error {
+	return m.client.CancelTransaction(orderCode)
+}
+
+// Refund implements ProviderInterface.
+func (m *midtransProvider) Refund(ctx context.Context, orderCode string, refundKey string, amount int64, reason string) (string, error) {
+	return m.client.RefundTransaction(orderCode, refundKey, amount, reason)
+}
+
+// ParseWebhook implements ProviderInterface.
+func (m *midtransProvider) ParseWebhook(ctx context.Context, body []byte) (*entity.GatewayNotificationEntity, error) {
+	var notification midtransNotification
+	if err := json.Unmarshal(body, &notification); err != nil {
+		log.Errorf("[MidtransProvider-1] ParseWebhook: %v", err)
+		return nil, errors.New("400")
+	}
+
+	if notification.OrderID == "" || notification.StatusCode == "" || notification.GrossAmount == "" ||
+		notification.SignatureKey == "" || notification.TransactionStatus == "" {
+		log.Errorf("[MidtransProvider-2] ParseWebhook: incomplete notification payload")
+		return nil, errors.New("400")
+	}
+
+	hash := sha512.Sum512([]byte(notification.OrderID + notification.StatusCode + notification.GrossAmount + m.cfg.Midtrans.ServerKey))
+	expected := hex.EncodeToString(hash[:])
+	if subtle.ConstantTimeCompare([]byte(expected), []byte(strings.ToLower(notification.SignatureKey))) != 1 {
+		log.Errorf("[MidtransProvider-3] ParseWebhook: invalid signature for order %s", notification.OrderID)
+		return nil, errors.New("401")
+	}
+
+	return &entity.GatewayNotificationEntity{
+		Provider:          m.Name(),
+		OrderID:           notification.OrderID,
+		TransactionID:     notification.TransactionID,
+		TransactionStatus: notification.TransactionStatus,
+		FraudStatus:       notification.FraudStatus,
+		PaymentType:       notification.PaymentType,
+		PaymentStatus:     mapMidtransStatus(notification.TransactionStatus, notification.FraudStatus),
+		StatusCode:        notification.StatusCode,
+		GrossAmount:       notification.GrossAmount,
+		SignatureKey:      notification.SignatureKey,
+		RawPayload:        string(body),
+	}, nil
+}
+
+func mapMidtransStatus(transactionStatus, fraudStatus string) string {
+	switch transactionStatus {
+	case "capture":
+		if fraudStatus == "challenge" {
+			return "Pending"
+		}
+		return "Success"
+	case "settlement":
+		return "Success"
+	case "deny", "cancel", "failure":
+		return "Failed"
+	case "expire":
+		return "Expired"
+	case "pending":
+		return "Pending"
+	case "refund":
+		return "Refunded"
+	case "partial_refund":
+		return "Partially Refunded"
+	default:
+		return ""
+	}
+}
+
+func NewMidtransProvider(cfg *config.Config, client httpclient.MidtransClientInterface) ProviderInterface {
+	return &midtransProvider{cfg: cfg, client: client}
+}
@@ -0,0 +1,164 @@
+package handlers
+
+import (
+	"net/http"
+	"payment-service/config"
+	"payment-service/internal/adapter"
+	"payment-service/internal/adapter/handlers/request"
+	"payment-service/internal/adapter/handlers/response"
+	"payment-service/internal/core/domain/entity"
+	"payment-service/internal/core/service"
+	"payment-service/utils/conv"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/labstack/gommon/log"
+)
+
+type CodCollectionHandlerInterface interface {
+	Confirm(c echo.Context) error
+	GetReconciliation(c echo.Context) error
+}
+
+type codCollectionHandler struct {
+	codCollectionService service.CodCollectionServiceInterface
+}
+
+func NewCodCollectionHandler(codCollectionService service.CodCollectionServiceInterface, e *echo.Echo, cfg *config.Config) CodCollectionHandlerInterface {
+	codCollectionHandler := &codCollectionHandler{
+		codCollectionService: codCollectionService,
+	}
+	mid := adapter.NewMiddlewareAdapter(cfg)
+	courierGroup := e.Group("/courier", mid.CheckToken())
+	courierGroup.POST("/payments/:id/cod-collection", codCollectionHandler.Confirm)
+
+	adminGroup := e.Group("/admin", mid.CheckToken())
+	adminGroup.POST("/payments/:id/cod-collection", codCollectionHandler.Confirm)
+	adminGroup.GET("/payments/cod/reconciliation", codCollectionHandler.GetReconciliation)
+
+	return codCollectionHandler
+}
+
+// Confirm implements CodCollectionHandlerInterface.
+func (ch *codCollectionHandler) Confirm(c echo.Context) error {
+	var (
+		ctx = c.Request().Context()
+		req = request.CodCollectionRequest{}
+	)
+
+	user := c.Get("user").(string)
+	if user == "" {
+		log.Errorf("[CodCollectionHandler-1] Confirm: %s", "data token not found")
+		return c.JSON(http.StatusUnauthorized, response.ResponseDefault("data token not found", nil))
+	}
+
+	paymentID, err := conv.StringToInt64(c.Param("id"))
+	if err != nil {
+		log.Errorf("[CodCollectionHandler-2] Confirm: %v", err)
+		return c.JSON(http.StatusBadRequest, response.ResponseDefault(err.Error(), nil))
+	}
+
+	if err := c.Bind(&req); err != nil {
+		log.Errorf("[CodCollectionHandler-3] Confirm: %v", err)
+		return c.JSON(http.StatusBadRequest, response.ResponseDefault(err.Error(), nil))
+	}
+
+	if err := c.Validate(&req); err != nil {
+		log.Errorf("[CodCollectionHandler-4] Confirm: %v", err)
+		return c.JSON(http.StatusUnprocessableEntity, response.ResponseDefault(err.Error(), nil))
+	}
+
+	result, err := ch.codCollectionService.ConfirmCollection(ctx, entity.CodCollectionEntity{
+		PaymentID:       uint(paymentID),
+		CourierID:       req.CourierID,
+		CollectedAmount: req.CollectedAmount,
+		Note:            req.Note,
+	}, user)
+	if err != nil {
+		log.Errorf("[CodCollectionHandler-5] Confirm: %v", err)
+		switch err.Error() {
+		case "400":
+			return c.JSON(http.StatusUnprocessableEntity, response.ResponseDefault("courier_id is required", nil))
+		case "403":
+			return c.JSON(http.StatusForbidden, response.ResponseDefault("Forbidden", nil))
+		case "404":
+			return c.JSON(http.StatusNotFound, response.ResponseDefault("Payment not found", nil))
+		case "409":
+			return c.JSON(http.StatusConflict, response.ResponseDefault("Payment is not awaiting collection", nil))
+		case "Payment method is not cash on delivery":
+			return c.JSON(http.StatusUnprocessableEntity, response.ResponseDefault(err.Error(), nil))
+		}
+		return c.JSON(http.StatusInternalServerError, response.ResponseDefault(err.Error(), nil))
+	}
+
+	return c.JSON(http.StatusCreated, response.ResponseDefault("success", response.CodCollectionResponse{
+		ID:              result.ID,
+		PaymentID:       result.PaymentID,
+		CourierID:       result.CourierID,
+		ExpectedAmount:  result.ExpectedAmount,
+		CollectedAmount: result.CollectedAmount,
+		Difference:      result.CollectedAmount - result.ExpectedAmount,
+		Note:            result.Note,
+		ConfirmedBy:     result.ConfirmedBy,
+		CollectedAt:     result.CollectedAt,
+	}))
+}
+
+// GetReconciliation implements CodCollectionHandlerInterface.
+func (ch *codCollectionHandler) GetReconciliation(c echo.Context) error {
+	var (
+		ctx   = c.Request().Context()
+		resps = []response.CodReconciliationResponse{}
+	)
+
+	// Default rekap adalah hari ini
+	now := time.Now()
+	startDate := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	endDate := startDate
+
+	var err error
+	if startStr := c.QueryParam("start_date"); startStr != "" {
+		if startDate, err = time.ParseInLocation("2006-01-02", startStr, now.Location()); err != nil {
+			log.Errorf("[CodCollectionHandler-1] GetReconciliation: %v", err)
+			return c.JSON(http.StatusBadRequest, response.ResponseDefault("start_date must use format YYYY-MM-DD", nil))
+		}
+	}
+	if endStr := c.QueryParam("end_date"); endStr != "" {
+		if endDate, err = time.ParseInLocation("2006-01-02", endStr, now.Location()); err != nil {
+			log.Errorf("[CodCollectionHandler-2] GetReconciliation: %v", err)
+			return c.JSON(http.StatusBadRequest, response.ResponseDefault("end_date must use format YYYY-MM-DD", nil))
+		}
+	}
+
+	var courierID int64
+	if courierStr := c.QueryParam("courier_id"); courierStr != "" {
+		if courierID, err = conv.StringToInt64(courierStr); err != nil {
+			log.Errorf("[CodCollectionHandler-3] GetReconciliation: %v", err)
+			return c.JSON(http.StatusBadRequest, response.ResponseDefault("courier_id must be a number", nil))
+		}
+	}
+
+	results, err := ch.codCollectionService.GetReconciliation(ctx, startDate, endDate, uint(courierID))
+	if err != nil {
+		log.Errorf("[CodCollectionHandler-4] GetReconciliation: %v", err)
+		if err.Error() == "400" {
+			return c.JSON(http.StatusBadRequest, response.ResponseDefault("end_date must not be before start_date", nil))
+		}
+		return c.JSON(http.StatusInternalServerError, response.ResponseDefault(err.Error(), nil))
+	}
+
+	for _, val := range results {
+		resps = append(resps, response.CodReconciliationResponse{
+			Date:              val.Date,
+			CourierID:         val.CourierID,
+			CollectionCount:   val.CollectionCount,
+			ExpectedAmount:    val.ExpectedAmount,
+			CollectedAmount:   val.CollectedAmount,
+			UncollectedCount:  val.UncollectedCount,
+			UncollectedAmount: val.UncollectedAmount,
+			Difference:        val.CollectedAmount - val.ExpectedAmount,
+		})
+	}
+
+	return c.JSON(http.StatusOK, response.ResponseDefault("success", resps))
+}
@@ -0,0 +1,198 @@
+package handlers
+
+import (
+	"net/http"
+	"payment-service/config"
+	"payment-service/internal/adapter"
+	"payment-service/internal/adapter/handlers/request"
+	"payment-service/internal/adapter/handlers/response"
+	"payment-service/internal/core/domain/entity"
+	"payment-service/internal/core/service"
+	"payment-service/utils/conv"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+	"github.com/labstack/gommon/log"
+)
+
+type FraudHandlerInterface interface {
+	GetAll(c echo.Context) error
+	GetByID(c echo.Context) error
+	Approve(c echo.Context) error
+	Reject(c echo.Context) error
+}
+
+type fraudHandler struct {
+	fraudService service.FraudServiceInterface
+}
+
+func NewFraudHandler(fraudService service.FraudServiceInterface, e *echo.Echo, cfg *config.Config) FraudHandlerInterface {
+	fraudHandler := &fraudHandler{
+		fraudService: fraudService,
+	}
+	mid := adapter.NewMiddlewareAdapter(cfg)
+
+	adminGroup := e.Group("/admin", mid.CheckToken())
+	adminGroup.GET("/payments/fraud-checks", fraudHandler.GetAll)
+	adminGroup.GET("/payments/fraud-checks/:id", fraudHandler.GetByID)
+	adminGroup.PUT("/payments/fraud-checks/:id/approve", fraudHandler.Approve)
+	adminGroup.PUT("/payments/fraud-checks/:id/reject", fraudHandler.Reject)
+
+	return fraudHandler
+}
+
+// GetAll implements FraudHandlerInterface.
+// Tanpa filter status, yang ditampilkan adalah antrean review.
+func (fh *fraudHandler) GetAll(c echo.Context) error {
+	var (
+		ctx   = c.Request().Context()
+		resps = []response.FraudCheckResponse{}
+	)
+
+	var page int64 = 1
+	if pageStr := c.QueryParam("page"); pageStr != "" {
+		page, _ = conv.StringToInt64(pageStr)
+		if page <= 0 {
+			page = 1
+		}
+	}
+
+	var perPage int64 = 10
+	if perPageStr := c.QueryParam("perPage"); perPageStr != "" {
+		perPage, _ = conv.StringToInt64(perPageStr)
+		if perPage <= 0 {
+			perPage = 10
+		}
+	}
+
+	status := c.QueryParam("status")
+	if status == "" {
+		status = "PendingReview"
+	}
+	if status == "all" {
+		status = ""
+	}
+
+	results, count, total, err := fh.fraudService.GetAll(ctx, entity.FraudCheckQueryEntity{
+		Page:     page,
+		Limit:    perPage,
+		Status:   status,
+		Decision: c.QueryParam("decision"),
+	})
+	if err != nil {
+		log.Errorf("[FraudHandler-1] GetAll: %v", err)
+		if err.Error() == "404" {
+			return c.JSON(http.StatusNotFound, response.ResponseDefault("Fraud check not found", nil))
+		}
+		return c.JSON(http.StatusInternalServerError, response.ResponseDefault(err.Error(), nil))
+	}
+
+	for _, val := range results {
+		resps = append(resps, toFraudCheckResponse(val))
+	}
+
+	return c.JSON(http.StatusOK, response.ResponseSuccessWithPagination("success", resps, page, count, total, perPage))
+}
+
+// GetByID implements FraudHandlerInterface.
+func (fh *fraudHandler) GetByID(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	checkID, err := conv.StringToInt64(c.Param("id"))
+	if err != nil {
+		log.Errorf("[FraudHandler-1] GetByID: %v", err)
+		return c.JSON(http.StatusBadRequest, response.ResponseDefault(err.Error(), nil))
+	}
+
+	result, err := fh.fraudService.GetByID(ctx, uint(checkID))
+	if err != nil {
+		log.Errorf("[FraudHandler-2] GetByID: %v", err)
+		if err.Error() == "404" {
+			return c.JSON(http.StatusNotFound, response.ResponseDefault("Fraud check not found", nil))
+		}
+		return c.JSON(http.StatusInternalServerError, response.ResponseDefault(err.Error(), nil))
+	}
+
+	return c.JSON(http.StatusOK, response.ResponseDefault("success", toFraudCheckResponse(*result)))
+}
+
+// Approve implements FraudHandlerInterface.
+func (fh *fraudHandler) Approve(c echo.Context) error {
+	return fh.review(c, true)
+}
+
+// Reject implements FraudHandlerInterface.
+func (fh *fraudHandler) Reject(c echo.Context) error {
+	return fh.review(c, false)
+}
+
+func (fh *fraudHandler) review(c echo.Context, approve bool) error {
+	var (
+		ctx = c.Request().Context()
+		req = request.FraudReviewRequest{}
+	)
+
+	user := c.Get("user").(string)
+	if user == "" {
+		log.Errorf("[FraudHandler-1] review: %s", "data token not found")
+		return c.JSON(http.StatusUnauthorized, response.ResponseDefault("data token not found", nil))
+	}
+
+	checkID, err := conv.StringToInt64(c.Param("id"))
+	if err != nil {
+		log.Errorf("[FraudHandler-2] review: %v", err)
+		return c.JSON(http.StatusBadRequest, response.ResponseDefault(err.Error(), nil))
+	}
+
+	if err := c.Bind(&req); err != nil {
+		log.Errorf("[FraudHandler-3] review: %v", err)
+		return c.JSON(http.StatusBadRequest, response.ResponseDefault(err.Error(), nil))
+	}
+
+	if !approve && strings.TrimSpace(req.Note) == "" {
+		log.Errorf("[FraudHandler-4] review: %s", "note is required when rejecting")
+		return c.JSON(http.StatusUnprocessableEntity, response.ResponseDefault("note is required when rejecting", nil))
+	}
+
+	result, err := fh.fraudService.Review(ctx, uint(checkID), approve, req.Note, user)
+	if err != nil {
+		log.Errorf("[FraudHandler-5] review: %v", err)
+		switch err.Error() {
+		case "404":
+			return c.JSON(http.StatusNotFound, response.ResponseDefault("Fraud check not found", nil))
+		case "409":
+			return c.JSON(http.StatusConflict, response.ResponseDefault("Fraud check is not awaiting review", nil))
+		}
+		return c.JSON(http.StatusInternalServerError, response.ResponseDefault(err.Error(), nil))
+	}
+
+	return c.JSON(http.StatusOK, response.ResponseDefault("success", toFraudCheckResponse(*result)))
+}
+
+func toFraudCheckResponse(val entity.FraudCheckEntity) response.FraudCheckResponse {
+	resp := response.FraudCheckResponse{
+		ID:          val.ID,
+		OrderID:     val.OrderID,
+		UserID:      val.UserID,
+		ClientIP:    val.ClientIP,
+		Phone:       val.Phone,
+		GrossAmount: val.GrossAmount,
+		Decision:    val.Decision,
+		Status:      val.Status,
+		ReviewNote:  val.ReviewNote,
+		ReviewedBy:  val.ReviewedBy,
+		ReviewedAt:  val.ReviewedAt,
+		CreatedAt:   val.CreatedAt,
+		RuleHits:    []response.FraudRuleHitResponse{},
+	}
+
+	for _, hit := range val.RuleHits {
+		resp.RuleHits = append(resp.RuleHits, response.FraudRuleHitResponse{
+			Rule:   hit.Rule,
+			Action: hit.Action,
+			Detail: hit.Detail,
+		})
+	}
+
+	return resp
+}
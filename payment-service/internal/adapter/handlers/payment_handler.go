@@ -18,10 +18,12 @@ import (
 
 type PaymentHandlerInterface interface {
 	Create(c echo.Context) error
-	MidtranswebHookHandler(c echo.Context) error
 	GetAllAdmin(c echo.Context) error
 	GetAllCustomer(c echo.Context) error
 	GetDetail(c echo.Context) error
+	CreateRefund(c echo.Context) error
+	GetRefunds(c echo.Context) error
+	GetTimeline(c echo.Context) error
 }
 
 type paymentHandler struct {
@@ -34,20 +36,175 @@ func NewPaymentHandler(paymentService service.PaymentServiceInterface, e *echo.E
 	}
 	e.Use(middleware.Recover())
 	mid := adapter.NewMiddlewareAdapter(cfg)
-	e.POST("/payments/webhook", paymentHandler.MidtranswebHookHandler)
 	authGroup := e.Group("auth", mid.CheckToken())
 	authGroup.GET("/payments", paymentHandler.GetAllCustomer)
 	authGroup.GET("/payments/:id", paymentHandler.GetDetail)
+	authGroup.GET("/payments/:id/timeline", paymentHandler.GetTimeline)
 	authGroup.POST("/payments", paymentHandler.Create)
 
 	adminGroup := e.Group("/admin", mid.CheckToken())
 	adminGroup.GET("/payments", paymentHandler.GetAllAdmin)
 	adminGroup.GET("/payments/:id", paymentHandler.GetDetail)
+	adminGroup.GET("/payments/:id/timeline", paymentHandler.GetTimeline)
+	adminGroup.POST("/payments/:id/refunds", paymentHandler.CreateRefund)
+	adminGroup.GET("/payments/:id/refunds", paymentHandler.GetRefunds)
 
 	return paymentHandler
 
 }
 
+func (ph *paymentHandler) GetTimeline(c echo.Context) error {
+	var (
+		ctx   = c.Request().Context()
+		resps = []response.PaymentTimelineResponse{}
+	)
+
+	user := c.Get("user").(string)
+	if user == "" {
+		log.Errorf("[PaymentHandler-1] GetTimeline: %s", "data token not found")
+		return c.JSON(http.StatusUnauthorized, response.ResponseDefault("data token not found", nil))
+	}
+
+	paymentIDInt, err := conv.StringToInt64(c.Param("id"))
+	if err != nil {
+		log.Errorf("[PaymentHandler-2] GetTimeline: %v", err)
+		return c.JSON(http.StatusBadRequest, response.ResponseDefault(err.Error(), nil))
+	}
+
+	results, err := ph.paymentService.GetTimeline(ctx, uint(paymentIDInt), user)
+	if err != nil {
+		log.Errorf("[PaymentHandler-3] GetTimeline: %v", err)
+		if err.Error() == "404" {
+			return c.JSON(http.StatusNotFound, response.ResponseDefault("Payment not found", nil))
+		}
+		return c.JSON(http.StatusInternalServerError, response.ResponseDefault(err.Error(), nil))
+	}
+
+	for _, val := range results {
+		resp := response.PaymentTimelineResponse{
+			ID:        val.ID,
+			Status:    val.Status,
+			Source:    val.Source,
+			EventKey:  val.EventKey,
+			CreatedAt: val.CreatedAt,
+		}
+		if val.Payload != "" {
+			resp.Payload = val.Payload
+			if json.Valid([]byte(val.Payload)) {
+				resp.Payload = json.RawMessage(val.Payload)
+			}
+		}
+		resps = append(resps, resp)
+	}
+
+	return c.JSON(http.StatusOK, response.ResponseDefault("success", resps))
+}
+
+func (ph *paymentHandler) GetRefunds(c echo.Context) error {
+	var (
+		ctx   = c.Request().Context()
+		resps = []response.RefundResponse{}
+	)
+
+	paymentIDInt, err := conv.StringToInt64(c.Param("id"))
+	if err != nil {
+		log.Errorf("[PaymentHandler-1] GetRefunds: %v", err)
+		return c.JSON(http.StatusBadRequest, response.ResponseDefault(err.Error(), nil))
+	}
+
+	results, err := ph.paymentService.GetRefunds(ctx, uint(paymentIDInt))
+	if err != nil {
+		log.Errorf("[PaymentHandler-2] GetRefunds: %v", err)
+		if err.Error() == "404" {
+			return c.JSON(http.StatusNotFound, response.ResponseDefault("Refund not found", nil))
+		}
+		return c.JSON(http.StatusInternalServerError, response.ResponseDefault(err.Error(), nil))
+	}
+
+	for _, val := range results {
+		resps = append(resps, response.RefundResponse{
+			ID:           val.ID,
+			PaymentID:    val.PaymentID,
+			RefundKey:    val.RefundKey,
+			Amount:       val.Amount,
+			Reason:       val.Reason,
+			RefundMethod: val.RefundMethod,
+			Status:       val.Status,
+			CreatedBy:    val.CreatedBy,
+			CreatedAt:    val.CreatedAt,
+		})
+	}
+
+	return c.JSON(http.StatusOK, response.ResponseDefault("success", resps))
+}
+
+func (ph *paymentHandler) CreateRefund(c echo.Context) error {
+	var (
+		ctx         = c.Request().Context()
+		req         = request.RefundRequest{}
+		jwtUserData = entity.JwtUserData{}
+	)
+
+	user := c.Get("user").(string)
+	if user == "" {
+		log.Errorf("[PaymentHandler-1] CreateRefund: %s", "data token not found")
+		return c.JSON(http.StatusUnauthorized, response.ResponseDefault("data token not found", nil))
+	}
+
+	if err := json.Unmarshal([]byte(user), &jwtUserData); err != nil {
+		log.Errorf("[PaymentHandler-2] CreateRefund: %v", err)
+		return c.JSON(http.StatusBadRequest, response.ResponseDefault(err.Error(), nil))
+	}
+
+	paymentIDInt, err := conv.StringToInt64(c.Param("id"))
+	if err != nil {
+		log.Errorf("[PaymentHandler-3] CreateRefund: %v", err)
+		return c.JSON(http.StatusBadRequest, response.ResponseDefault(err.Error(), nil))
+	}
+
+	if err := c.Bind(&req); err != nil {
+		log.Errorf("[PaymentHandler-4] CreateRefund: %v", err)
+		return c.JSON(http.StatusBadRequest, response.ResponseDefault(err.Error(), nil))
+	}
+
+	if err := c.Validate(&req); err != nil {
+		log.Errorf("[PaymentHandler-5] CreateRefund: %v", err)
+		return c.JSON(http.StatusUnprocessableEntity, response.ResponseDefault(err.Error(), nil))
+	}
+
+	refundEntity := entity.RefundEntity{
+		PaymentID: uint(paymentIDInt),
+		Amount:    req.Amount,
+		Reason:    req.Reason,
+		CreatedBy: uint(jwtUserData.UserID),
+	}
+
+	result, err := ph.paymentService.CreateRefund(ctx, refundEntity, user)
+	if err != nil {
+		log.Errorf("[PaymentHandler-6] CreateRefund: %v", err)
+		switch err.Error() {
+		case "404":
+			return c.JSON(http.StatusNotFound, response.ResponseDefault("Payment not found", nil))
+		case "Payment is not refundable", "Refund amount exceeds refundable amount":
+			return c.JSON(http.StatusUnprocessableEntity, response.ResponseDefault(err.Error(), nil))
+		}
+		return c.JSON(http.StatusInternalServerError, response.ResponseDefault(err.Error(), nil))
+	}
+
+	resp := response.RefundResponse{
+		ID:           result.ID,
+		PaymentID:    result.PaymentID,
+		RefundKey:    result.RefundKey,
+		Amount:       result.Amount,
+		Reason:       result.Reason,
+		RefundMethod: result.RefundMethod,
+		Status:       result.Status,
+		CreatedBy:    result.CreatedBy,
+	}
+
+	return c.JSON(http.StatusCreated, response.ResponseDefault("success", resp))
+}
+
 func (ph *paymentHandler) GetDetail(c echo.Context) error {
 	var (
 		ctx   = c.Request().Context()
@@ -89,6 +246,36 @@ func (ph *paymentHandler) GetDetail(c echo.Context) error {
 	resps.OrderRemarks = result.OrderRemarks
 	resps.CustomerName = result.CustomerName
 	resps.CustomerAddress = result.CustomerAddress
+	resps.TaxAmount = result.OrderTaxAmount
+	resps.TaxInclusive = result.OrderTaxInclusive
+	resps.PaymentChannel = result.PaymentChannel
+	resps.PaymentCode = result.PaymentCode
+	resps.ExpiryTime = result.ExpiresAt
+	if json.Valid([]byte(result.OrderSnapshot)) {
+		resps.OrderSnapshot = json.RawMessage(result.OrderSnapshot)
+	}
+
+	for _, item := range result.OrderItems {
+		resps.Items = append(resps.Items, response.PaymentItemResponse{
+			ProductName:  item.ProductName,
+			ProductPrice: item.ProductPrice,
+			Quantity:     item.Quantity,
+			Subtotal:     item.Subtotal,
+			TaxRate:      item.TaxRate,
+			TaxAmount:    item.TaxAmount,
+		})
+	}
+
+	for _, attempt := range result.Attempts {
+		resps.Attempts = append(resps.Attempts, response.PaymentAttemptResponse{
+			ID:            attempt.ID,
+			AttemptNumber: attempt.AttemptNumber,
+			PaymentMethod: attempt.PaymentMethod,
+			PaymentStatus: attempt.PaymentStatus,
+			GrossAmount:   attempt.GrossAmount,
+			PaymentAt:     attempt.PaymentAt,
+		})
+	}
 
 	return c.JSON(http.StatusOK, response.ResponseDefault("success", resps))
 }
@@ -250,36 +437,6 @@ func (ph *paymentHandler) GetAllAdmin(c echo.Context) error {
 	return c.JSON(http.StatusOK, response.ResponseSuccessWithPagination("success", resps, page, count, total, perPage))
 }
 
-func (ph *paymentHandler) MidtranswebHookHandler(c echo.Context) error {
-	var notificationPayload map[string]interface{}
-	if err := c.Bind(&notificationPayload); err != nil {
-		log.Errorf("[PaymentHandler-1] MidtranswebHookHandler: %v", err)
-		return c.JSON(http.StatusBadRequest, response.ResponseDefault(err.Error(), nil))
-	}
-
-	transactionStatus := notificationPayload["transaction_status"].(string)
-	orderID := notificationPayload["order_id"].(string)
-
-	newStatus := ""
-	switch transactionStatus {
-	case "capture", "settlement":
-		newStatus = "success"
-	case "deny", "cancel", "expire":
-		newStatus = "failed"
-	case "pending":
-		newStatus = "pending"
-	default:
-		newStatus = "unknown"
-	}
-
-	if err := ph.paymentService.UpdateStatusByOrderCode(c.Request().Context(), orderID, newStatus); err != nil {
-		log.Errorf("[PaymentHandler-3] MidtranswebHookHandler: %v", err)
-		return c.JSON(http.StatusInternalServerError, response.ResponseDefault(err.Error(), nil))
-	}
-
-	return c.JSON(http.StatusOK, response.ResponseDefault("success", nil))
-}
-
 func (p *paymentHandler) Create(c echo.Context) error {
 	var (
 		ctx = c.Request().Context()
@@ -303,16 +460,30 @@ func (p *paymentHandler) Create(c echo.Context) error {
 	}
 
 	paymentEntity := entity.PaymentEntity{
-		OrderID:       req.OrderID,
-		PaymentMethod: req.PaymentMethod,
-		GrossAmount:   float64(req.GrossAmount),
-		UserID:        req.UserID,
-		Remarks:       req.Remarks,
+		OrderID:        req.OrderID,
+		PaymentMethod:  req.PaymentMethod,
+		GrossAmount:    float64(req.GrossAmount),
+		UserID:         req.UserID,
+		PaymentChannel: req.Bank,
+		Remarks:        req.Remarks,
+		ClientIP:       c.RealIP(),
 	}
 
 	result, err := p.paymentService.ProcessPayment(ctx, paymentEntity, user)
 	if err != nil {
 		log.Errorf("[PaymentHandler-4] Create: %v", err)
+		switch err.Error() {
+		case "403":
+			return c.JSON(http.StatusForbidden, response.ResponseDefault("order does not belong to user", nil))
+		case "409":
+			return c.JSON(http.StatusConflict, response.ResponseDefault("order is not awaiting payment", nil))
+		case "422":
+			return c.JSON(http.StatusUnprocessableEntity, response.ResponseDefault("gross amount does not match order total", nil))
+		case "Payment blocked by fraud check":
+			return c.JSON(http.StatusForbidden, response.ResponseDefault(err.Error(), nil))
+		case "Payment is under review":
+			return c.JSON(http.StatusAccepted, response.ResponseDefault(err.Error(), nil))
+		}
 		return c.JSON(http.StatusInternalServerError, response.ResponseDefault(err.Error(), nil))
 	}
 
@@ -320,5 +491,24 @@ func (p *paymentHandler) Create(c echo.Context) error {
 		"payment_token": result.PaymentGatewayID,
 	}
 
+	if result.Instructions != "" {
+		responPayment["payment_status"] = result.PaymentStatus
+		responPayment["instructions"] = result.Instructions
+	}
+
+	// VA dan QRIS tidak punya halaman pembayaran, customer membayar memakai nomor VA atau QR string sebelum kedaluwarsa
+	switch result.PaymentMethod {
+	case "virtual_account":
+		responPayment["payment_status"] = result.PaymentStatus
+		responPayment["bank"] = result.PaymentChannel
+		responPayment["va_number"] = result.PaymentCode
+		responPayment["expiry_time"] = result.ExpiresAt
+	case "qris":
+		responPayment["payment_status"] = result.PaymentStatus
+		responPayment["qr_string"] = result.PaymentCode
+		responPayment["qr_url"] = result.PaymentURL
+		responPayment["expiry_time"] = result.ExpiresAt
+	}
+
 	return c.JSON(http.StatusCreated, response.ResponseDefault("success", responPayment))
 }
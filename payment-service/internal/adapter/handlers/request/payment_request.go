@@ -5,5 +5,30 @@ type PaymentRequest struct {
 	PaymentMethod string `json:"payment_method" validate:"required"`
 	GrossAmount   int64  `json:"gross_amount" validate:"required"`
 	UserID        uint   `json:"user_id" validate:"required"`
+	Bank          string `json:"bank" validate:"required_if=PaymentMethod virtual_account,omitempty,oneof=bca bni bri permata cimb"`
 	Remarks       string `json:"remarks"`
 }
+
+type RefundRequest struct {
+	Amount float64 `json:"amount" validate:"gte=0"`
+	Reason string  `json:"reason" validate:"required"`
+}
+
+type TransferProofReviewRequest struct {
+	Note string `json:"note"`
+}
+
+type SandboxSimulateRequest struct {
+	Action string `json:"action" validate:"required,oneof=settlement deny expire cancel refund"`
+	Amount int64  `json:"amount" validate:"gte=0"`
+}
+
+type CodCollectionRequest struct {
+	CollectedAmount float64 `json:"collected_amount" validate:"required,gt=0"`
+	CourierID       uint    `json:"courier_id"`
+	Note            string  `json:"note"`
+}
+
+type FraudReviewRequest struct {
+	Note string `json:"note"`
+}
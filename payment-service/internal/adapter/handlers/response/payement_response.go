@@ -10,15 +10,148 @@ type PaymentListResponse struct {
 }
 
 type PaymentDetailResponse struct {
-	ID              int64   `json:"id"`
-	OrderCode       string  `json:"order_code"`
-	PaymentMethod   string  `json:"payment_method"`
-	PaymentStatus   string  `json:"payment_status"`
-	GrossAmount     float64 `json:"gross_amount"`
-	ShippingType    string  `json:"shipping_type"`
-	PaymentAt       string  `json:"payment_at"`
-	OrderAt         string  `json:"order_at"`
-	OrderRemarks    string  `json:"order_remarks"`
-	CustomerName    string  `json:"customer_name"`
-	CustomerAddress string  `json:"customer_address"`
+	ID              int64                    `json:"id"`
+	OrderCode       string                   `json:"order_code"`
+	PaymentMethod   string                   `json:"payment_method"`
+	PaymentStatus   string                   `json:"payment_status"`
+	GrossAmount     float64                  `json:"gross_amount"`
+	TaxAmount       int64                    `json:"tax_amount"`
+	TaxInclusive    bool                     `json:"tax_inclusive"`
+	ShippingType    string                   `json:"shipping_type"`
+	PaymentAt       string                   `json:"payment_at"`
+	OrderAt         string                   `json:"order_at"`
+	OrderRemarks    string                   `json:"order_remarks"`
+	CustomerName    string                   `json:"customer_name"`
+	CustomerAddress string                   `json:"customer_address"`
+	Items           []PaymentItemResponse    `json:"items"`
+	Attempts        []PaymentAttemptResponse `json:"attempts"`
+	PaymentChannel  string                   `json:"payment_channel,omitempty"`
+	PaymentCode     string                   `json:"payment_code,omitempty"`
+	ExpiryTime      string                   `json:"expiry_time,omitempty"`
+	OrderSnapshot   interface{}              `json:"order_snapshot,omitempty"`
+}
+
+type PaymentAttemptResponse struct {
+	ID            uint    `json:"id"`
+	AttemptNumber int     `json:"attempt_number"`
+	PaymentMethod string  `json:"payment_method"`
+	PaymentStatus string  `json:"payment_status"`
+	GrossAmount   float64 `json:"gross_amount"`
+	PaymentAt     string  `json:"payment_at"`
+}
+
+type PaymentItemResponse struct {
+	ProductName  string  `json:"product_name"`
+	ProductPrice int64   `json:"product_price"`
+	Quantity     int64   `json:"quantity"`
+	Subtotal     int64   `json:"subtotal"`
+	TaxRate      float64 `json:"tax_rate"`
+	TaxAmount    int64   `json:"tax_amount"`
+}
+
+type RefundResponse struct {
+	ID           uint    `json:"id"`
+	PaymentID    uint    `json:"payment_id"`
+	RefundKey    string  `json:"refund_key"`
+	Amount       float64 `json:"amount"`
+	Reason       string  `json:"reason"`
+	RefundMethod string  `json:"refund_method"`
+	Status       string  `json:"status"`
+	CreatedBy    uint    `json:"created_by"`
+	CreatedAt    string  `json:"created_at,omitempty"`
+}
+
+type TransferProofResponse struct {
+	ID          uint    `json:"id"`
+	PaymentID   uint    `json:"payment_id"`
+	BankName    string  `json:"bank_name"`
+	AccountName string  `json:"account_name"`
+	Amount      float64 `json:"amount"`
+	ImageURL    string  `json:"image_url"`
+	Status      string  `json:"status"`
+	Note        string  `json:"note,omitempty"`
+	ReviewedBy  uint    `json:"reviewed_by,omitempty"`
+	ReviewedAt  string  `json:"reviewed_at,omitempty"`
+	CreatedAt   string  `json:"created_at,omitempty"`
+}
+
+type PaymentTimelineResponse struct {
+	ID        uint        `json:"id"`
+	Status    string      `json:"status"`
+	Source    string      `json:"source"`
+	EventKey  string      `json:"event_key,omitempty"`
+	Payload   interface{} `json:"payload,omitempty"`
+	CreatedAt string      `json:"created_at"`
+}
+
+type SettlementFlagResponse struct {
+	Line      int    `json:"line,omitempty"`
+	OrderID   string `json:"order_id"`
+	PaymentID uint   `json:"payment_id,omitempty"`
+	Flag      string `json:"flag"`
+	Detail    string `json:"detail"`
+}
+
+type SettlementImportResponse struct {
+	TotalRows int                      `json:"total_rows"`
+	Matched   int                      `json:"matched"`
+	Flagged   int                      `json:"flagged"`
+	Flags     []SettlementFlagResponse `json:"flags"`
+}
+
+type WebhookInboxResponse struct {
+	ID            uint        `json:"id"`
+	Provider      string      `json:"provider"`
+	Status        string      `json:"status"`
+	Attempts      int         `json:"attempts"`
+	LastError     string      `json:"last_error,omitempty"`
+	NextAttemptAt string      `json:"next_attempt_at,omitempty"`
+	ProcessedAt   string      `json:"processed_at,omitempty"`
+	CreatedAt     string      `json:"created_at"`
+	Payload       interface{} `json:"payload,omitempty"`
+}
+
+type CodCollectionResponse struct {
+	ID              uint    `json:"id"`
+	PaymentID       uint    `json:"payment_id"`
+	CourierID       uint    `json:"courier_id"`
+	ExpectedAmount  float64 `json:"expected_amount"`
+	CollectedAmount float64 `json:"collected_amount"`
+	Difference      float64 `json:"difference"`
+	Note            string  `json:"note,omitempty"`
+	ConfirmedBy     uint    `json:"confirmed_by"`
+	CollectedAt     string  `json:"collected_at"`
+}
+
+type CodReconciliationResponse struct {
+	Date              string  `json:"date"`
+	CourierID         uint    `json:"courier_id"`
+	CollectionCount   int64   `json:"collection_count"`
+	ExpectedAmount    float64 `json:"expected_amount"`
+	CollectedAmount   float64 `json:"collected_amount"`
+	UncollectedCount  int64   `json:"uncollected_count"`
+	UncollectedAmount float64 `json:"uncollected_amount"`
+	Difference        float64 `json:"difference"`
+}
+
+type FraudRuleHitResponse struct {
+	Rule   string `json:"rule"`
+	Action string `json:"action"`
+	Detail string `json:"detail"`
+}
+
+type FraudCheckResponse struct {
+	ID          uint                   `json:"id"`
+	OrderID     uint                   `json:"order_id"`
+	UserID      uint                   `json:"user_id"`
+	ClientIP    string                 `json:"client_ip"`
+	Phone       string                 `json:"phone"`
+	GrossAmount float64                `json:"gross_amount"`
+	Decision    string                 `json:"decision"`
+	Status      string                 `json:"status"`
+	ReviewNote  string                 `json:"review_note,omitempty"`
+	ReviewedBy  uint                   `json:"reviewed_by,omitempty"`
+	ReviewedAt  string                 `json:"reviewed_at,omitempty"`
+	CreatedAt   string                 `json:"created_at"`
+	RuleHits    []FraudRuleHitResponse `json:"rule_hits"`
 }
@@ -0,0 +1,72 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"payment-service/config"
+	"payment-service/internal/adapter"
+	"payment-service/internal/adapter/handlers/request"
+	"payment-service/internal/adapter/handlers/response"
+	httpclient "payment-service/internal/adapter/http_client"
+
+	"github.com/labstack/echo/v4"
+	"github.com/labstack/gommon/log"
+)
+
+type SandboxHandlerInterface interface {
+	Simulate(c echo.Context) error
+}
+
+type sandboxHandler struct {
+	sandbox httpclient.MidtransSandboxInterface
+}
+
+// NewSandboxHandler hanya didaftarkan ketika MIDTRANS_DRIVER=sandbox.
+func NewSandboxHandler(sandbox httpclient.MidtransSandboxInterface, e *echo.Echo, cfg *config.Config) SandboxHandlerInterface {
+	sandboxHandler := &sandboxHandler{
+		sandbox: sandbox,
+	}
+	mid := adapter.NewMiddlewareAdapter(cfg)
+
+	adminGroup := e.Group("/admin", mid.CheckToken())
+	adminGroup.POST("/payments/sandbox/:orderCode/simulate", sandboxHandler.Simulate)
+
+	return sandboxHandler
+}
+
+// Simulate implements SandboxHandlerInterface.
+func (sh *sandboxHandler) Simulate(c echo.Context) error {
+	req := request.SandboxSimulateRequest{}
+
+	if err := c.Bind(&req); err != nil {
+		log.Errorf("[SandboxHandler-1] Simulate: %v", err)
+		return c.JSON(http.StatusBadRequest, response.ResponseDefault(err.Error(), nil))
+	}
+
+	if err := c.Validate(&req); err != nil {
+		log.Errorf("[SandboxHandler-2] Simulate: %v", err)
+		return c.JSON(http.StatusUnprocessableEntity, response.ResponseDefault(err.Error(), nil))
+	}
+
+	result, err := sh.sandbox.Simulate(c.Param("orderCode"), req.Action, req.Amount)
+	if err != nil {
+		log.Errorf("[SandboxHandler-3] Simulate: %v", err)
+		switch err.Error() {
+		case "400":
+			return c.JSON(http.StatusBadRequest, response.ResponseDefault("invalid action", nil))
+		case "404":
+			return c.JSON(http.StatusNotFound, response.ResponseDefault("sandbox transaction not found", nil))
+		case "409":
+			return c.JSON(http.StatusConflict, response.ResponseDefault("transaction status does not allow this action", nil))
+		case "422":
+			return c.JSON(http.StatusUnprocessableEntity, response.ResponseDefault("refund amount exceeds refundable amount", nil))
+		}
+		if result == nil {
+			return c.JSON(http.StatusInternalServerError, response.ResponseDefault(err.Error(), nil))
+		}
+		// Status sandbox sudah berubah, hanya pengiriman webhook yang gagal
+		return c.JSON(http.StatusBadGateway, response.ResponseDefault(err.Error(), json.RawMessage(result.RawPayload)))
+	}
+
+	return c.JSON(http.StatusOK, response.ResponseDefault("success", json.RawMessage(result.RawPayload)))
+}
@@ -0,0 +1,170 @@
+package handlers
+
+import (
+	"encoding/csv"
+	"fmt"
+	"net/http"
+	"payment-service/config"
+	"payment-service/internal/adapter"
+	"payment-service/internal/adapter/handlers/response"
+	"payment-service/internal/core/service"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/labstack/gommon/log"
+)
+
+const maxSettlementFileSize = 10 << 20
+
+type SettlementHandlerInterface interface {
+	ExportDailyReport(c echo.Context) error
+	ImportSettlement(c echo.Context) error
+}
+
+type settlementHandler struct {
+	settlementService service.SettlementServiceInterface
+}
+
+func NewSettlementHandler(settlementService service.SettlementServiceInterface, e *echo.Echo, cfg *config.Config) SettlementHandlerInterface {
+	settlementHandler := &settlementHandler{
+		settlementService: settlementService,
+	}
+	mid := adapter.NewMiddlewareAdapter(cfg)
+
+	adminGroup := e.Group("/admin", mid.CheckToken())
+	adminGroup.GET("/payments/reports/daily", settlementHandler.ExportDailyReport)
+	adminGroup.POST("/payments/settlements/import", settlementHandler.ImportSettlement)
+
+	return settlementHandler
+}
+
+// ExportDailyReport implements SettlementHandlerInterface.
+func (sh *settlementHandler) ExportDailyReport(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	// Default laporan adalah awal bulan berjalan sampai hari ini
+	now := time.Now()
+	startDate := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, now.Location())
+	endDate := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+
+	var err error
+	if startStr := c.QueryParam("start_date"); startStr != "" {
+		if startDate, err = time.ParseInLocation("2006-01-02", startStr, now.Location()); err != nil {
+			log.Errorf("[SettlementHandler-1] ExportDailyReport: %v", err)
+			return c.JSON(http.StatusBadRequest, response.ResponseDefault("start_date must use format YYYY-MM-DD", nil))
+		}
+	}
+	if endStr := c.QueryParam("end_date"); endStr != "" {
+		if endDate, err = time.ParseInLocation("2006-01-02", endStr, now.Location()); err != nil {
+			log.Errorf("[SettlementHandler-2] ExportDailyReport: %v", err)
+			return c.JSON(http.StatusBadRequest, response.ResponseDefault("end_date must use format YYYY-MM-DD", nil))
+		}
+	}
+
+	results, err := sh.settlementService.GetDailyReport(ctx, startDate, endDate)
+	if err != nil {
+		log.Errorf("[SettlementHandler-3] ExportDailyReport: %v", err)
+		if err.Error() == "400" {
+			return c.JSON(http.StatusBadRequest, response.ResponseDefault("end_date must not be before start_date", nil))
+		}
+		return c.JSON(http.StatusInternalServerError, response.ResponseDefault(err.Error(), nil))
+	}
+
+	filename := fmt.Sprintf("payments_%s_%s.csv", startDate.Format("20060102"), endDate.Format("20060102"))
+	c.Response().Header().Set(echo.HeaderContentType, "text/csv")
+	c.Response().Header().Set(echo.HeaderContentDisposition, fmt.Sprintf("attachment; filename=%q", filename))
+	c.Response().WriteHeader(http.StatusOK)
+
+	writer := csv.NewWriter(c.Response())
+	writer.Write([]string{"date", "payment_method", "payment_status", "transaction_count", "gross_amount", "fee_amount", "refunded_amount", "net_amount"})
+	for _, val := range results {
+		writer.Write([]string{
+			val.Date,
+			val.PaymentMethod,
+			val.PaymentStatus,
+			strconv.FormatInt(val.TransactionCount, 10),
+			strconv.FormatFloat(val.GrossAmount, 'f', 2, 64),
+			strconv.FormatFloat(val.FeeAmount, 'f', 2, 64),
+			strconv.FormatFloat(val.RefundedAmount, 'f', 2, 64),
+			strconv.FormatFloat(val.GrossAmount-val.FeeAmount-val.RefundedAmount, 'f', 2, 64),
+		})
+	}
+	writer.Flush()
+
+	return writer.Error()
+}
+
+// ImportSettlement implements SettlementHandlerInterface.
+func (sh *settlementHandler) ImportSettlement(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	var startDate, endDate time.Time
+	var err error
+	if startStr := c.FormValue("start_date"); startStr != "" {
+		if startDate, err = time.ParseInLocation("2006-01-02", startStr, time.Local); err != nil {
+			log.Errorf("[SettlementHandler-1] ImportSettlement: %v", err)
+			return c.JSON(http.StatusBadRequest, response.ResponseDefault("start_date must use format YYYY-MM-DD", nil))
+		}
+	}
+	if endStr := c.FormValue("end_date"); endStr != "" {
+		if endDate, err = time.ParseInLocation("2006-01-02", endStr, time.Local); err != nil {
+			log.Errorf("[SettlementHandler-2] ImportSettlement: %v", err)
+			return c.JSON(http.StatusBadRequest, response.ResponseDefault("end_date must use format YYYY-MM-DD", nil))
+		}
+	}
+
+	paymentMethod := c.FormValue("payment_method")
+	if paymentMethod == "" {
+		paymentMethod = "midtrans"
+	}
+
+	file, err := c.FormFile("file")
+	if err != nil {
+		log.Errorf("[SettlementHandler-3] ImportSettlement: %v", err)
+		return c.JSON(http.StatusUnprocessableEntity, response.ResponseDefault(err.Error(), nil))
+	}
+
+	if file.Size > maxSettlementFileSize {
+		log.Errorf("[SettlementHandler-4] ImportSettlement: file size %d exceeds limit", file.Size)
+		return c.JSON(http.StatusRequestEntityTooLarge, response.ResponseDefault("settlement file must not exceed 10MB", nil))
+	}
+
+	src, err := file.Open()
+	if err != nil {
+		log.Errorf("[SettlementHandler-5] ImportSettlement: %v", err)
+		return c.JSON(http.StatusInternalServerError, response.ResponseDefault(err.Error(), nil))
+	}
+	defer src.Close()
+
+	result, err := sh.settlementService.ImportSettlement(ctx, src, paymentMethod, startDate, endDate)
+	if err != nil {
+		log.Errorf("[SettlementHandler-6] ImportSettlement: %v", err)
+		if err.Error() == "400" {
+			return c.JSON(http.StatusBadRequest, response.ResponseDefault("settlement file must be a CSV with order id and gross amount columns", nil))
+		}
+		if strings.HasPrefix(err.Error(), "invalid") {
+			return c.JSON(http.StatusUnprocessableEntity, response.ResponseDefault(err.Error(), nil))
+		}
+		return c.JSON(http.StatusInternalServerError, response.ResponseDefault(err.Error(), nil))
+	}
+
+	resp := response.SettlementImportResponse{
+		TotalRows: result.TotalRows,
+		Matched:   result.Matched,
+		Flagged:   len(result.Flags),
+		Flags:     []response.SettlementFlagResponse{},
+	}
+	for _, val := range result.Flags {
+		resp.Flags = append(resp.Flags, response.SettlementFlagResponse{
+			Line:      val.Line,
+			OrderID:   val.OrderID,
+			PaymentID: val.PaymentID,
+			Flag:      val.Flag,
+			Detail:    val.Detail,
+		})
+	}
+
+	return c.JSON(http.StatusOK, response.ResponseDefault("success", resp))
+}
@@ -0,0 +1,243 @@
+package handlers
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"path/filepath"
+	"payment-service/config"
+	"payment-service/internal/adapter"
+	"payment-service/internal/adapter/handlers/request"
+	"payment-service/internal/adapter/handlers/response"
+	"payment-service/internal/core/domain/entity"
+	"payment-service/internal/core/service"
+	"payment-service/utils/conv"
+	"strconv"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+	"github.com/labstack/gommon/log"
+)
+
+const maxTransferProofSize = 5 << 20
+
+var allowedTransferProofTypes = map[string]bool{
+	"image/jpeg":      true,
+	"image/png":       true,
+	"application/pdf": true,
+}
+
+type TransferProofHandlerInterface interface {
+	Submit(c echo.Context) error
+	GetByPaymentID(c echo.Context) error
+	Confirm(c echo.Context) error
+	Reject(c echo.Context) error
+}
+
+type transferProofHandler struct {
+	transferProofService service.TransferProofServiceInterface
+}
+
+func NewTransferProofHandler(transferProofService service.TransferProofServiceInterface, e *echo.Echo, cfg *config.Config) TransferProofHandlerInterface {
+	transferProofHandler := &transferProofHandler{
+		transferProofService: transferProofService,
+	}
+	mid := adapter.NewMiddlewareAdapter(cfg)
+	authGroup := e.Group("auth", mid.CheckToken())
+	authGroup.POST("/payments/:id/transfer-proofs", transferProofHandler.Submit)
+	authGroup.GET("/payments/:id/transfer-proofs", transferProofHandler.GetByPaymentID)
+
+	adminGroup := e.Group("/admin", mid.CheckToken())
+	adminGroup.GET("/payments/:id/transfer-proofs", transferProofHandler.GetByPaymentID)
+	adminGroup.PUT("/transfer-proofs/:id/confirm", transferProofHandler.Confirm)
+	adminGroup.PUT("/transfer-proofs/:id/reject", transferProofHandler.Reject)
+
+	return transferProofHandler
+}
+
+func (th *transferProofHandler) Reject(c echo.Context) error {
+	return th.review(c, false)
+}
+
+func (th *transferProofHandler) Confirm(c echo.Context) error {
+	return th.review(c, true)
+}
+
+func (th *transferProofHandler) review(c echo.Context, approve bool) error {
+	var (
+		ctx = c.Request().Context()
+		req = request.TransferProofReviewRequest{}
+	)
+
+	user := c.Get("user").(string)
+	if user == "" {
+		log.Errorf("[TransferProofHandler-1] review: %s", "data token not found")
+		return c.JSON(http.StatusUnauthorized, response.ResponseDefault("data token not found", nil))
+	}
+
+	proofID, err := conv.StringToInt64(c.Param("id"))
+	if err != nil {
+		log.Errorf("[TransferProofHandler-2] review: %v", err)
+		return c.JSON(http.StatusBadRequest, response.ResponseDefault(err.Error(), nil))
+	}
+
+	if err := c.Bind(&req); err != nil {
+		log.Errorf("[TransferProofHandler-3] review: %v", err)
+		return c.JSON(http.StatusBadRequest, response.ResponseDefault(err.Error(), nil))
+	}
+
+	if !approve && strings.TrimSpace(req.Note) == "" {
+		log.Errorf("[TransferProofHandler-4] review: %s", "note is required when rejecting")
+		return c.JSON(http.StatusUnprocessableEntity, response.ResponseDefault("note is required when rejecting", nil))
+	}
+
+	result, err := th.transferProofService.ReviewTransferProof(ctx, uint(proofID), approve, req.Note, user)
+	if err != nil {
+		log.Errorf("[TransferProofHandler-5] review: %v", err)
+		switch err.Error() {
+		case "404":
+			return c.JSON(http.StatusNotFound, response.ResponseDefault("Transfer proof not found", nil))
+		case "409":
+			return c.JSON(http.StatusConflict, response.ResponseDefault("Transfer proof already reviewed", nil))
+		}
+		return c.JSON(http.StatusInternalServerError, response.ResponseDefault(err.Error(), nil))
+	}
+
+	return c.JSON(http.StatusOK, response.ResponseDefault("success", toTransferProofResponse(*result)))
+}
+
+func (th *transferProofHandler) GetByPaymentID(c echo.Context) error {
+	var (
+		ctx   = c.Request().Context()
+		resps = []response.TransferProofResponse{}
+	)
+
+	user := c.Get("user").(string)
+	if user == "" {
+		log.Errorf("[TransferProofHandler-1] GetByPaymentID: %s", "data token not found")
+		return c.JSON(http.StatusUnauthorized, response.ResponseDefault("data token not found", nil))
+	}
+
+	paymentID, err := conv.StringToInt64(c.Param("id"))
+	if err != nil {
+		log.Errorf("[TransferProofHandler-2] GetByPaymentID: %v", err)
+		return c.JSON(http.StatusBadRequest, response.ResponseDefault(err.Error(), nil))
+	}
+
+	results, err := th.transferProofService.GetByPaymentID(ctx, uint(paymentID), user)
+	if err != nil {
+		log.Errorf("[TransferProofHandler-3] GetByPaymentID: %v", err)
+		if err.Error() == "404" {
+			return c.JSON(http.StatusNotFound, response.ResponseDefault("Transfer proof not found", nil))
+		}
+		return c.JSON(http.StatusInternalServerError, response.ResponseDefault(err.Error(), nil))
+	}
+
+	for _, val := range results {
+		resps = append(resps, toTransferProofResponse(val))
+	}
+
+	return c.JSON(http.StatusOK, response.ResponseDefault("success", resps))
+}
+
+func (th *transferProofHandler) Submit(c echo.Context) error {
+	var (
+		ctx         = c.Request().Context()
+		jwtUserData = entity.JwtUserData{}
+	)
+
+	user := c.Get("user").(string)
+	if user == "" {
+		log.Errorf("[TransferProofHandler-1] Submit: %s", "data token not found")
+		return c.JSON(http.StatusUnauthorized, response.ResponseDefault("data token not found", nil))
+	}
+
+	if err := json.Unmarshal([]byte(user), &jwtUserData); err != nil {
+		log.Errorf("[TransferProofHandler-2] Submit: %v", err)
+		return c.JSON(http.StatusBadRequest, response.ResponseDefault(err.Error(), nil))
+	}
+
+	paymentID, err := conv.StringToInt64(c.Param("id"))
+	if err != nil {
+		log.Errorf("[TransferProofHandler-3] Submit: %v", err)
+		return c.JSON(http.StatusBadRequest, response.ResponseDefault(err.Error(), nil))
+	}
+
+	bankName := c.FormValue("bank_name")
+	accountName := c.FormValue("account_name")
+	amount, err := strconv.ParseFloat(c.FormValue("amount"), 64)
+	if bankName == "" || accountName == "" || err != nil || amount <= 0 {
+		log.Errorf("[TransferProofHandler-4] Submit: %s", "bank_name, account_name and amount are required")
+		return c.JSON(http.StatusUnprocessableEntity, response.ResponseDefault("bank_name, account_name and amount are required", nil))
+	}
+
+	file, err := c.FormFile("proof")
+	if err != nil {
+		log.Errorf("[TransferProofHandler-5] Submit: %v", err)
+		return c.JSON(http.StatusUnprocessableEntity, response.ResponseDefault(err.Error(), nil))
+	}
+
+	if file.Size > maxTransferProofSize {
+		log.Errorf("[TransferProofHandler-6] Submit: file size %d exceeds limit", file.Size)
+		return c.JSON(http.StatusRequestEntityTooLarge, response.ResponseDefault("transfer proof must not exceed 5MB", nil))
+	}
+
+	src, err := file.Open()
+	if err != nil {
+		log.Errorf("[TransferProofHandler-7] Submit: %v", err)
+		return c.JSON(http.StatusBadRequest, response.ResponseDefault(err.Error(), nil))
+	}
+
+	defer src.Close()
+
+	fileBuffer := new(bytes.Buffer)
+	if _, err = io.Copy(fileBuffer, io.LimitReader(src, maxTransferProofSize)); err != nil {
+		log.Errorf("[TransferProofHandler-8] Submit: %v", err)
+		return c.JSON(http.StatusInternalServerError, response.ResponseDefault(err.Error(), nil))
+	}
+
+	contentType := http.DetectContentType(fileBuffer.Bytes())
+	if !allowedTransferProofTypes[contentType] {
+		log.Errorf("[TransferProofHandler-9] Submit: unsupported content type %s", contentType)
+		return c.JSON(http.StatusUnsupportedMediaType, response.ResponseDefault("transfer proof must be a JPEG, PNG or PDF file", nil))
+	}
+
+	proof := entity.TransferProofEntity{
+		PaymentID:   uint(paymentID),
+		UserID:      uint(jwtUserData.UserID),
+		BankName:    bankName,
+		AccountName: accountName,
+		Amount:      amount,
+	}
+
+	result, err := th.transferProofService.SubmitTransferProof(ctx, proof, fileBuffer, strings.ToLower(filepath.Ext(file.Filename)), contentType)
+	if err != nil {
+		log.Errorf("[TransferProofHandler-10] Submit: %v", err)
+		switch err.Error() {
+		case "404":
+			return c.JSON(http.StatusNotFound, response.ResponseDefault("Payment not found", nil))
+		case "Payment method does not accept transfer proof", "Payment is not awaiting transfer proof":
+			return c.JSON(http.StatusUnprocessableEntity, response.ResponseDefault(err.Error(), nil))
+		}
+		return c.JSON(http.StatusInternalServerError, response.ResponseDefault(err.Error(), nil))
+	}
+
+	return c.JSON(http.StatusCreated, response.ResponseDefault("success", toTransferProofResponse(*result)))
+}
+
+func toTransferProofResponse(val entity.TransferProofEntity) response.TransferProofResponse {
+	return response.TransferProofResponse{
+		ID:          val.ID,
+		PaymentID:   val.PaymentID,
+		BankName:    val.BankName,
+		AccountName: val.AccountName,
+		Amount:      val.Amount,
+		ImageURL:    val.ImageURL,
+		Status:      val.Status,
+		Note:        val.Note,
+		ReviewedBy:  val.ReviewedBy,
+		ReviewedAt:  val.ReviewedAt,
+		CreatedAt:   val.CreatedAt,
+	}
+}
@@ -0,0 +1,191 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"payment-service/config"
+	"payment-service/internal/adapter"
+	"payment-service/internal/adapter/handlers/response"
+	"payment-service/internal/core/domain/entity"
+	"payment-service/internal/core/service"
+	"payment-service/utils/conv"
+
+	"github.com/labstack/echo/v4"
+	"github.com/labstack/gommon/log"
+)
+
+const maxWebhookBodySize = 1 << 20
+
+type WebhookInboxHandlerInterface interface {
+	MidtransWebhook(c echo.Context) error
+	GetAll(c echo.Context) error
+	GetByID(c echo.Context) error
+	Replay(c echo.Context) error
+}
+
+type webhookInboxHandler struct {
+	webhookInboxService service.WebhookInboxServiceInterface
+}
+
+func NewWebhookInboxHandler(webhookInboxService service.WebhookInboxServiceInterface, e *echo.Echo, cfg *config.Config) WebhookInboxHandlerInterface {
+	webhookInboxHandler := &webhookInboxHandler{
+		webhookInboxService: webhookInboxService,
+	}
+	mid := adapter.NewMiddlewareAdapter(cfg)
+	e.POST("/payments/webhook", webhookInboxHandler.MidtransWebhook)
+
+	adminGroup := e.Group("/admin", mid.CheckToken())
+	adminGroup.GET("/payments/webhooks", webhookInboxHandler.GetAll)
+	adminGroup.GET("/payments/webhooks/:id", webhookInboxHandler.GetByID)
+	adminGroup.POST("/payments/webhooks/:id/replay", webhookInboxHandler.Replay)
+
+	return webhookInboxHandler
+}
+
+// MidtransWebhook implements WebhookInboxHandlerInterface.
+// Gateway langsung mendapat 200 setelah signature valid dan payload tersimpan, pemrosesan berjalan di belakang.
+func (wh *webhookInboxHandler) MidtransWebhook(c echo.Context) error {
+	body, err := io.ReadAll(http.MaxBytesReader(c.Response(), c.Request().Body, maxWebhookBodySize))
+	if err != nil {
+		log.Errorf("[WebhookInboxHandler-1] MidtransWebhook: %v", err)
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			return c.JSON(http.StatusRequestEntityTooLarge, response.ResponseDefault("notification payload is too large", nil))
+		}
+		return c.JSON(http.StatusBadRequest, response.ResponseDefault(err.Error(), nil))
+	}
+
+	if len(body) == 0 {
+		log.Errorf("[WebhookInboxHandler-2] MidtransWebhook: %s", "empty payload")
+		return c.JSON(http.StatusBadRequest, response.ResponseDefault("invalid notification payload", nil))
+	}
+
+	if _, err := wh.webhookInboxService.Receive(c.Request().Context(), "midtrans", body); err != nil {
+		log.Errorf("[WebhookInboxHandler-3] MidtransWebhook: %v", err)
+		switch err.Error() {
+		case "400":
+			return c.JSON(http.StatusBadRequest, response.ResponseDefault("invalid notification payload", nil))
+		case "401":
+			return c.JSON(http.StatusUnauthorized, response.ResponseDefault("invalid signature", nil))
+		case "404":
+			return c.JSON(http.StatusNotFound, response.ResponseDefault("provider not found", nil))
+		}
+		return c.JSON(http.StatusInternalServerError, response.ResponseDefault(err.Error(), nil))
+	}
+
+	return c.JSON(http.StatusOK, response.ResponseDefault("success", nil))
+}
+
+// GetAll implements WebhookInboxHandlerInterface.
+func (wh *webhookInboxHandler) GetAll(c echo.Context) error {
+	var (
+		ctx   = c.Request().Context()
+		resps = []response.WebhookInboxResponse{}
+	)
+
+	var page int64 = 1
+	if pageStr := c.QueryParam("page"); pageStr != "" {
+		page, _ = conv.StringToInt64(pageStr)
+		if page <= 0 {
+			page = 1
+		}
+	}
+
+	var perPage int64 = 10
+	if perPageStr := c.QueryParam("perPage"); perPageStr != "" {
+		perPage, _ = conv.StringToInt64(perPageStr)
+		if perPage <= 0 {
+			perPage = 10
+		}
+	}
+
+	results, count, total, err := wh.webhookInboxService.GetAll(ctx, entity.WebhookInboxQueryEntity{
+		Page:     page,
+		Limit:    perPage,
+		Status:   c.QueryParam("status"),
+		Provider: c.QueryParam("provider"),
+	})
+	if err != nil {
+		log.Errorf("[WebhookInboxHandler-1] GetAll: %v", err)
+		if err.Error() == "404" {
+			return c.JSON(http.StatusNotFound, response.ResponseDefault("Webhook not found", nil))
+		}
+		return c.JSON(http.StatusInternalServerError, response.ResponseDefault(err.Error(), nil))
+	}
+
+	for _, val := range results {
+		resps = append(resps, toWebhookInboxResponse(val, false))
+	}
+
+	return c.JSON(http.StatusOK, response.ResponseSuccessWithPagination("success", resps, page, count, total, perPage))
+}
+
+// GetByID implements WebhookInboxHandlerInterface.
+func (wh *webhookInboxHandler) GetByID(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	webhookID, err := conv.StringToInt64(c.Param("id"))
+	if err != nil {
+		log.Errorf("[WebhookInboxHandler-1] GetByID: %v", err)
+		return c.JSON(http.StatusBadRequest, response.ResponseDefault(err.Error(), nil))
+	}
+
+	result, err := wh.webhookInboxService.GetByID(ctx, uint(webhookID))
+	if err != nil {
+		log.Errorf("[WebhookInboxHandler-2] GetByID: %v", err)
+		if err.Error() == "404" {
+			return c.JSON(http.StatusNotFound, response.ResponseDefault("Webhook not found", nil))
+		}
+		return c.JSON(http.StatusInternalServerError, response.ResponseDefault(err.Error(), nil))
+	}
+
+	return c.JSON(http.StatusOK, response.ResponseDefault("success", toWebhookInboxResponse(*result, true)))
+}
+
+// Replay implements WebhookInboxHandlerInterface.
+func (wh *webhookInboxHandler) Replay(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	webhookID, err := conv.StringToInt64(c.Param("id"))
+	if err != nil {
+		log.Errorf("[WebhookInboxHandler-1] Replay: %v", err)
+		return c.JSON(http.StatusBadRequest, response.ResponseDefault(err.Error(), nil))
+	}
+
+	if err := wh.webhookInboxService.Replay(ctx, uint(webhookID)); err != nil {
+		log.Errorf("[WebhookInboxHandler-2] Replay: %v", err)
+		switch err.Error() {
+		case "404":
+			return c.JSON(http.StatusNotFound, response.ResponseDefault("Webhook not found", nil))
+		case "409":
+			return c.JSON(http.StatusConflict, response.ResponseDefault("Webhook is being processed", nil))
+		}
+		return c.JSON(http.StatusInternalServerError, response.ResponseDefault(err.Error(), nil))
+	}
+
+	return c.JSON(http.StatusAccepted, response.ResponseDefault("success", nil))
+}
+
+func toWebhookInboxResponse(val entity.WebhookInboxEntity, withPayload bool) response.WebhookInboxResponse {
+	resp := response.WebhookInboxResponse{
+		ID:            val.ID,
+		Provider:      val.Provider,
+		Status:        val.Status,
+		Attempts:      val.Attempts,
+		LastError:     val.LastError,
+		NextAttemptAt: val.NextAttemptAt,
+		ProcessedAt:   val.ProcessedAt,
+		CreatedAt:     val.CreatedAt,
+	}
+
+	if withPayload {
+		resp.Payload = val.Payload
+		if json.Valid([]byte(val.Payload)) {
+			resp.Payload = json.RawMessage(val.Payload)
+		}
+	}
+
+	return resp
+}
@@ -1,7 +1,14 @@
 package httpclient
 
 import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
 	"payment-service/config"
+	"payment-service/internal/core/domain/entity"
+	"time"
 
 	"github.com/labstack/gommon/log"
 	"github.com/midtrans/midtrans-go"
@@ -10,6 +17,40 @@ import (
 
 type MidtransClientInterface interface {
 	CreateTransaction(orderID string, amount int64, customerName, customerEmail string) (string, error)
+	GetTransactionStatus(orderID string) (*entity.GatewayNotificationEntity, error)
+	RefundTransaction(orderID, refundKey string, amount int64, reason string) (string, error)
+	CancelTransaction(orderID string) error
+	Charge(charge entity.GatewayChargeEntity) (*entity.GatewayTransactionEntity, error)
+}
+
+type midtransStatusResponse struct {
+	StatusCode        string `json:"status_code"`
+	StatusMessage     string `json:"status_message"`
+	OrderID           string `json:"order_id"`
+	TransactionID     string `json:"transaction_id"`
+	TransactionStatus string `json:"transaction_status"`
+	FraudStatus       string `json:"fraud_status"`
+	PaymentType       string `json:"payment_type"`
+	GrossAmount       string `json:"gross_amount"`
+	SignatureKey      string `json:"signature_key"`
+}
+
+type midtransChargeResponse struct {
+	StatusCode        string `json:"status_code"`
+	StatusMessage     string `json:"status_message"`
+	TransactionID     string `json:"transaction_id"`
+	TransactionStatus string `json:"transaction_status"`
+	PermataVANumber   string `json:"permata_va_number"`
+	VANumbers         []struct {
+		Bank     string `json:"bank"`
+		VANumber string `json:"va_number"`
+	} `json:"va_numbers"`
+	QRString string `json:"qr_string"`
+	Actions  []struct {
+		Name string `json:"name"`
+		URL  string `json:"url"`
+	} `json:"actions"`
+	ExpiryTime string `json:"expiry_time"`
 }
 
 type midtransClient struct {
@@ -41,6 +82,194 @@ func (m *midtransClient) CreateTransaction(orderID string, amount int64, custome
 	return snapRes.Token, nil
 }
 
+// GetTransactionStatus implements MidtransClientInterface.
+func (m *midtransClient) GetTransactionStatus(orderID string) (*entity.GatewayNotificationEntity, error) {
+	resp, body, err := m.doRequest(http.MethodGet, fmt.Sprintf("/v2/%s/status", orderID), nil)
+	if err != nil {
+		log.Errorf("[MidtransClient-1] GetTransactionStatus: %v", err)
+		return nil, err
+	}
+
+	var statusResponse midtransStatusResponse
+	if err := json.Unmarshal(body, &statusResponse); err != nil {
+		log.Errorf("[MidtransClient-2] GetTransactionStatus: %v", err)
+		return nil, err
+	}
+
+	// Midtrans mengembalikan status_code di body, transaksi yang belum dibuat bernilai 404
+	if resp.StatusCode != http.StatusOK || statusResponse.TransactionStatus == "" {
+		log.Infof("[MidtransClient-3] GetTransactionStatus: order %s status %s %s", orderID, statusResponse.StatusCode, statusResponse.StatusMessage)
+		return nil, fmt.Errorf("transaction status unavailable: %s", statusResponse.StatusCode)
+	}
+
+	return &entity.GatewayNotificationEntity{
+		OrderID:           statusResponse.OrderID,
+		TransactionID:     statusResponse.TransactionID,
+		TransactionStatus: statusResponse.TransactionStatus,
+		FraudStatus:       statusResponse.FraudStatus,
+		PaymentType:       statusResponse.PaymentType,
+		StatusCode:        statusResponse.StatusCode,
+		GrossAmount:       statusResponse.GrossAmount,
+		SignatureKey:      statusResponse.SignatureKey,
+		RawPayload:        string(body),
+	}, nil
+}
+
+// RefundTransaction implements MidtransClientInterface.
+func (m *midtransClient) RefundTransaction(orderID string, refundKey string, amount int64, reason string) (string, error) {
+	payload, err := json.Marshal(map[string]interface{}{
+		"refund_key": refundKey,
+		"amount":     amount,
+		"reason":     reason,
+	})
+	if err != nil {
+		log.Errorf("[MidtransClient-1] RefundTransaction: %v", err)
+		return "", err
+	}
+
+	resp, body, err := m.doRequest(http.MethodPost, fmt.Sprintf("/v2/%s/refund", orderID), payload)
+	if err != nil {
+		log.Errorf("[MidtransClient-2] RefundTransaction: %v", err)
+		return "", err
+	}
+
+	var refundResponse midtransStatusResponse
+	if err := json.Unmarshal(body, &refundResponse); err != nil {
+		log.Errorf("[MidtransClient-3] RefundTransaction: %v", err)
+		return string(body), err
+	}
+
+	if resp.StatusCode != http.StatusOK || refundResponse.StatusCode != "200" {
+		log.Errorf("[MidtransClient-4] RefundTransaction: order %s status %s %s", orderID, refundResponse.StatusCode, refundResponse.StatusMessage)
+		return string(body), fmt.Errorf("refund rejected by gateway: %s %s", refundResponse.StatusCode, refundResponse.StatusMessage)
+	}
+
+	return string(body), nil
+}
+
+// CancelTransaction implements MidtransClientInterface.
+func (m *midtransClient) CancelTransaction(orderID string) error {
+	resp, body, err := m.doRequest(http.MethodPost, fmt.Sprintf("/v2/%s/cancel", orderID), nil)
+	if err != nil {
+		log.Errorf("[MidtransClient-1] CancelTransaction: %v", err)
+		return err
+	}
+
+	var cancelResponse midtransStatusResponse
+	if err := json.Unmarshal(body, &cancelResponse); err != nil {
+		log.Errorf("[MidtransClient-2] CancelTransaction: %v", err)
+		return err
+	}
+
+	if resp.StatusCode != http.StatusOK || cancelResponse.StatusCode != "200" {
+		log.Errorf("[MidtransClient-3] CancelTransaction: order %s status %s %s", orderID, cancelResponse.StatusCode, cancelResponse.StatusMessage)
+		return fmt.Errorf("cancel rejected by gateway: %s %s", cancelResponse.StatusCode, cancelResponse.StatusMessage)
+	}
+
+	return nil
+}
+
+// Charge implements MidtransClientInterface.
+func (m *midtransClient) Charge(charge entity.GatewayChargeEntity) (*entity.GatewayTransactionEntity, error) {
+	request := map[string]interface{}{
+		"payment_type": charge.PaymentType,
+		"transaction_details": map[string]interface{}{
+			"order_id":     charge.OrderID,
+			"gross_amount": charge.GrossAmount,
+		},
+		"customer_details": map[string]interface{}{
+			"first_name": charge.CustomerName,
+			"email":      charge.CustomerEmail,
+		},
+	}
+
+	switch charge.PaymentType {
+	case "bank_transfer":
+		request["bank_transfer"] = map[string]interface{}{"bank": charge.Bank}
+	case "qris":
+		request["qris"] = map[string]interface{}{"acquirer": "gopay"}
+	}
+
+	payload, err := json.Marshal(request)
+	if err != nil {
+		log.Errorf("[MidtransClient-1] Charge: %v", err)
+		return nil, err
+	}
+
+	resp, body, err := m.doRequest(http.MethodPost, "/v2/charge", payload)
+	if err != nil {
+		log.Errorf("[MidtransClient-2] Charge: %v", err)
+		return nil, err
+	}
+
+	var chargeResponse midtransChargeResponse
+	if err := json.Unmarshal(body, &chargeResponse); err != nil {
+		log.Errorf("[MidtransClient-3] Charge: %v", err)
+		return nil, err
+	}
+
+	if resp.StatusCode != http.StatusOK || chargeResponse.StatusCode != "201" {
+		log.Errorf("[MidtransClient-4] Charge: order %s status %s %s", charge.OrderID, chargeResponse.StatusCode, chargeResponse.StatusMessage)
+		return nil, fmt.Errorf("charge rejected by gateway: %s %s", chargeResponse.StatusCode, chargeResponse.StatusMessage)
+	}
+
+	result := &entity.GatewayTransactionEntity{
+		PaymentStatus:    "Pending",
+		PaymentGatewayID: chargeResponse.TransactionID,
+		PaymentChannel:   charge.Bank,
+		ExpiresAt:        chargeResponse.ExpiryTime,
+	}
+
+	switch charge.PaymentType {
+	case "bank_transfer":
+		result.PaymentCode = chargeResponse.PermataVANumber
+		for _, va := range chargeResponse.VANumbers {
+			if va.Bank == charge.Bank {
+				result.PaymentCode = va.VANumber
+			}
+		}
+	case "qris":
+		result.PaymentChannel = "qris"
+		result.PaymentCode = chargeResponse.QRString
+		for _, action := range chargeResponse.Actions {
+			if action.Name == "generate-qr-code" {
+				result.PaymentURL = action.URL
+			}
+		}
+	}
+
+	return result, nil
+}
+
+func (m *midtransClient) doRequest(method, path string, payload []byte) (*http.Response, []byte, error) {
+	baseUrl := m.cfg.Midtrans.BaseUrl
+	if baseUrl == "" {
+		baseUrl = midtrans.EnvironmentType(m.cfg.Midtrans.Environment).BaseUrl()
+	}
+
+	req, err := http.NewRequest(method, baseUrl+path, bytes.NewBuffer(payload))
+	if err != nil {
+		return nil, nil, err
+	}
+	req.SetBasicAuth(m.cfg.Midtrans.ServerKey, "")
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("Content-Type", "application/json")
+
+	client := &http.Client{Timeout: time.Duration(m.cfg.App.ServerTimeOut) * time.Second}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return resp, body, nil
+}
+
 func NewMidtransClient(cfg *config.Config) MidtransClientInterface {
 	return &midtransClient{cfg: cfg}
 }
@@ -0,0 +1,91 @@
+package httpclient
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"payment-service/config"
+	"testing"
+	"time"
+)
+
+// newMidtransTestServer meniru endpoint status Midtrans, order ORD-SLOW sengaja dibalas melebihi timeout client.
+func newMidtransTestServer(t *testing.T) *httptest.Server {
+	t.Helper()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if user, _, ok := r.BasicAuth(); !ok || user != "server-key" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/v2/ORD-PAID/status":
+			w.Write([]byte(`{"status_code":"200","order_id":"ORD-PAID","transaction_id":"trx-1","transaction_status":"settlement","fraud_status":"accept","payment_type":"bank_transfer","gross_amount":"150000.00"}`))
+		case "/v2/ORD-EXPIRED/status":
+			w.Write([]byte(`{"status_code":"407","order_id":"ORD-EXPIRED","transaction_id":"trx-2","transaction_status":"expire","payment_type":"qris","gross_amount":"150000.00"}`))
+		case "/v2/ORD-SLOW/status":
+			time.Sleep(1500 * time.Millisecond)
+			w.Write([]byte(`{"status_code":"200","order_id":"ORD-SLOW","transaction_status":"settlement","gross_amount":"150000.00"}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"status_code":"404","status_message":"Transaction doesn't exist."}`))
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	return server
+}
+
+func newMidtransTestConfig(baseUrl string) *config.Config {
+	return &config.Config{
+		App:      config.App{ServerTimeOut: 1},
+		Midtrans: config.Midtrans{ServerKey: "server-key", BaseUrl: baseUrl},
+	}
+}
+
+func TestMidtransClientGetTransactionStatus(t *testing.T) {
+	server := newMidtransTestServer(t)
+	client := NewMidtransClient(newMidtransTestConfig(server.URL))
+
+	tests := []struct {
+		name              string
+		orderID           string
+		wantErr           bool
+		transactionStatus string
+		transactionID     string
+	}{
+		{name: "settlement", orderID: "ORD-PAID", transactionStatus: "settlement", transactionID: "trx-1"},
+		{name: "expire", orderID: "ORD-EXPIRED", transactionStatus: "expire", transactionID: "trx-2"},
+		{name: "not found", orderID: "ORD-UNKNOWN", wantErr: true},
+		{name: "timeout", orderID: "ORD-SLOW", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			status, err := client.GetTransactionStatus(tt.orderID)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("expected error, got status %+v", status)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if status.TransactionStatus != tt.transactionStatus {
+				t.Errorf("transaction status = %q, want %q", status.TransactionStatus, tt.transactionStatus)
+			}
+			if status.TransactionID != tt.transactionID {
+				t.Errorf("transaction id = %q, want %q", status.TransactionID, tt.transactionID)
+			}
+			if status.OrderID != tt.orderID {
+				t.Errorf("order id = %q, want %q", status.OrderID, tt.orderID)
+			}
+			if status.RawPayload == "" {
+				t.Error("raw payload is empty")
+			}
+		})
+	}
+}
@@ -0,0 +1,311 @@
+package httpclient
+
+import (
+	"bytes"
+	"crypto/rand"
+	"crypto/sha512"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"payment-service/config"
+	"payment-service/internal/core/domain/entity"
+	"sync"
+	"time"
+
+	"github.com/labstack/gommon/log"
+)
+
+// MidtransSandboxInterface adalah pengganti Midtrans untuk development, transaksi disimpan di memori
+// dan perubahan status dikirim ke webhook payment-service dengan format dan signature yang sama dengan Midtrans.
+type MidtransSandboxInterface interface {
+	MidtransClientInterface
+	Simulate(orderID, action string, amount int64) (*entity.GatewayNotificationEntity, error)
+}
+
+type sandboxTransaction struct {
+	orderID           string
+	transactionID     string
+	transactionStatus string
+	paymentType       string
+	grossAmount       int64
+	refundedAmount    int64
+}
+
+type midtransSandboxClient struct {
+	cfg          *config.Config
+	mu           sync.Mutex
+	transactions map[string]*sandboxTransaction
+}
+
+// CreateTransaction implements MidtransClientInterface.
+func (m *midtransSandboxClient) CreateTransaction(orderID string, amount int64, customerName string, customerEmail string) (string, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if _, ok := m.transactions[orderID]; ok {
+		log.Errorf("[MidtransSandboxClient-1] CreateTransaction: order %s already has a transaction", orderID)
+		return "", errors.New("409")
+	}
+
+	m.transactions[orderID] = &sandboxTransaction{
+		orderID:           orderID,
+		transactionID:     "sandbox-" + randomHex(16),
+		transactionStatus: "pending",
+		paymentType:       "sandbox",
+		grossAmount:       amount,
+	}
+
+	return "sandbox-snap-" + randomHex(16), nil
+}
+
+// Charge implements MidtransClientInterface.
+// Nomor VA dan QR string dibuat acak, transaksi tetap pending sampai disimulasikan lewat Simulate.
+func (m *midtransSandboxClient) Charge(charge entity.GatewayChargeEntity) (*entity.GatewayTransactionEntity, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if _, ok := m.transactions[charge.OrderID]; ok {
+		log.Errorf("[MidtransSandboxClient-1] Charge: order %s already has a transaction", charge.OrderID)
+		return nil, errors.New("409")
+	}
+
+	trx := &sandboxTransaction{
+		orderID:           charge.OrderID,
+		transactionID:     "sandbox-" + randomHex(16),
+		transactionStatus: "pending",
+		paymentType:       charge.PaymentType,
+		grossAmount:       charge.GrossAmount,
+	}
+
+	result := &entity.GatewayTransactionEntity{
+		PaymentStatus:    "Pending",
+		PaymentGatewayID: trx.transactionID,
+	}
+
+	switch charge.PaymentType {
+	case "bank_transfer":
+		result.PaymentChannel = charge.Bank
+		result.PaymentCode = fmt.Sprintf("%d", 8800000000000000+time.Now().UnixNano()%100000000000000)
+		result.ExpiresAt = time.Now().Add(24 * time.Hour).Format("2006-01-02 15:04:05")
+	case "qris":
+		result.PaymentChannel = "qris"
+		result.PaymentCode = "00020101021226620014COM.GO-JEK.WWW0118" + randomHex(16)
+		result.PaymentURL = fmt.Sprintf("https://sandbox.local/qris/%s/qr-code", trx.transactionID)
+		result.ExpiresAt = time.Now().Add(15 * time.Minute).Format("2006-01-02 15:04:05")
+	default:
+		log.Errorf("[MidtransSandboxClient-2] Charge: unsupported payment type %s", charge.PaymentType)
+		return nil, errors.New("400")
+	}
+
+	m.transactions[charge.OrderID] = trx
+
+	return result, nil
+}
+
+// GetTransactionStatus implements MidtransClientInterface.
+func (m *midtransSandboxClient) GetTransactionStatus(orderID string) (*entity.GatewayNotificationEntity, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	trx, ok := m.transactions[orderID]
+	if !ok {
+		log.Infof("[MidtransSandboxClient-1] GetTransactionStatus: order %s not found", orderID)
+		return nil, fmt.Errorf("transaction status unavailable: %s", "404")
+	}
+
+	return m.notification(trx), nil
+}
+
+// RefundTransaction implements MidtransClientInterface.
+func (m *midtransSandboxClient) RefundTransaction(orderID string, refundKey string, amount int64, reason string) (string, error) {
+	m.mu.Lock()
+	trx, ok := m.transactions[orderID]
+	if !ok {
+		m.mu.Unlock()
+		return "", fmt.Errorf("refund rejected by gateway: %s %s", "404", "Transaction doesn't exist.")
+	}
+
+	if err := m.refund(trx, amount); err != nil {
+		m.mu.Unlock()
+		return "", fmt.Errorf("refund rejected by gateway: %s %s", "412", err.Error())
+	}
+	notification := m.notification(trx)
+	m.mu.Unlock()
+
+	// Midtrans mengirim notifikasi refund secara asinkron setelah API refund berhasil
+	go m.sendWebhook(notification)
+
+	body, _ := json.Marshal(map[string]interface{}{
+		"status_code":        "200",
+		"status_message":     "Success, refund request is approved",
+		"order_id":           orderID,
+		"transaction_id":     trx.transactionID,
+		"transaction_status": notification.TransactionStatus,
+		"refund_key":         refundKey,
+		"refund_amount":      fmt.Sprintf("%d.00", amount),
+		"reason":             reason,
+	})
+
+	return string(body), nil
+}
+
+// CancelTransaction implements MidtransClientInterface.
+func (m *midtransSandboxClient) CancelTransaction(orderID string) error {
+	notification, err := m.transition(orderID, "cancel", 0)
+	if err != nil {
+		return fmt.Errorf("cancel rejected by gateway: %s", err.Error())
+	}
+
+	go m.sendWebhook(notification)
+
+	return nil
+}
+
+// Simulate implements MidtransSandboxInterface.
+func (m *midtransSandboxClient) Simulate(orderID string, action string, amount int64) (*entity.GatewayNotificationEntity, error) {
+	notification, err := m.transition(orderID, action, amount)
+	if err != nil {
+		log.Errorf("[MidtransSandboxClient-1] Simulate: %v", err)
+		return nil, err
+	}
+
+	if err := m.sendWebhook(notification); err != nil {
+		log.Errorf("[MidtransSandboxClient-2] Simulate: %v", err)
+		return notification, err
+	}
+
+	return notification, nil
+}
+
+func (m *midtransSandboxClient) transition(orderID, action string, amount int64) (*entity.GatewayNotificationEntity, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	trx, ok := m.transactions[orderID]
+	if !ok {
+		return nil, errors.New("404")
+	}
+
+	switch action {
+	case "settlement", "deny", "expire", "cancel":
+		if trx.transactionStatus != "pending" {
+			return nil, errors.New("409")
+		}
+		trx.transactionStatus = action
+	case "refund":
+		if err := m.refund(trx, amount); err != nil {
+			return nil, err
+		}
+	default:
+		return nil, errors.New("400")
+	}
+
+	return m.notification(trx), nil
+}
+
+// refund mengikuti aturan Midtrans: hanya transaksi settlement yang bisa direfund, amount 0 berarti sisa penuh.
+func (m *midtransSandboxClient) refund(trx *sandboxTransaction, amount int64) error {
+	if trx.transactionStatus != "settlement" && trx.transactionStatus != "partial_refund" {
+		return errors.New("409")
+	}
+
+	remaining := trx.grossAmount - trx.refundedAmount
+	if amount == 0 {
+		amount = remaining
+	}
+	if amount <= 0 || amount > remaining {
+		return errors.New("422")
+	}
+
+	trx.refundedAmount += amount
+	trx.transactionStatus = "partial_refund"
+	if trx.refundedAmount == trx.grossAmount {
+		trx.transactionStatus = "refund"
+	}
+
+	return nil
+}
+
+func (m *midtransSandboxClient) notification(trx *sandboxTransaction) *entity.GatewayNotificationEntity {
+	statusCode := "200"
+	switch trx.transactionStatus {
+	case "pending":
+		statusCode = "201"
+	case "deny":
+		statusCode = "202"
+	case "expire":
+		statusCode = "407"
+	}
+
+	grossAmount := fmt.Sprintf("%d.00", trx.grossAmount)
+	hash := sha512.Sum512([]byte(trx.orderID + statusCode + grossAmount + m.cfg.Midtrans.ServerKey))
+
+	notification := &entity.GatewayNotificationEntity{
+		OrderID:           trx.orderID,
+		TransactionID:     trx.transactionID,
+		TransactionStatus: trx.transactionStatus,
+		FraudStatus:       "accept",
+		PaymentType:       trx.paymentType,
+		StatusCode:        statusCode,
+		GrossAmount:       grossAmount,
+		SignatureKey:      hex.EncodeToString(hash[:]),
+	}
+
+	payload, _ := json.Marshal(map[string]string{
+		"order_id":           notification.OrderID,
+		"transaction_id":     notification.TransactionID,
+		"transaction_status": notification.TransactionStatus,
+		"transaction_time":   time.Now().Format("2006-01-02 15:04:05"),
+		"fraud_status":       notification.FraudStatus,
+		"payment_type":       notification.PaymentType,
+		"status_code":        notification.StatusCode,
+		"gross_amount":       notification.GrossAmount,
+		"signature_key":      notification.SignatureKey,
+		"currency":           "IDR",
+	})
+	notification.RawPayload = string(payload)
+
+	return notification
+}
+
+func (m *midtransSandboxClient) sendWebhook(notification *entity.GatewayNotificationEntity) error {
+	webhookUrl := m.cfg.Midtrans.SandboxWebhookUrl
+	if webhookUrl == "" {
+		webhookUrl = fmt.Sprintf("http://localhost:%s/payments/webhook", m.cfg.App.AppPort)
+	}
+
+	client := &http.Client{Timeout: time.Duration(m.cfg.App.ServerTimeOut) * time.Second}
+	resp, err := client.Post(webhookUrl, "application/json", bytes.NewBufferString(notification.RawPayload))
+	if err != nil {
+		log.Errorf("[MidtransSandboxClient-1] sendWebhook: %v", err)
+		return err
+	}
+	defer resp.Body.Close()
+
+	body, _ := io.ReadAll(resp.Body)
+	if resp.StatusCode != http.StatusOK {
+		log.Errorf("[MidtransSandboxClient-2] sendWebhook: order %s status %d %s", notification.OrderID, resp.StatusCode, string(body))
+		return fmt.Errorf("webhook rejected: %d %s", resp.StatusCode, string(body))
+	}
+
+	return nil
+}
+
+func randomHex(n int) string {
+	b := make([]byte, n)
+	if _, err := rand.Read(b); err != nil {
+		return fmt.Sprintf("%d", time.Now().UnixNano())
+	}
+
+	return hex.EncodeToString(b)
+}
+
+func NewMidtransSandboxClient(cfg *config.Config) MidtransSandboxInterface {
+	return &midtransSandboxClient{
+		cfg:          cfg,
+		transactions: map[string]*sandboxTransaction{},
+	}
+}
@@ -11,18 +11,42 @@ import (
 )
 
 type PublishRabbitMQInterface interface {
+	PublishPaymentCreated(payment entity.PaymentEntity) error
 	PublishPaymentSuccess(payment entity.PaymentEntity) error
+	PublishPaymentFailed(payment entity.PaymentEntity) error
+	PublishPaymentExpired(payment entity.PaymentEntity) error
+	PublishPaymentRefunded(payment entity.PaymentEntity, refund entity.RefundEntity) error
+	PublishNotification(queueName, notifType, subject, message, receiverEmail string, receiverID int64) error
 }
 
 type PublishRabbitMQ struct {
 	cfg *config.Config
 }
 
+// PublishPaymentCreated implements PublishRabbitMQInterface.
+func (p *PublishRabbitMQ) PublishPaymentCreated(payment entity.PaymentEntity) error {
+	return p.publishPaymentEvent(p.cfg.PublisherName.PaymentCreated, "PublishPaymentCreated", payment)
+}
+
 // PublishPaymentSuccess implements PublishRabbitMQInterface.
 func (p *PublishRabbitMQ) PublishPaymentSuccess(payment entity.PaymentEntity) error {
+	return p.publishPaymentEvent(p.cfg.PublisherName.PaymentSuccess, "PublishPaymentSuccess", payment)
+}
+
+// PublishPaymentFailed implements PublishRabbitMQInterface.
+func (p *PublishRabbitMQ) PublishPaymentFailed(payment entity.PaymentEntity) error {
+	return p.publishPaymentEvent(p.cfg.PublisherName.PaymentFailed, "PublishPaymentFailed", payment)
+}
+
+// PublishPaymentExpired implements PublishRabbitMQInterface.
+func (p *PublishRabbitMQ) PublishPaymentExpired(payment entity.PaymentEntity) error {
+	return p.publishPaymentEvent(p.cfg.PublisherName.PaymentExpired, "PublishPaymentExpired", payment)
+}
+
+func (p *PublishRabbitMQ) publishPaymentEvent(queueName, logPrefix string, payment entity.PaymentEntity) error {
 	conn, err := p.cfg.NewRabbitMQ()
 	if err != nil {
-		log.Errorf("[PublishPaymentSuccess-1] Failed to connect to RabbitMQ: %v", err)
+		log.Errorf("[%s-1] Failed to connect to RabbitMQ: %v", logPrefix, err)
 		return err
 	}
 
@@ -30,14 +54,14 @@ func (p *PublishRabbitMQ) PublishPaymentSuccess(payment entity.PaymentEntity) er
 
 	ch, err := conn.Channel()
 	if err != nil {
-		log.Errorf("[PublishPaymentSuccess-2] Failed to open a channel: %v", err)
+		log.Errorf("[%s-2] Failed to open a channel: %v", logPrefix, err)
 		return err
 	}
 
 	defer ch.Close()
 
 	q, err := ch.QueueDeclare(
-		p.cfg.PublisherName.PaymentSuccess,
+		queueName,
 		true,
 		false,
 		false,
@@ -45,13 +69,17 @@ func (p *PublishRabbitMQ) PublishPaymentSuccess(payment entity.PaymentEntity) er
 		nil,
 	)
 	if err != nil {
-		log.Errorf("[PublishPaymentSuccess-3] Failed to declare queue: %v", err)
+		log.Errorf("[%s-3] Failed to declare queue: %v", logPrefix, err)
 		return err
 	}
 
 	paymentOrder := map[string]string{
 		"orderID":       fmt.Sprintf("%d", payment.OrderID),
+		"orderCode":     payment.OrderCode,
+		"paymentID":     fmt.Sprintf("%d", payment.ID),
 		"paymentMethod": payment.PaymentMethod,
+		"paymentStatus": payment.PaymentStatus,
+		"grossAmount":   fmt.Sprintf("%.2f", payment.GrossAmount),
 	}
 
 	data, _ := json.Marshal(paymentOrder)
@@ -66,7 +94,130 @@ func (p *PublishRabbitMQ) PublishPaymentSuccess(payment entity.PaymentEntity) er
 		},
 	)
 	if err != nil {
-		log.Errorf("[PublishPaymentSuccess-4] Failed to publish message: %v", err)
+		log.Errorf("[%s-4] Failed to publish message: %v", logPrefix, err)
+		return err
+	}
+
+	return nil
+}
+
+// PublishPaymentRefunded implements PublishRabbitMQInterface.
+func (p *PublishRabbitMQ) PublishPaymentRefunded(payment entity.PaymentEntity, refund entity.RefundEntity) error {
+	conn, err := p.cfg.NewRabbitMQ()
+	if err != nil {
+		log.Errorf("[PublishPaymentRefunded-1] Failed to connect to RabbitMQ: %v", err)
+		return err
+	}
+
+	defer conn.Close()
+
+	ch, err := conn.Channel()
+	if err != nil {
+		log.Errorf("[PublishPaymentRefunded-2] Failed to open a channel: %v", err)
+		return err
+	}
+
+	defer ch.Close()
+
+	q, err := ch.QueueDeclare(
+		p.cfg.PublisherName.PaymentRefunded,
+		true,
+		false,
+		false,
+		false,
+		nil,
+	)
+	if err != nil {
+		log.Errorf("[PublishPaymentRefunded-3] Failed to declare queue: %v", err)
+		return err
+	}
+
+	paymentRefund := map[string]string{
+		"orderID":       fmt.Sprintf("%d", payment.OrderID),
+		"paymentID":     fmt.Sprintf("%d", payment.ID),
+		"paymentStatus": payment.PaymentStatus,
+		"refundID":      fmt.Sprintf("%d", refund.ID),
+		"refundAmount":  fmt.Sprintf("%.2f", refund.Amount),
+		"refundReason":  refund.Reason,
+		"refundMethod":  refund.RefundMethod,
+	}
+
+	data, _ := json.Marshal(paymentRefund)
+	err = ch.Publish(
+		"",
+		q.Name,
+		false,
+		false,
+		amqp.Publishing{
+			ContentType: "application/json",
+			Body:        data,
+		},
+	)
+	if err != nil {
+		log.Errorf("[PublishPaymentRefunded-4] Failed to publish message: %v", err)
+		return err
+	}
+
+	return nil
+}
+
+// PublishNotification implements PublishRabbitMQInterface.
+func (p *PublishRabbitMQ) PublishNotification(queueName string, notifType string, subject string, message string, receiverEmail string, receiverID int64) error {
+	conn, err := p.cfg.NewRabbitMQ()
+	if err != nil {
+		log.Errorf("[PublishNotification-1] Failed to connect to RabbitMQ: %v", err)
+		return err
+	}
+
+	defer conn.Close()
+
+	ch, err := conn.Channel()
+	if err != nil {
+		log.Errorf("[PublishNotification-2] Failed to open a channel: %v", err)
+		return err
+	}
+
+	defer ch.Close()
+
+	q, err := ch.QueueDeclare(
+		queueName,
+		true,
+		false,
+		false,
+		false,
+		nil,
+	)
+	if err != nil {
+		log.Errorf("[PublishNotification-3] Failed to declare queue: %v", err)
+		return err
+	}
+
+	notification := map[string]interface{}{
+		"receiver_email":    receiverEmail,
+		"message":           message,
+		"subject":           subject,
+		"receiver_id":       receiverID,
+		"notification_type": notifType,
+	}
+
+	data, err := json.Marshal(notification)
+	if err != nil {
+		log.Errorf("[PublishNotification-4] Failed to marshal JSON: %v", err)
+		return err
+	}
+
+	err = ch.Publish(
+		"",
+		q.Name,
+		false,
+		false,
+		amqp.Publishing{
+			ContentType: "application/json",
+			Body:        data,
+		},
+	)
+	if err != nil {
+		log.Errorf("[PublishNotification-5] Failed to publish message: %v", err)
 		return err
 	}
 
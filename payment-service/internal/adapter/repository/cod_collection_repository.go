@@ -0,0 +1,122 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"payment-service/internal/core/domain/entity"
+	"payment-service/internal/core/domain/model"
+	"time"
+
+	"github.com/labstack/gommon/log"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type CodCollectionRepositoryInterface interface {
+	CreateCollection(ctx context.Context, collection entity.CodCollectionEntity) (uint, error)
+	GetReconciliation(ctx context.Context, startDate, endDate time.Time, courierID uint) ([]entity.CodReconciliationEntity, error)
+}
+
+type codCollectionRepository struct {
+	db *gorm.DB
+}
+
+// CreateCollection implements CodCollectionRepositoryInterface.
+// Pencatatan uang tunai dan perubahan payment menjadi Success dilakukan dalam satu transaksi,
+// payment dikunci agar konfirmasi ganda dari kurir dan admin tidak tercatat dua kali.
+func (c *codCollectionRepository) CreateCollection(ctx context.Context, collection entity.CodCollectionEntity) (uint, error) {
+	modelCollection := model.CodCollection{
+		PaymentID:       collection.PaymentID,
+		CourierID:       collection.CourierID,
+		ExpectedAmount:  collection.ExpectedAmount,
+		CollectedAmount: collection.CollectedAmount,
+		ConfirmedBy:     collection.ConfirmedBy,
+		CollectedAt:     time.Now(),
+	}
+	if collection.Note != "" {
+		modelCollection.Note = &collection.Note
+	}
+
+	err := c.db.Transaction(func(tx *gorm.DB) error {
+		modelPayment := model.Payment{}
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("id = ?", collection.PaymentID).First(&modelPayment).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return errors.New("404")
+			}
+			return err
+		}
+
+		if modelPayment.PaymentStatus != "AwaitingCollection" {
+			log.Infof("[CodCollectionRepository-1] CreateCollection: payment %d has status %s", modelPayment.ID, modelPayment.PaymentStatus)
+			return errors.New("409")
+		}
+
+		if err := tx.Create(&modelCollection).Error; err != nil {
+			return err
+		}
+
+		return tx.Model(&modelPayment).Update("payment_status", "Success").Error
+	})
+	if err != nil {
+		log.Errorf("[CodCollectionRepository-2] CreateCollection: %v", err)
+		return 0, err
+	}
+
+	return modelCollection.ID, nil
+}
+
+// GetReconciliation implements CodCollectionRepositoryInterface.
+// Nominal yang diharapkan dihitung dari semua payment COD pada periode, termasuk yang masih AwaitingCollection.
+// Payment yang belum ditagih belum memiliki kurir sehingga tercatat pada courier_id 0 dan tidak ikut saat filter kurir dipakai.
+func (c *codCollectionRepository) GetReconciliation(ctx context.Context, startDate time.Time, endDate time.Time, courierID uint) ([]entity.CodReconciliationEntity, error) {
+	rows := []struct {
+		Date              time.Time
+		CourierID         uint
+		CollectionCount   int64
+		ExpectedAmount    float64
+		CollectedAmount   float64
+		UncollectedCount  int64
+		UncollectedAmount float64
+	}{}
+
+	sqlMain := c.db.Model(&model.Payment{}).
+		Select("COALESCE(DATE(cod_collections.collected_at), DATE(payments.created_at)) AS date, "+
+			"COALESCE(cod_collections.courier_id, 0) AS courier_id, "+
+			"COUNT(cod_collections.id) AS collection_count, "+
+			"COALESCE(SUM(COALESCE(cod_collections.expected_amount, payments.gross_amount)), 0) AS expected_amount, "+
+			"COALESCE(SUM(cod_collections.collected_amount), 0) AS collected_amount, "+
+			"COUNT(*) - COUNT(cod_collections.id) AS uncollected_count, "+
+			"COALESCE(SUM(CASE WHEN cod_collections.id IS NULL THEN payments.gross_amount ELSE 0 END), 0) AS uncollected_amount").
+		Joins("LEFT JOIN cod_collections ON cod_collections.payment_id = payments.id").
+		Where("payments.payment_method = ? AND payments.deleted_at IS NULL", "cod").
+		Where("cod_collections.id IS NOT NULL OR payments.payment_status = ?", "AwaitingCollection").
+		Where("(cod_collections.collected_at >= ? AND cod_collections.collected_at < ?) OR (cod_collections.id IS NULL AND payments.created_at >= ? AND payments.created_at < ?)",
+			startDate, endDate, startDate, endDate)
+	if courierID != 0 {
+		sqlMain = sqlMain.Where("cod_collections.courier_id = ?", courierID)
+	}
+
+	if err := sqlMain.Group("1, 2").Order("date ASC, courier_id ASC").Scan(&rows).Error; err != nil {
+		log.Errorf("[CodCollectionRepository-1] GetReconciliation: %v", err)
+		return nil, err
+	}
+
+	entities := []entity.CodReconciliationEntity{}
+	for _, val := range rows {
+		entities = append(entities, entity.CodReconciliationEntity{
+			Date:              val.Date.Format("2006-01-02"),
+			CourierID:         val.CourierID,
+			CollectionCount:   val.CollectionCount,
+			ExpectedAmount:    val.ExpectedAmount,
+			CollectedAmount:   val.CollectedAmount,
+			UncollectedCount:  val.UncollectedCount,
+			UncollectedAmount: val.UncollectedAmount,
+		})
+	}
+
+	return entities, nil
+}
+
+func NewCodCollectionRepository(db *gorm.DB) CodCollectionRepositoryInterface {
+	return &codCollectionRepository{db: db}
+}
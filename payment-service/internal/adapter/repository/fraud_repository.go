@@ -0,0 +1,200 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"math"
+	"payment-service/internal/core/domain/entity"
+	"payment-service/internal/core/domain/model"
+	"time"
+
+	"github.com/labstack/gommon/log"
+	"gorm.io/gorm"
+)
+
+type FraudRepositoryInterface interface {
+	CreateCheck(ctx context.Context, check entity.FraudCheckEntity) (uint, error)
+	GetLatestByOrderID(ctx context.Context, orderID uint) (*entity.FraudCheckEntity, error)
+	GetAll(ctx context.Context, query entity.FraudCheckQueryEntity) ([]entity.FraudCheckEntity, int64, int64, error)
+	GetByID(ctx context.Context, checkID uint) (*entity.FraudCheckEntity, error)
+	UpdateReview(ctx context.Context, checkID uint, status, note string, reviewedBy uint) error
+	CountFailedPayments(ctx context.Context, userID uint, since time.Time) (int64, error)
+}
+
+type fraudRepository struct {
+	db *gorm.DB
+}
+
+// CreateCheck implements FraudRepositoryInterface.
+func (f *fraudRepository) CreateCheck(ctx context.Context, check entity.FraudCheckEntity) (uint, error) {
+	modelCheck := model.FraudCheck{
+		OrderID:     check.OrderID,
+		UserID:      check.UserID,
+		ClientIP:    check.ClientIP,
+		Phone:       check.Phone,
+		GrossAmount: check.GrossAmount,
+		Decision:    check.Decision,
+		Status:      check.Status,
+	}
+	for _, hit := range check.RuleHits {
+		modelCheck.RuleHits = append(modelCheck.RuleHits, model.FraudRuleHit{
+			Rule:   hit.Rule,
+			Action: hit.Action,
+			Detail: hit.Detail,
+		})
+	}
+
+	if err := f.db.Create(&modelCheck).Error; err != nil {
+		log.Errorf("[FraudRepository-1] CreateCheck: %v", err)
+		return 0, err
+	}
+
+	return modelCheck.ID, nil
+}
+
+// GetLatestByOrderID implements FraudRepositoryInterface.
+func (f *fraudRepository) GetLatestByOrderID(ctx context.Context, orderID uint) (*entity.FraudCheckEntity, error) {
+	modelCheck := model.FraudCheck{}
+
+	if err := f.db.Where("order_id = ?", orderID).Order("created_at DESC, id DESC").First(&modelCheck).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			err = errors.New("404")
+			log.Infof("[FraudRepository-1] GetLatestByOrderID: No fraud check found")
+			return nil, err
+		}
+		log.Errorf("[FraudRepository-2] GetLatestByOrderID: %v", err)
+		return nil, err
+	}
+
+	result := toFraudCheckEntity(modelCheck)
+	return &result, nil
+}
+
+// GetAll implements FraudRepositoryInterface.
+func (f *fraudRepository) GetAll(ctx context.Context, query entity.FraudCheckQueryEntity) ([]entity.FraudCheckEntity, int64, int64, error) {
+	modelChecks := []model.FraudCheck{}
+	var countData int64
+	offset := (query.Page - 1) * query.Limit
+
+	sqlMain := f.db.Model(&model.FraudCheck{})
+	if query.Status != "" {
+		sqlMain = sqlMain.Where("status = ?", query.Status)
+	}
+	if query.Decision != "" {
+		sqlMain = sqlMain.Where("decision = ?", query.Decision)
+	}
+
+	if err := sqlMain.Count(&countData).Error; err != nil {
+		log.Errorf("[FraudRepository-1] GetAll: %v", err)
+		return nil, 0, 0, err
+	}
+
+	totalPage := int(math.Ceil(float64(countData) / float64(query.Limit)))
+	if err := sqlMain.Preload("RuleHits").Order("created_at ASC, id ASC").Limit(int(query.Limit)).Offset(int(offset)).Find(&modelChecks).Error; err != nil {
+		log.Errorf("[FraudRepository-2] GetAll: %v", err)
+		return nil, 0, 0, err
+	}
+
+	if len(modelChecks) == 0 {
+		err := errors.New("404")
+		log.Infof("[FraudRepository-3] GetAll: No fraud check found")
+		return nil, 0, 0, err
+	}
+
+	entities := []entity.FraudCheckEntity{}
+	for _, val := range modelChecks {
+		entities = append(entities, toFraudCheckEntity(val))
+	}
+
+	return entities, countData, int64(totalPage), nil
+}
+
+// GetByID implements FraudRepositoryInterface.
+func (f *fraudRepository) GetByID(ctx context.Context, checkID uint) (*entity.FraudCheckEntity, error) {
+	modelCheck := model.FraudCheck{}
+
+	if err := f.db.Preload("RuleHits").Where("id = ?", checkID).First(&modelCheck).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			err = errors.New("404")
+			log.Infof("[FraudRepository-1] GetByID: No fraud check found")
+			return nil, err
+		}
+		log.Errorf("[FraudRepository-2] GetByID: %v", err)
+		return nil, err
+	}
+
+	result := toFraudCheckEntity(modelCheck)
+	return &result, nil
+}
+
+// UpdateReview implements FraudRepositoryInterface.
+func (f *fraudRepository) UpdateReview(ctx context.Context, checkID uint, status string, note string, reviewedBy uint) error {
+	result := f.db.Model(&model.FraudCheck{}).Where("id = ? AND status = ?", checkID, "PendingReview").Updates(map[string]interface{}{
+		"status":      status,
+		"review_note": note,
+		"reviewed_by": reviewedBy,
+		"reviewed_at": time.Now(),
+	})
+	if result.Error != nil {
+		log.Errorf("[FraudRepository-1] UpdateReview: %v", result.Error)
+		return result.Error
+	}
+
+	if result.RowsAffected == 0 {
+		log.Infof("[FraudRepository-2] UpdateReview: fraud check %d already reviewed", checkID)
+		return errors.New("409")
+	}
+
+	return nil
+}
+
+// CountFailedPayments implements FraudRepositoryInterface.
+func (f *fraudRepository) CountFailedPayments(ctx context.Context, userID uint, since time.Time) (int64, error) {
+	var count int64
+
+	if err := f.db.Model(&model.Payment{}).
+		Where("user_id = ? AND payment_status IN ? AND created_at >= ?", userID, []string{"Failed", "Expired"}, since).
+		Count(&count).Error; err != nil {
+		log.Errorf("[FraudRepository-1] CountFailedPayments: %v", err)
+		return 0, err
+	}
+
+	return count, nil
+}
+
+func toFraudCheckEntity(val model.FraudCheck) entity.FraudCheckEntity {
+	result := entity.FraudCheckEntity{
+		ID:          val.ID,
+		OrderID:     val.OrderID,
+		UserID:      val.UserID,
+		ClientIP:    val.ClientIP,
+		Phone:       val.Phone,
+		GrossAmount: val.GrossAmount,
+		Decision:    val.Decision,
+		Status:      val.Status,
+		CreatedAt:   val.CreatedAt.Format("2006-01-02 15:04:05"),
+		RuleHits:    []entity.FraudRuleHitEntity{},
+	}
+	if val.ReviewNote != nil {
+		result.ReviewNote = *val.ReviewNote
+	}
+	if val.ReviewedBy != nil {
+		result.ReviewedBy = *val.ReviewedBy
+	}
+	if val.ReviewedAt != nil {
+		result.ReviewedAt = val.ReviewedAt.Format("2006-01-02 15:04:05")
+	}
+	for _, hit := range val.RuleHits {
+		result.RuleHits = append(result.RuleHits, entity.FraudRuleHitEntity{
+			Rule:   hit.Rule,
+			Action: hit.Action,
+			Detail: hit.Detail,
+		})
+	}
+
+	return result
+}
+
+func NewFraudRepository(db *gorm.DB) FraudRepositoryInterface {
+	return &fraudRepository{db: db}
+}
@@ -3,37 +3,146 @@ package repository
 import (
 	"context"
 	"errors"
+	"fmt"
 	"math"
 	"payment-service/internal/core/domain/entity"
 	"payment-service/internal/core/domain/model"
+	"time"
 
 	"github.com/labstack/gommon/log"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type PaymentRepositoryInterface interface {
-	CreatePayment(ctx context.Context, payment entity.PaymentEntity) error
-	LogPayment(ctx context.Context, paymentID uint, status string) error
-	UpdateStatusByOrderCode(ctx context.Context, orderID uint, status string) error
+	CreatePayment(ctx context.Context, payment entity.PaymentEntity) (*entity.PaymentEntity, error)
+	SaveTransaction(ctx context.Context, payment entity.PaymentEntity, paymentLog entity.PaymentLogEntity) error
+	LogPayment(ctx context.Context, paymentLog entity.PaymentLogEntity) error
+	LogPaymentWithStatus(ctx context.Context, paymentLog entity.PaymentLogEntity, fromStatus, status string) (bool, error)
+	IsEventLogged(ctx context.Context, eventKey string) (bool, error)
+	GetLogsByPaymentID(ctx context.Context, paymentID uint) ([]entity.PaymentLogEntity, error)
+	UpdateStatus(ctx context.Context, paymentID uint, status string) error
 	GetAll(ctx context.Context, req entity.PaymentQueryStringRequest) ([]entity.PaymentEntity, int64, int64, error)
 	GetDetail(ctx context.Context, paymentID uint) (*entity.PaymentEntity, error)
-	GetByOrderID(ctx context.Context, orderID uint) error
+	GetAttemptsByOrderID(ctx context.Context, orderID uint) ([]entity.PaymentEntity, error)
+	GetDetailByOrderID(ctx context.Context, orderID uint) (*entity.PaymentEntity, error)
+	GetDetailByGatewayOrderID(ctx context.Context, gatewayOrderID string) (*entity.PaymentEntity, error)
+	GetForReconciliation(ctx context.Context, updatedSince time.Time) ([]entity.PaymentEntity, error)
 }
 
 type paymentRepository struct {
 	db *gorm.DB
 }
 
-// GetByOrderID implements PaymentRepositoryInterface.
-func (p *paymentRepository) GetByOrderID(ctx context.Context, orderID uint) error {
+// GetAttemptsByOrderID implements PaymentRepositoryInterface.
+func (p *paymentRepository) GetAttemptsByOrderID(ctx context.Context, orderID uint) ([]entity.PaymentEntity, error) {
+	modelPayments := []model.Payment{}
+
+	if err := p.db.Where("order_id = ?", orderID).Order("attempt_number ASC, id ASC").Find(&modelPayments).Error; err != nil {
+		log.Errorf("[PaymentRepository-1] GetAttemptsByOrderID: %v", err)
+		return nil, err
+	}
+
+	entities := []entity.PaymentEntity{}
+	for _, val := range modelPayments {
+		entities = append(entities, entity.PaymentEntity{
+			ID:             val.ID,
+			OrderID:        val.OrderID,
+			OrderCode:      val.OrderCode,
+			GatewayOrderID: val.GatewayOrderID,
+			AttemptNumber:  val.AttemptNumber,
+			UserID:         val.UserID,
+			PaymentMethod:  val.PaymentMethod,
+			PaymentStatus:  val.PaymentStatus,
+			GrossAmount:    val.GrossAmount,
+			PaymentAt:      val.CreatedAt.Format("2006-01-02 15:04:05"),
+		})
+	}
+
+	return entities, nil
+}
+
+// GetForReconciliation implements PaymentRepositoryInterface.
+// Percobaan Pending dan percobaan yang berubah sejak updatedSince dicek ke gateway,
+// transisi status yang tidak diizinkan disaring oleh service.
+func (p *paymentRepository) GetForReconciliation(ctx context.Context, updatedSince time.Time) ([]entity.PaymentEntity, error) {
+	modelPayments := []model.Payment{}
+
+	if err := p.db.Where("payment_method IN ? AND (gateway_order_id <> '' OR order_code <> '')", []string{"midtrans", "virtual_account", "qris"}).
+		Where("payment_status = ? OR updated_at >= ?", "Pending", updatedSince).
+		Order("updated_at ASC").
+		Find(&modelPayments).Error; err != nil {
+		log.Errorf("[PaymentRepository-1] GetForReconciliation: %v", err)
+		return nil, err
+	}
+
+	entities := []entity.PaymentEntity{}
+	for _, val := range modelPayments {
+		entities = append(entities, entity.PaymentEntity{
+			ID:             val.ID,
+			OrderID:        val.OrderID,
+			OrderCode:      val.OrderCode,
+			GatewayOrderID: val.GatewayOrderID,
+			AttemptNumber:  val.AttemptNumber,
+			UserID:         val.UserID,
+			PaymentMethod:  val.PaymentMethod,
+			PaymentStatus:  val.PaymentStatus,
+			GrossAmount:    val.GrossAmount,
+		})
+	}
+
+	return entities, nil
+}
+
+// GetDetailByOrderID implements PaymentRepositoryInterface.
+// Order dengan beberapa percobaan pembayaran mengembalikan percobaan terakhir.
+func (p *paymentRepository) GetDetailByOrderID(ctx context.Context, orderID uint) (*entity.PaymentEntity, error) {
+	return p.getDetailWhere("GetDetailByOrderID", p.db.Where("order_id = ?", orderID).Order("attempt_number DESC, id DESC"))
+}
+
+// GetDetailByGatewayOrderID implements PaymentRepositoryInterface.
+func (p *paymentRepository) GetDetailByGatewayOrderID(ctx context.Context, gatewayOrderID string) (*entity.PaymentEntity, error) {
+	return p.getDetailWhere("GetDetailByGatewayOrderID", p.db.Where("gateway_order_id = ?", gatewayOrderID))
+}
+
+func (p *paymentRepository) getDetailWhere(method string, query *gorm.DB) (*entity.PaymentEntity, error) {
 	modelPayment := model.Payment{}
 
-	if err := p.db.Where("order_id = ?", orderID).First(&modelPayment).Error; err != nil {
-		log.Errorf("[PaymentRepository-1] GetByOrderID: %v", err)
-		return err
+	if err := query.First(&modelPayment).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			err = errors.New("404")
+			log.Infof("[PaymentRepository-1] %s: No payment found", method)
+			return nil, err
+		}
+		log.Errorf("[PaymentRepository-2] %s: %v", method, err)
+		return nil, err
 	}
 
-	return nil
+	paymentGatewayID := ""
+	if modelPayment.PaymentGatewayID != nil {
+		paymentGatewayID = *modelPayment.PaymentGatewayID
+	}
+
+	paymentURL := ""
+	if modelPayment.PaymentURL != nil {
+		paymentURL = *modelPayment.PaymentURL
+	}
+
+	return &entity.PaymentEntity{
+		ID:               modelPayment.ID,
+		OrderID:          modelPayment.OrderID,
+		OrderCode:        modelPayment.OrderCode,
+		GatewayOrderID:   modelPayment.GatewayOrderID,
+		AttemptNumber:    modelPayment.AttemptNumber,
+		UserID:           modelPayment.UserID,
+		PaymentMethod:    modelPayment.PaymentMethod,
+		PaymentStatus:    modelPayment.PaymentStatus,
+		PaymentGatewayID: paymentGatewayID,
+		GrossAmount:      modelPayment.GrossAmount,
+		PaymentURL:       paymentURL,
+		PaymentChannel:   modelPayment.PaymentChannel,
+		PaymentAt:        modelPayment.CreatedAt.Format("2006-01-02 15:04:05"),
+	}, nil
 }
 
 // GetDetail implements PaymentRepositoryInterface.
@@ -50,15 +159,47 @@ func (p *paymentRepository) GetDetail(ctx context.Context, paymentID uint) (*ent
 		return nil, err
 	}
 
+	orderSnapshot := ""
+	if modelPayment.OrderSnapshot != nil {
+		orderSnapshot = *modelPayment.OrderSnapshot
+	}
+
+	paymentCode := ""
+	if modelPayment.PaymentCode != nil {
+		paymentCode = *modelPayment.PaymentCode
+	}
+
+	expiresAt := ""
+	if modelPayment.ExpiresAt != nil {
+		expiresAt = modelPayment.ExpiresAt.Format("2006-01-02 15:04:05")
+	}
+
+	paymentGatewayID := ""
+	if modelPayment.PaymentGatewayID != nil {
+		paymentGatewayID = *modelPayment.PaymentGatewayID
+	}
+
+	paymentURL := ""
+	if modelPayment.PaymentURL != nil {
+		paymentURL = *modelPayment.PaymentURL
+	}
+
 	return &entity.PaymentEntity{
 		ID:               modelPayment.ID,
 		OrderID:          modelPayment.OrderID,
+		OrderCode:        modelPayment.OrderCode,
+		GatewayOrderID:   modelPayment.GatewayOrderID,
+		AttemptNumber:    modelPayment.AttemptNumber,
 		UserID:           modelPayment.UserID,
 		PaymentMethod:    modelPayment.PaymentMethod,
 		PaymentStatus:    modelPayment.PaymentStatus,
-		PaymentGatewayID: *modelPayment.PaymentGatewayID,
+		PaymentGatewayID: paymentGatewayID,
 		GrossAmount:      modelPayment.GrossAmount,
-		PaymentURL:       *modelPayment.PaymentURL,
+		PaymentURL:       paymentURL,
+		OrderSnapshot:    orderSnapshot,
+		PaymentChannel:   modelPayment.PaymentChannel,
+		PaymentCode:      paymentCode,
+		ExpiresAt:        expiresAt,
 		PaymentAt:        modelPayment.CreatedAt.Format("2006-01-02 15:04:05"),
 	}, nil
 }
@@ -95,49 +236,200 @@ func (p *paymentRepository) GetAll(ctx context.Context, req entity.PaymentQueryS
 
 	entities := []entity.PaymentEntity{}
 	for _, val := range modelPayments {
+		paymentGatewayID := ""
+		if val.PaymentGatewayID != nil {
+			paymentGatewayID = *val.PaymentGatewayID
+		}
+
+		paymentURL := ""
+		if val.PaymentURL != nil {
+			paymentURL = *val.PaymentURL
+		}
+
 		entities = append(entities, entity.PaymentEntity{
 			ID:               val.ID,
 			OrderID:          val.OrderID,
+			AttemptNumber:    val.AttemptNumber,
 			UserID:           val.UserID,
 			PaymentMethod:    val.PaymentMethod,
 			PaymentStatus:    val.PaymentStatus,
-			PaymentGatewayID: *val.PaymentGatewayID,
+			PaymentGatewayID: paymentGatewayID,
 			GrossAmount:      val.GrossAmount,
-			PaymentURL:       *val.PaymentURL,
+			PaymentURL:       paymentURL,
 		})
 	}
 
 	return entities, countData, int64(totalPage), nil
 }
 
-// UpdateStatusByOrderCode implements PaymentRepositoryInterface.
-func (p *paymentRepository) UpdateStatusByOrderCode(ctx context.Context, orderID uint, status string) error {
+// UpdateStatus implements PaymentRepositoryInterface.
+// Hanya satu percobaan per order yang boleh Success, percobaan order yang sama dikunci selama pengecekan.
+func (p *paymentRepository) UpdateStatus(ctx context.Context, paymentID uint, status string) error {
+	return p.db.Transaction(func(tx *gorm.DB) error {
+		return updateStatusTx(tx, paymentID, status)
+	})
+}
+
+// updateStatusTx mengubah status payment di dalam transaksi tx, error 409 jika percobaan lain untuk order yang sama sudah dibayar.
+func updateStatusTx(tx *gorm.DB, paymentID uint, status string) error {
 	modelPayment := model.Payment{}
 
-	if err := p.db.Where("order_id = ?", orderID).First(&modelPayment).Error; err != nil {
-		log.Errorf("[PaymentRepository] UpdateStatusByOrderCode-1: %v", err)
+	if err := tx.Where("id = ?", paymentID).First(&modelPayment).Error; err != nil {
+		log.Errorf("[PaymentRepository] UpdateStatus-1: %v", err)
 		return err
 	}
 
-	modelPayment.PaymentStatus = status
+	if status == "Success" {
+		attempts := []model.Payment{}
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("order_id = ?", modelPayment.OrderID).
+			Find(&attempts).Error; err != nil {
+			log.Errorf("[PaymentRepository] UpdateStatus-2: %v", err)
+			return err
+		}
+
+		for _, val := range attempts {
+			if val.ID != modelPayment.ID && (val.PaymentStatus == "Success" || val.PaymentStatus == "Refunded" || val.PaymentStatus == "Partially Refunded") {
+				log.Errorf("[PaymentRepository] UpdateStatus-3: order %d already paid by payment %d", modelPayment.OrderID, val.ID)
+				return errors.New("409")
+			}
+		}
+	}
 
-	if err := p.db.Save(&modelPayment).Error; err != nil {
-		log.Errorf("[PaymentRepository] UpdateStatusByOrderCode-2: %v", err)
+	if err := tx.Model(&modelPayment).Update("payment_status", status).Error; err != nil {
+		log.Errorf("[PaymentRepository] UpdateStatus-4: %v", err)
 		return err
 	}
 
 	return nil
 }
 
+// GetLogsByPaymentID implements PaymentRepositoryInterface.
+func (p *paymentRepository) GetLogsByPaymentID(ctx context.Context, paymentID uint) ([]entity.PaymentLogEntity, error) {
+	modelLogs := []model.PaymentLog{}
+
+	if err := p.db.Where("payment_id = ?", paymentID).Order("created_at ASC, id ASC").Find(&modelLogs).Error; err != nil {
+		log.Errorf("[PaymentRepository-1] GetLogsByPaymentID: %v", err)
+		return nil, err
+	}
+
+	if len(modelLogs) == 0 {
+		err := errors.New("404")
+		log.Infof("[PaymentRepository-2] GetLogsByPaymentID: No payment log found")
+		return nil, err
+	}
+
+	entities := []entity.PaymentLogEntity{}
+	for _, val := range modelLogs {
+		paymentLog := entity.PaymentLogEntity{
+			ID:        val.ID,
+			PaymentID: val.PaymentID,
+			Status:    val.Status,
+			Source:    val.Source,
+			CreatedAt: val.CreatedAt.Format("2006-01-02 15:04:05"),
+		}
+		if val.EventKey != nil {
+			paymentLog.EventKey = *val.EventKey
+		}
+		if val.Payload != nil {
+			paymentLog.Payload = *val.Payload
+		}
+		entities = append(entities, paymentLog)
+	}
+
+	return entities, nil
+}
+
+// IsEventLogged implements PaymentRepositoryInterface.
+func (p *paymentRepository) IsEventLogged(ctx context.Context, eventKey string) (bool, error) {
+	var count int64
+
+	if err := p.db.Model(&model.PaymentLog{}).Where("event_key = ?", eventKey).Count(&count).Error; err != nil {
+		log.Errorf("[PaymentRepository] IsEventLogged-1: %v", err)
+		return false, err
+	}
+
+	return count > 0, nil
+}
+
 // LogPayment implements PaymentRepositoryInterface.
-func (p *paymentRepository) LogPayment(ctx context.Context, paymentID uint, status string) error {
+// Event key yang sudah tercatat mengembalikan error 409 sehingga pemanggil bisa menganggapnya sudah diproses.
+func (p *paymentRepository) LogPayment(ctx context.Context, paymentLog entity.PaymentLogEntity) error {
+	return createPaymentLog(p.db, paymentLog)
+}
+
+// LogPaymentWithStatus implements PaymentRepositoryInterface.
+// Log dan perubahan status ditulis dalam satu transaksi, sehingga event key hanya tersimpan jika status berhasil diubah
+// dan retry dari inbox tetap memproses ulang event yang gagal. Status kosong berarti hanya mencatat log.
+// Status hanya diubah jika status payment saat dikunci masih sama dengan fromStatus.
+// Nilai false tanpa error berarti log tersimpan tetapi status tidak diubah karena statusnya sudah berubah
+// atau percobaan lain sudah dibayar.
+func (p *paymentRepository) LogPaymentWithStatus(ctx context.Context, paymentLog entity.PaymentLogEntity, fromStatus, status string) (bool, error) {
+	updated := false
+	err := p.db.Transaction(func(tx *gorm.DB) error {
+		if err := createPaymentLog(tx, paymentLog); err != nil {
+			return err
+		}
+
+		if status == "" {
+			return nil
+		}
+
+		modelPayment := model.Payment{}
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("id = ?", paymentLog.PaymentID).First(&modelPayment).Error; err != nil {
+			log.Errorf("[PaymentRepository] LogPaymentWithStatus-1: %v", err)
+			return err
+		}
+
+		if modelPayment.PaymentStatus != fromStatus {
+			log.Infof("[PaymentRepository] LogPaymentWithStatus-2: payment %d is %s, expected %s", modelPayment.ID, modelPayment.PaymentStatus, fromStatus)
+			return nil
+		}
+
+		if err := updateStatusTx(tx, paymentLog.PaymentID, status); err != nil {
+			if err.Error() == "409" {
+				return nil
+			}
+			return err
+		}
+
+		updated = true
+		return nil
+	})
+	if err != nil {
+		log.Errorf("[PaymentRepository] LogPaymentWithStatus-3: %v", err)
+		return false, err
+	}
+
+	return updated, nil
+}
+
+// createPaymentLog menyimpan satu baris PaymentLog memakai db atau transaksi yang diberikan.
+func createPaymentLog(db *gorm.DB, paymentLog entity.PaymentLogEntity) error {
 	logPayment := model.PaymentLog{
-		PaymentID: paymentID,
-		Status:    status,
+		PaymentID: paymentLog.PaymentID,
+		Status:    paymentLog.Status,
+		Source:    paymentLog.Source,
+	}
+
+	if logPayment.Source == "" {
+		logPayment.Source = "system"
+	}
+
+	if paymentLog.EventKey != "" {
+		logPayment.EventKey = &paymentLog.EventKey
 	}
 
-	if err := p.db.Create(&logPayment).Error; err != nil {
-		log.Errorf("[PaymentRepository] LogPayment-1: %v", err)
+	if paymentLog.Payload != "" {
+		logPayment.Payload = &paymentLog.Payload
+	}
+
+	if err := db.Create(&logPayment).Error; err != nil {
+		if errors.Is(err, gorm.ErrDuplicatedKey) {
+			log.Infof("[PaymentRepository] LogPayment-1: event %s already logged", paymentLog.EventKey)
+			return errors.New("409")
+		}
+		log.Errorf("[PaymentRepository] LogPayment-2: %v", err)
 		return err
 	}
 
@@ -145,23 +437,98 @@ func (p *paymentRepository) LogPayment(ctx context.Context, paymentID uint, stat
 }
 
 // CreatePayment implements PaymentRepositoryInterface.
-func (p *paymentRepository) CreatePayment(ctx context.Context, payment entity.PaymentEntity) error {
+// Nomor percobaan dialokasikan saat percobaan order yang sama dikunci, unique index (order_id, attempt_number)
+// menjadi pengaman terakhir. Percobaan baru berstatus Pending sampai data dari gateway disimpan.
+func (p *paymentRepository) CreatePayment(ctx context.Context, payment entity.PaymentEntity) (*entity.PaymentEntity, error) {
 	modelPayment := model.Payment{
-		OrderID:          payment.OrderID,
-		UserID:           payment.UserID,
-		PaymentMethod:    payment.PaymentMethod,
-		PaymentStatus:    payment.PaymentStatus,
-		PaymentGatewayID: &payment.PaymentGatewayID,
-		GrossAmount:      payment.GrossAmount,
-		PaymentURL:       &payment.PaymentURL,
+		OrderID:        payment.OrderID,
+		OrderCode:      payment.OrderCode,
+		UserID:         payment.UserID,
+		PaymentMethod:  payment.PaymentMethod,
+		PaymentStatus:  "Pending",
+		GrossAmount:    payment.GrossAmount,
+		OrderSnapshot:  &payment.OrderSnapshot,
+		PaymentChannel: payment.PaymentChannel,
 	}
 
-	if err := p.db.Create(&modelPayment).Error; err != nil {
-		log.Errorf("[PaymentRepository] Create-1: %v", err)
-		return err
+	err := p.db.Transaction(func(tx *gorm.DB) error {
+		attempts := []model.Payment{}
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("order_id = ?", payment.OrderID).
+			Find(&attempts).Error; err != nil {
+			log.Errorf("[PaymentRepository] CreatePayment-1: %v", err)
+			return err
+		}
+
+		for _, val := range attempts {
+			if val.PaymentStatus == "Success" || val.PaymentStatus == "Refunded" || val.PaymentStatus == "Partially Refunded" || val.PaymentStatus == "AwaitingCollection" {
+				log.Infof("[PaymentRepository] CreatePayment-2: order %d already paid by payment %d", payment.OrderID, val.ID)
+				return errors.New("Payment already exists")
+			}
+			if val.AttemptNumber > modelPayment.AttemptNumber {
+				modelPayment.AttemptNumber = val.AttemptNumber
+			}
+		}
+
+		// Gateway menolak order_id yang sama dipakai ulang, percobaan berikutnya diberi suffix nomor percobaan
+		modelPayment.AttemptNumber++
+		modelPayment.GatewayOrderID = payment.OrderCode
+		if modelPayment.AttemptNumber > 1 {
+			modelPayment.GatewayOrderID = fmt.Sprintf("%s-%d", payment.OrderCode, modelPayment.AttemptNumber)
+		}
+
+		if err := tx.Create(&modelPayment).Error; err != nil {
+			if errors.Is(err, gorm.ErrDuplicatedKey) {
+				return errors.New("409")
+			}
+			log.Errorf("[PaymentRepository] CreatePayment-3: %v", err)
+			return err
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	payment.ID = modelPayment.ID
+	payment.AttemptNumber = modelPayment.AttemptNumber
+	payment.GatewayOrderID = modelPayment.GatewayOrderID
+	payment.PaymentStatus = modelPayment.PaymentStatus
+	return &payment, nil
+}
+
+// SaveTransaction implements PaymentRepositoryInterface.
+// Data dari gateway dan log pembuatan transaksi ditulis dalam satu transaksi.
+func (p *paymentRepository) SaveTransaction(ctx context.Context, payment entity.PaymentEntity, paymentLog entity.PaymentLogEntity) error {
+	updates := map[string]interface{}{
+		"payment_status":     payment.PaymentStatus,
+		"payment_gateway_id": payment.PaymentGatewayID,
+		"payment_url":        payment.PaymentURL,
+		"payment_channel":    payment.PaymentChannel,
+	}
+
+	if payment.PaymentCode != "" {
+		updates["payment_code"] = payment.PaymentCode
+	}
+
+	if payment.ExpiresAt != "" {
+		expiresAt, err := time.ParseInLocation("2006-01-02 15:04:05", payment.ExpiresAt, time.Local)
+		if err != nil {
+			log.Errorf("[PaymentRepository] SaveTransaction-1: %v", err)
+			return err
+		}
+		updates["expires_at"] = expiresAt
 	}
 
-	return p.LogPayment(ctx, modelPayment.ID, modelPayment.PaymentStatus)
+	return p.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&model.Payment{}).Where("id = ?", payment.ID).Updates(updates).Error; err != nil {
+			log.Errorf("[PaymentRepository] SaveTransaction-2: %v", err)
+			return err
+		}
+
+		return createPaymentLog(tx, paymentLog)
+	})
 }
 
 func NewPaymentRepository(db *gorm.DB) PaymentRepositoryInterface {
@@ -0,0 +1,196 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"payment-service/internal/core/domain/entity"
+	"payment-service/internal/core/domain/model"
+
+	"github.com/labstack/gommon/log"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type RefundRepositoryInterface interface {
+	ReserveRefund(ctx context.Context, refund entity.RefundEntity) (*entity.RefundEntity, error)
+	CompleteRefund(ctx context.Context, refund entity.RefundEntity) (string, error)
+	GetByPaymentID(ctx context.Context, paymentID uint) ([]entity.RefundEntity, error)
+}
+
+type refundRepository struct {
+	db *gorm.DB
+}
+
+// GetByPaymentID implements RefundRepositoryInterface.
+func (r *refundRepository) GetByPaymentID(ctx context.Context, paymentID uint) ([]entity.RefundEntity, error) {
+	modelRefunds := []model.Refund{}
+
+	if err := r.db.Where("payment_id = ?", paymentID).Order("created_at DESC").Find(&modelRefunds).Error; err != nil {
+		log.Errorf("[RefundRepository-1] GetByPaymentID: %v", err)
+		return nil, err
+	}
+
+	if len(modelRefunds) == 0 {
+		err := errors.New("404")
+		log.Infof("[RefundRepository-2] GetByPaymentID: No refund found")
+		return nil, err
+	}
+
+	entities := []entity.RefundEntity{}
+	for _, val := range modelRefunds {
+		gatewayResponse := ""
+		if val.GatewayResponse != nil {
+			gatewayResponse = *val.GatewayResponse
+		}
+
+		entities = append(entities, entity.RefundEntity{
+			ID:              val.ID,
+			PaymentID:       val.PaymentID,
+			RefundKey:       val.RefundKey,
+			Amount:          val.Amount,
+			Reason:          val.Reason,
+			RefundMethod:    val.RefundMethod,
+			Status:          val.Status,
+			GatewayResponse: gatewayResponse,
+			CreatedBy:       val.CreatedBy,
+			CreatedAt:       val.CreatedAt.Format("2006-01-02 15:04:05"),
+		})
+	}
+
+	return entities, nil
+}
+
+// ReserveRefund implements RefundRepositoryInterface.
+// Payment dikunci selama sisa dana dihitung dan refund disimpan sebagai Pending,
+// sehingga refund yang berjalan bersamaan tidak bisa melebihi dana yang dibayar.
+// Amount 0 berarti refund penuh atas sisa dana yang belum dikembalikan.
+func (r *refundRepository) ReserveRefund(ctx context.Context, refund entity.RefundEntity) (*entity.RefundEntity, error) {
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		modelPayment := model.Payment{}
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("id = ?", refund.PaymentID).First(&modelPayment).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				log.Infof("[RefundRepository-1] ReserveRefund: No payment found")
+				return errors.New("404")
+			}
+			log.Errorf("[RefundRepository-2] ReserveRefund: %v", err)
+			return err
+		}
+
+		if modelPayment.PaymentStatus != "Success" && modelPayment.PaymentStatus != "Partially Refunded" {
+			log.Infof("[RefundRepository-3] ReserveRefund: payment %d has status %s", modelPayment.ID, modelPayment.PaymentStatus)
+			return errors.New("Payment is not refundable")
+		}
+
+		refundedAmount, err := sumRefundedAmount(tx, modelPayment.ID, []string{"Pending", "Success"})
+		if err != nil {
+			log.Errorf("[RefundRepository-4] ReserveRefund: %v", err)
+			return err
+		}
+
+		remaining := modelPayment.GrossAmount - refundedAmount
+		if refund.Amount == 0 {
+			refund.Amount = remaining
+		}
+		if refund.Amount <= 0 || refund.Amount > remaining+0.01 {
+			log.Infof("[RefundRepository-5] ReserveRefund: amount %.2f exceeds refundable %.2f", refund.Amount, remaining)
+			return errors.New("Refund amount exceeds refundable amount")
+		}
+
+		modelRefund := model.Refund{
+			PaymentID:    refund.PaymentID,
+			RefundKey:    refund.RefundKey,
+			Amount:       refund.Amount,
+			Reason:       refund.Reason,
+			RefundMethod: refund.RefundMethod,
+			Status:       "Pending",
+			CreatedBy:    refund.CreatedBy,
+		}
+
+		if err := tx.Create(&modelRefund).Error; err != nil {
+			log.Errorf("[RefundRepository-6] ReserveRefund: %v", err)
+			return err
+		}
+
+		refund.ID = modelRefund.ID
+		refund.Status = modelRefund.Status
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &refund, nil
+}
+
+// CompleteRefund implements RefundRepositoryInterface.
+// Status payment dihitung ulang dari refund yang Success di dalam transaksi yang sama,
+// mengembalikan status payment setelah refund selesai.
+func (r *refundRepository) CompleteRefund(ctx context.Context, refund entity.RefundEntity) (string, error) {
+	paymentStatus := ""
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		modelPayment := model.Payment{}
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("id = ?", refund.PaymentID).First(&modelPayment).Error; err != nil {
+			log.Errorf("[RefundRepository-1] CompleteRefund: %v", err)
+			return err
+		}
+
+		updates := map[string]interface{}{
+			"refund_method": refund.RefundMethod,
+			"status":        refund.Status,
+		}
+		if refund.GatewayResponse != "" {
+			updates["gateway_response"] = refund.GatewayResponse
+		}
+
+		if err := tx.Model(&model.Refund{}).Where("id = ?", refund.ID).Updates(updates).Error; err != nil {
+			log.Errorf("[RefundRepository-2] CompleteRefund: %v", err)
+			return err
+		}
+
+		paymentStatus = modelPayment.PaymentStatus
+		if refund.Status != "Success" {
+			return nil
+		}
+
+		refundedAmount, err := sumRefundedAmount(tx, modelPayment.ID, []string{"Success"})
+		if err != nil {
+			log.Errorf("[RefundRepository-3] CompleteRefund: %v", err)
+			return err
+		}
+
+		paymentStatus = "Partially Refunded"
+		if refundedAmount >= modelPayment.GrossAmount-0.01 {
+			paymentStatus = "Refunded"
+		}
+
+		if err := tx.Model(&modelPayment).Update("payment_status", paymentStatus).Error; err != nil {
+			log.Errorf("[RefundRepository-4] CompleteRefund: %v", err)
+			return err
+		}
+
+		return nil
+	})
+	if err != nil {
+		return "", err
+	}
+
+	return paymentStatus, nil
+}
+
+// sumRefundedAmount menjumlahkan refund payment dengan status yang diberikan memakai db atau transaksi yang diberikan.
+func sumRefundedAmount(db *gorm.DB, paymentID uint, statuses []string) (float64, error) {
+	var total float64
+
+	if err := db.Model(&model.Refund{}).
+		Select("COALESCE(SUM(amount), 0)").
+		Where("payment_id = ? AND status IN ?", paymentID, statuses).
+		Scan(&total).Error; err != nil {
+		return 0, err
+	}
+
+	return total, nil
+}
+
+func NewRefundRepository(db *gorm.DB) RefundRepositoryInterface {
+	return &refundRepository{db: db}
+}
@@ -0,0 +1,142 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"payment-service/internal/core/domain/entity"
+	"payment-service/internal/core/domain/model"
+	"time"
+
+	"github.com/labstack/gommon/log"
+	"gorm.io/gorm"
+)
+
+type SettlementRepositoryInterface interface {
+	GetDailySummary(ctx context.Context, startDate, endDate time.Time) ([]entity.DailyPaymentSummaryEntity, error)
+	GetByGatewayReference(ctx context.Context, reference string) (*entity.PaymentEntity, error)
+	GetPaidPayments(ctx context.Context, paymentMethod string, startDate, endDate time.Time) ([]entity.PaymentEntity, error)
+	UpdateGatewayFee(ctx context.Context, paymentID uint, fee float64) error
+}
+
+type settlementRepository struct {
+	db *gorm.DB
+}
+
+type dailyPaymentSummary struct {
+	Date             time.Time
+	PaymentMethod    string
+	PaymentStatus    string
+	TransactionCount int64
+	GrossAmount      float64
+	FeeAmount        float64
+	RefundedAmount   float64
+}
+
+// GetDailySummary implements SettlementRepositoryInterface.
+func (s *settlementRepository) GetDailySummary(ctx context.Context, startDate time.Time, endDate time.Time) ([]entity.DailyPaymentSummaryEntity, error) {
+	rows := []dailyPaymentSummary{}
+
+	refunded := s.db.Model(&model.Refund{}).
+		Select("payment_id, SUM(amount) AS amount").
+		Where("status = ?", "Success").
+		Group("payment_id")
+
+	if err := s.db.Table("payments AS p").
+		Select(`DATE(p.created_at) AS date, p.payment_method, p.payment_status,
+			COUNT(*) AS transaction_count,
+			COALESCE(SUM(p.gross_amount), 0) AS gross_amount,
+			COALESCE(SUM(p.gateway_fee), 0) AS fee_amount,
+			COALESCE(SUM(r.amount), 0) AS refunded_amount`).
+		Joins("LEFT JOIN (?) AS r ON r.payment_id = p.id", refunded).
+		Where("p.deleted_at IS NULL AND p.created_at >= ? AND p.created_at < ?", startDate, endDate).
+		Group("DATE(p.created_at), p.payment_method, p.payment_status").
+		Order("date ASC, p.payment_method ASC, p.payment_status ASC").
+		Scan(&rows).Error; err != nil {
+		log.Errorf("[SettlementRepository-1] GetDailySummary: %v", err)
+		return nil, err
+	}
+
+	entities := []entity.DailyPaymentSummaryEntity{}
+	for _, val := range rows {
+		entities = append(entities, entity.DailyPaymentSummaryEntity{
+			Date:             val.Date.Format("2006-01-02"),
+			PaymentMethod:    val.PaymentMethod,
+			PaymentStatus:    val.PaymentStatus,
+			TransactionCount: val.TransactionCount,
+			GrossAmount:      val.GrossAmount,
+			FeeAmount:        val.FeeAmount,
+			RefundedAmount:   val.RefundedAmount,
+		})
+	}
+
+	return entities, nil
+}
+
+// GetByGatewayReference implements SettlementRepositoryInterface.
+// Payment lama belum punya gateway_order_id sehingga dicocokkan dengan order code.
+func (s *settlementRepository) GetByGatewayReference(ctx context.Context, reference string) (*entity.PaymentEntity, error) {
+	modelPayment := model.Payment{}
+
+	if err := s.db.Where("gateway_order_id = ? OR (gateway_order_id = '' AND order_code = ?)", reference, reference).
+		Order("id DESC").
+		First(&modelPayment).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			log.Infof("[SettlementRepository-1] GetByGatewayReference: No payment found for %s", reference)
+			return nil, errors.New("404")
+		}
+		log.Errorf("[SettlementRepository-2] GetByGatewayReference: %v", err)
+		return nil, err
+	}
+
+	return &entity.PaymentEntity{
+		ID:             modelPayment.ID,
+		OrderID:        modelPayment.OrderID,
+		OrderCode:      modelPayment.OrderCode,
+		GatewayOrderID: modelPayment.GatewayOrderID,
+		PaymentMethod:  modelPayment.PaymentMethod,
+		PaymentStatus:  modelPayment.PaymentStatus,
+		GrossAmount:    modelPayment.GrossAmount,
+	}, nil
+}
+
+// GetPaidPayments implements SettlementRepositoryInterface.
+func (s *settlementRepository) GetPaidPayments(ctx context.Context, paymentMethod string, startDate time.Time, endDate time.Time) ([]entity.PaymentEntity, error) {
+	modelPayments := []model.Payment{}
+
+	if err := s.db.Where("payment_method = ? AND payment_status IN ?", paymentMethod, []string{"Success", "Partially Refunded", "Refunded"}).
+		Where("created_at >= ? AND created_at < ?", startDate, endDate).
+		Order("created_at ASC").
+		Find(&modelPayments).Error; err != nil {
+		log.Errorf("[SettlementRepository-1] GetPaidPayments: %v", err)
+		return nil, err
+	}
+
+	entities := []entity.PaymentEntity{}
+	for _, val := range modelPayments {
+		entities = append(entities, entity.PaymentEntity{
+			ID:             val.ID,
+			OrderID:        val.OrderID,
+			OrderCode:      val.OrderCode,
+			GatewayOrderID: val.GatewayOrderID,
+			PaymentMethod:  val.PaymentMethod,
+			PaymentStatus:  val.PaymentStatus,
+			GrossAmount:    val.GrossAmount,
+		})
+	}
+
+	return entities, nil
+}
+
+// UpdateGatewayFee implements SettlementRepositoryInterface.
+func (s *settlementRepository) UpdateGatewayFee(ctx context.Context, paymentID uint, fee float64) error {
+	if err := s.db.Model(&model.Payment{}).Where("id = ?", paymentID).Update("gateway_fee", fee).Error; err != nil {
+		log.Errorf("[SettlementRepository-1] UpdateGatewayFee: %v", err)
+		return err
+	}
+
+	return nil
+}
+
+func NewSettlementRepository(db *gorm.DB) SettlementRepositoryInterface {
+	return &settlementRepository{db: db}
+}
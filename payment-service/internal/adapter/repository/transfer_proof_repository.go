@@ -0,0 +1,138 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"payment-service/internal/core/domain/entity"
+	"payment-service/internal/core/domain/model"
+	"time"
+
+	"github.com/labstack/gommon/log"
+	"gorm.io/gorm"
+)
+
+type TransferProofRepositoryInterface interface {
+	CreateTransferProof(ctx context.Context, proof entity.TransferProofEntity) (uint, error)
+	GetByID(ctx context.Context, proofID uint) (*entity.TransferProofEntity, error)
+	GetByPaymentID(ctx context.Context, paymentID uint) ([]entity.TransferProofEntity, error)
+	UpdateReview(ctx context.Context, proofID uint, status, note string, reviewedBy uint) error
+}
+
+type transferProofRepository struct {
+	db *gorm.DB
+}
+
+// UpdateReview implements TransferProofRepositoryInterface.
+func (t *transferProofRepository) UpdateReview(ctx context.Context, proofID uint, status string, note string, reviewedBy uint) error {
+	updates := map[string]interface{}{
+		"status":      status,
+		"note":        note,
+		"reviewed_by": reviewedBy,
+		"reviewed_at": time.Now(),
+	}
+
+	result := t.db.Model(&model.TransferProof{}).Where("id = ? AND status = ?", proofID, "Submitted").Updates(updates)
+	if result.Error != nil {
+		log.Errorf("[TransferProofRepository-1] UpdateReview: %v", result.Error)
+		return result.Error
+	}
+
+	if result.RowsAffected == 0 {
+		err := errors.New("409")
+		log.Infof("[TransferProofRepository-2] UpdateReview: transfer proof already reviewed")
+		return err
+	}
+
+	return nil
+}
+
+// GetByPaymentID implements TransferProofRepositoryInterface.
+func (t *transferProofRepository) GetByPaymentID(ctx context.Context, paymentID uint) ([]entity.TransferProofEntity, error) {
+	modelProofs := []model.TransferProof{}
+
+	if err := t.db.Where("payment_id = ?", paymentID).Order("created_at DESC").Find(&modelProofs).Error; err != nil {
+		log.Errorf("[TransferProofRepository-1] GetByPaymentID: %v", err)
+		return nil, err
+	}
+
+	if len(modelProofs) == 0 {
+		err := errors.New("404")
+		log.Infof("[TransferProofRepository-2] GetByPaymentID: No transfer proof found")
+		return nil, err
+	}
+
+	entities := []entity.TransferProofEntity{}
+	for _, val := range modelProofs {
+		entities = append(entities, t.toEntity(val))
+	}
+
+	return entities, nil
+}
+
+// GetByID implements TransferProofRepositoryInterface.
+func (t *transferProofRepository) GetByID(ctx context.Context, proofID uint) (*entity.TransferProofEntity, error) {
+	modelProof := model.TransferProof{}
+
+	if err := t.db.Where("id = ?", proofID).First(&modelProof).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			err = errors.New("404")
+			log.Infof("[TransferProofRepository-1] GetByID: No transfer proof found")
+			return nil, err
+		}
+		log.Errorf("[TransferProofRepository-2] GetByID: %v", err)
+		return nil, err
+	}
+
+	result := t.toEntity(modelProof)
+	return &result, nil
+}
+
+// CreateTransferProof implements TransferProofRepositoryInterface.
+func (t *transferProofRepository) CreateTransferProof(ctx context.Context, proof entity.TransferProofEntity) (uint, error) {
+	modelProof := model.TransferProof{
+		PaymentID:   proof.PaymentID,
+		UserID:      proof.UserID,
+		BankName:    proof.BankName,
+		AccountName: proof.AccountName,
+		Amount:      proof.Amount,
+		ImageURL:    proof.ImageURL,
+		Status:      proof.Status,
+	}
+
+	if err := t.db.Create(&modelProof).Error; err != nil {
+		log.Errorf("[TransferProofRepository-1] CreateTransferProof: %v", err)
+		return 0, err
+	}
+
+	return modelProof.ID, nil
+}
+
+func (t *transferProofRepository) toEntity(val model.TransferProof) entity.TransferProofEntity {
+	result := entity.TransferProofEntity{
+		ID:          val.ID,
+		PaymentID:   val.PaymentID,
+		UserID:      val.UserID,
+		BankName:    val.BankName,
+		AccountName: val.AccountName,
+		Amount:      val.Amount,
+		ImageURL:    val.ImageURL,
+		Status:      val.Status,
+		CreatedAt:   val.CreatedAt.Format("2006-01-02 15:04:05"),
+	}
+
+	if val.Note != nil {
+		result.Note = *val.Note
+	}
+	if val.ReviewedBy != nil {
+		result.ReviewedBy = *val.ReviewedBy
+	}
+	if val.ReviewedAt != nil {
+		result.ReviewedAt = val.ReviewedAt.Format("2006-01-02 15:04:05")
+	}
+
+	return result
+}
+
+func NewTransferProofRepository(db *gorm.DB) TransferProofRepositoryInterface {
+	return &transferProofRepository{db: db}
+}
@@ -0,0 +1,243 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"math"
+	"payment-service/internal/core/domain/entity"
+	"payment-service/internal/core/domain/model"
+	"time"
+
+	"github.com/labstack/gommon/log"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type WebhookInboxRepositoryInterface interface {
+	CreateWebhook(ctx context.Context, provider, payload string) (uint, error)
+	GetAll(ctx context.Context, query entity.WebhookInboxQueryEntity) ([]entity.WebhookInboxEntity, int64, int64, error)
+	GetByID(ctx context.Context, webhookID uint) (*entity.WebhookInboxEntity, error)
+	Claim(ctx context.Context, webhookID uint) (*entity.WebhookInboxEntity, error)
+	ClaimDue(ctx context.Context, now time.Time, staleBefore time.Time, limit int) ([]entity.WebhookInboxEntity, error)
+	MarkProcessed(ctx context.Context, webhookID uint) error
+	MarkFailed(ctx context.Context, webhookID uint, status, lastError string, nextAttemptAt time.Time) error
+	Requeue(ctx context.Context, webhookID uint) error
+}
+
+type webhookInboxRepository struct {
+	db *gorm.DB
+}
+
+// CreateWebhook implements WebhookInboxRepositoryInterface.
+func (w *webhookInboxRepository) CreateWebhook(ctx context.Context, provider string, payload string) (uint, error) {
+	modelWebhook := model.WebhookInbox{
+		Provider:      provider,
+		Payload:       payload,
+		Status:        "Pending",
+		NextAttemptAt: time.Now(),
+	}
+
+	if err := w.db.Create(&modelWebhook).Error; err != nil {
+		log.Errorf("[WebhookInboxRepository-1] CreateWebhook: %v", err)
+		return 0, err
+	}
+
+	return modelWebhook.ID, nil
+}
+
+// GetAll implements WebhookInboxRepositoryInterface.
+func (w *webhookInboxRepository) GetAll(ctx context.Context, query entity.WebhookInboxQueryEntity) ([]entity.WebhookInboxEntity, int64, int64, error) {
+	modelWebhooks := []model.WebhookInbox{}
+	var countData int64
+	offset := (query.Page - 1) * query.Limit
+
+	sqlMain := w.db.Model(&model.WebhookInbox{})
+	if query.Status != "" {
+		sqlMain = sqlMain.Where("status = ?", query.Status)
+	}
+	if query.Provider != "" {
+		sqlMain = sqlMain.Where("provider = ?", query.Provider)
+	}
+
+	if err := sqlMain.Count(&countData).Error; err != nil {
+		log.Errorf("[WebhookInboxRepository-1] GetAll: %v", err)
+		return nil, 0, 0, err
+	}
+
+	totalPage := int(math.Ceil(float64(countData) / float64(query.Limit)))
+	if err := sqlMain.Order("created_at DESC, id DESC").Limit(int(query.Limit)).Offset(int(offset)).Find(&modelWebhooks).Error; err != nil {
+		log.Errorf("[WebhookInboxRepository-2] GetAll: %v", err)
+		return nil, 0, 0, err
+	}
+
+	if len(modelWebhooks) == 0 {
+		err := errors.New("404")
+		log.Infof("[WebhookInboxRepository-3] GetAll: No webhook found")
+		return nil, 0, 0, err
+	}
+
+	entities := []entity.WebhookInboxEntity{}
+	for _, val := range modelWebhooks {
+		entities = append(entities, toWebhookInboxEntity(val))
+	}
+
+	return entities, countData, int64(totalPage), nil
+}
+
+// GetByID implements WebhookInboxRepositoryInterface.
+func (w *webhookInboxRepository) GetByID(ctx context.Context, webhookID uint) (*entity.WebhookInboxEntity, error) {
+	modelWebhook := model.WebhookInbox{}
+
+	if err := w.db.Where("id = ?", webhookID).First(&modelWebhook).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			err = errors.New("404")
+			log.Infof("[WebhookInboxRepository-1] GetByID: No webhook found")
+			return nil, err
+		}
+		log.Errorf("[WebhookInboxRepository-2] GetByID: %v", err)
+		return nil, err
+	}
+
+	result := toWebhookInboxEntity(modelWebhook)
+	return &result, nil
+}
+
+// Claim implements WebhookInboxRepositoryInterface.
+// Webhook hanya bisa diproses oleh satu proses, klaim gagal jika status bukan Pending.
+func (w *webhookInboxRepository) Claim(ctx context.Context, webhookID uint) (*entity.WebhookInboxEntity, error) {
+	result := w.db.Model(&model.WebhookInbox{}).
+		Where("id = ? AND status = ?", webhookID, "Pending").
+		Updates(map[string]interface{}{
+			"status":   "Processing",
+			"attempts": gorm.Expr("attempts + 1"),
+		})
+	if result.Error != nil {
+		log.Errorf("[WebhookInboxRepository-1] Claim: %v", result.Error)
+		return nil, result.Error
+	}
+
+	if result.RowsAffected == 0 {
+		log.Infof("[WebhookInboxRepository-2] Claim: webhook %d already claimed", webhookID)
+		return nil, errors.New("409")
+	}
+
+	return w.GetByID(ctx, webhookID)
+}
+
+// ClaimDue implements WebhookInboxRepositoryInterface.
+// Webhook yang Processing sejak sebelum staleBefore dianggap tertinggal karena proses berhenti dan diklaim ulang.
+func (w *webhookInboxRepository) ClaimDue(ctx context.Context, now time.Time, staleBefore time.Time, limit int) ([]entity.WebhookInboxEntity, error) {
+	modelWebhooks := []model.WebhookInbox{}
+
+	err := w.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+			Where("(status = ? AND next_attempt_at <= ?) OR (status = ? AND updated_at < ?)", "Pending", now, "Processing", staleBefore).
+			Order("next_attempt_at ASC, id ASC").
+			Limit(limit).
+			Find(&modelWebhooks).Error; err != nil {
+			return err
+		}
+
+		if len(modelWebhooks) == 0 {
+			return nil
+		}
+
+		ids := []uint{}
+		for key := range modelWebhooks {
+			ids = append(ids, modelWebhooks[key].ID)
+			modelWebhooks[key].Status = "Processing"
+			modelWebhooks[key].Attempts++
+		}
+
+		return tx.Model(&model.WebhookInbox{}).
+			Where("id IN ?", ids).
+			Updates(map[string]interface{}{
+				"status":   "Processing",
+				"attempts": gorm.Expr("attempts + 1"),
+			}).Error
+	})
+	if err != nil {
+		log.Errorf("[WebhookInboxRepository-1] ClaimDue: %v", err)
+		return nil, err
+	}
+
+	entities := []entity.WebhookInboxEntity{}
+	for _, val := range modelWebhooks {
+		entities = append(entities, toWebhookInboxEntity(val))
+	}
+
+	return entities, nil
+}
+
+// MarkProcessed implements WebhookInboxRepositoryInterface.
+func (w *webhookInboxRepository) MarkProcessed(ctx context.Context, webhookID uint) error {
+	if err := w.db.Model(&model.WebhookInbox{}).Where("id = ?", webhookID).Updates(map[string]interface{}{
+		"status":       "Processed",
+		"last_error":   nil,
+		"processed_at": time.Now(),
+	}).Error; err != nil {
+		log.Errorf("[WebhookInboxRepository-1] MarkProcessed: %v", err)
+		return err
+	}
+
+	return nil
+}
+
+// MarkFailed implements WebhookInboxRepositoryInterface.
+func (w *webhookInboxRepository) MarkFailed(ctx context.Context, webhookID uint, status string, lastError string, nextAttemptAt time.Time) error {
+	if err := w.db.Model(&model.WebhookInbox{}).Where("id = ?", webhookID).Updates(map[string]interface{}{
+		"status":          status,
+		"last_error":      lastError,
+		"next_attempt_at": nextAttemptAt,
+	}).Error; err != nil {
+		log.Errorf("[WebhookInboxRepository-1] MarkFailed: %v", err)
+		return err
+	}
+
+	return nil
+}
+
+// Requeue implements WebhookInboxRepositoryInterface.
+func (w *webhookInboxRepository) Requeue(ctx context.Context, webhookID uint) error {
+	result := w.db.Model(&model.WebhookInbox{}).
+		Where("id = ? AND status <> ?", webhookID, "Processing").
+		Updates(map[string]interface{}{
+			"status":          "Pending",
+			"next_attempt_at": time.Now(),
+		})
+	if result.Error != nil {
+		log.Errorf("[WebhookInboxRepository-1] Requeue: %v", result.Error)
+		return result.Error
+	}
+
+	if result.RowsAffected == 0 {
+		log.Infof("[WebhookInboxRepository-2] Requeue: webhook %d is being processed", webhookID)
+		return errors.New("409")
+	}
+
+	return nil
+}
+
+func toWebhookInboxEntity(val model.WebhookInbox) entity.WebhookInboxEntity {
+	result := entity.WebhookInboxEntity{
+		ID:            val.ID,
+		Provider:      val.Provider,
+		Payload:       val.Payload,
+		Status:        val.Status,
+		Attempts:      val.Attempts,
+		NextAttemptAt: val.NextAttemptAt.Format("2006-01-02 15:04:05"),
+		CreatedAt:     val.CreatedAt.Format("2006-01-02 15:04:05"),
+	}
+	if val.LastError != nil {
+		result.LastError = *val.LastError
+	}
+	if val.ProcessedAt != nil {
+		result.ProcessedAt = val.ProcessedAt.Format("2006-01-02 15:04:05")
+	}
+
+	return result
+}
+
+func NewWebhookInboxRepository(db *gorm.DB) WebhookInboxRepositoryInterface {
+	return &webhookInboxRepository{db: db}
+}
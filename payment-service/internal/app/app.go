@@ -2,15 +2,19 @@ package app
 
 import (
 	"context"
+	"net"
 	"os"
 	"os/signal"
 	"payment-service/config"
+	"payment-service/internal/adapter/gateway"
 	"payment-service/internal/adapter/handlers"
 	httpclient "payment-service/internal/adapter/http_client"
 	"payment-service/internal/adapter/message"
 	"payment-service/internal/adapter/repository"
+	"payment-service/internal/adapter/storage"
 	"payment-service/internal/core/service"
 	"payment-service/utils/validator"
+	"strings"
 	"syscall"
 	"time"
 
@@ -29,16 +33,46 @@ func RunServer() {
 	}
 
 	paymentRepo := repository.NewPaymentRepository(db.DB)
+	refundRepo := repository.NewRefundRepository(db.DB)
+	transferProofRepo := repository.NewTransferProofRepository(db.DB)
+	settlementRepo := repository.NewSettlementRepository(db.DB)
+	webhookInboxRepo := repository.NewWebhookInboxRepository(db.DB)
+	codCollectionRepo := repository.NewCodCollectionRepository(db.DB)
+	fraudRepo := repository.NewFraudRepository(db.DB)
 
 	httpClient := httpclient.NewHttpClient(cfg)
 	midtrans := httpclient.NewMidtransClient(cfg)
+	var midtransSandbox httpclient.MidtransSandboxInterface
+	if cfg.Midtrans.Driver == "sandbox" {
+		midtransSandbox = httpclient.NewMidtransSandboxClient(cfg)
+		midtrans = midtransSandbox
+	}
+	gateways := gateway.NewRegistry(
+		gateway.NewMidtransProvider(cfg, midtrans),
+		gateway.NewVirtualAccountProvider(cfg, midtrans),
+		gateway.NewQrisProvider(cfg, midtrans),
+		gateway.NewCodProvider(),
+		gateway.NewBankTransferProvider(cfg),
+	)
 
 	publisherRabbitMQ := message.NewPublisherRabbitMQ(cfg)
+	storageHandler := storage.NewStorage(cfg)
+
+	fraudService := service.NewFraudService(fraudRepo, cfg, publisherRabbitMQ)
+
+	paymentService := service.NewPaymentService(paymentRepo, refundRepo, cfg, httpClient, gateways, publisherRabbitMQ, fraudService)
+
+	transferProofService := service.NewTransferProofService(transferProofRepo, paymentRepo, storageHandler, cfg, publisherRabbitMQ)
+
+	settlementService := service.NewSettlementService(settlementRepo)
+
+	webhookInboxService := service.NewWebhookInboxService(webhookInboxRepo, paymentService, cfg)
 
-	paymentService := service.NewPaymentService(paymentRepo, cfg, httpClient, midtrans, publisherRabbitMQ)
+	codCollectionService := service.NewCodCollectionService(codCollectionRepo, paymentRepo, cfg, publisherRabbitMQ)
 
 	e := echo.New()
 	e.Use(middleware.CORS())
+	e.IPExtractor = newIPExtractor(cfg.App.TrustedProxies)
 
 	customValidator := validator.NewValidator()
 	en.RegisterDefaultTranslations(customValidator.Validator, customValidator.Translator)
@@ -49,6 +83,17 @@ func RunServer() {
 	})
 
 	handlers.NewPaymentHandler(paymentService, e, cfg)
+	handlers.NewTransferProofHandler(transferProofService, e, cfg)
+	handlers.NewSettlementHandler(settlementService, e, cfg)
+	handlers.NewWebhookInboxHandler(webhookInboxService, e, cfg)
+	handlers.NewCodCollectionHandler(codCollectionService, e, cfg)
+	handlers.NewFraudHandler(fraudService, e, cfg)
+	if localStorage, ok := storageHandler.(storage.LocalStorageInterface); ok {
+		handlers.NewStorageHandler(localStorage, e)
+	}
+	if midtransSandbox != nil {
+		handlers.NewSandboxHandler(midtransSandbox, e, cfg)
+	}
 
 	go func() {
 		if cfg.App.AppPort == "" {
@@ -61,11 +106,15 @@ func RunServer() {
 		}
 	}()
 
+	retryCtx, stopRetry := context.WithCancel(context.Background())
+	go runWebhookRetry(retryCtx, webhookInboxService, cfg)
+
 	quit := make(chan os.Signal, 1)
 	signal.Notify(quit, os.Interrupt)
 	signal.Notify(quit, syscall.SIGTERM)
 
 	<-quit
+	stopRetry()
 
 	log.Print("[RunServer-3] Shutting down server of 5 second...")
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
@@ -73,3 +122,49 @@ func RunServer() {
 
 	e.Shutdown(ctx)
 }
+
+// runWebhookRetry memproses ulang webhook inbox yang gagal atau tertinggal secara berkala.
+func runWebhookRetry(ctx context.Context, webhookInboxService service.WebhookInboxServiceInterface, cfg *config.Config) {
+	interval := time.Duration(cfg.App.WebhookRetryInterval) * time.Second
+	if interval <= 0 {
+		interval = 30 * time.Second
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := webhookInboxService.ProcessDue(ctx); err != nil {
+				log.Errorf("[runWebhookRetry-1] %v", err)
+			}
+		}
+	}
+}
+
+// newIPExtractor menentukan IP client yang dipakai rule fraud. Tanpa TRUSTED_PROXIES IP diambil dari koneksi langsung,
+// header X-Forwarded-For hanya dibaca jika request datang dari proxy yang terdaftar.
+func newIPExtractor(trustedProxies string) echo.IPExtractor {
+	if trustedProxies == "" {
+		return echo.ExtractIPDirect()
+	}
+
+	options := []echo.TrustOption{
+		echo.TrustLoopback(false),
+		echo.TrustLinkLocal(false),
+		echo.TrustPrivateNet(false),
+	}
+	for _, val := range strings.Split(trustedProxies, ",") {
+		_, ipRange, err := net.ParseCIDR(strings.TrimSpace(val))
+		if err != nil {
+			log.Errorf("[newIPExtractor-1] invalid trusted proxy %s: %v", val, err)
+			continue
+		}
+		options = append(options, echo.TrustIPRange(ipRange))
+	}
+
+	return echo.ExtractIPFromXFFHeader(options...)
+}
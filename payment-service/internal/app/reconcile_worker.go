@@ -0,0 +1,81 @@
+package app
+
+import (
+	"context"
+	"os"
+	"os/signal"
+	"payment-service/config"
+	"payment-service/internal/adapter/gateway"
+	httpclient "payment-service/internal/adapter/http_client"
+	"payment-service/internal/adapter/message"
+	"payment-service/internal/adapter/repository"
+	"payment-service/internal/core/service"
+	"syscall"
+	"time"
+
+	"github.com/labstack/gommon/log"
+)
+
+func RunReconcileWorker() {
+	cfg := config.NewConfig()
+	db, err := cfg.ConnectionPostgres()
+	if err != nil {
+		log.Fatalf("[RunReconcileWorker-1] %v", err)
+		return
+	}
+
+	paymentRepo := repository.NewPaymentRepository(db.DB)
+	refundRepo := repository.NewRefundRepository(db.DB)
+	fraudRepo := repository.NewFraudRepository(db.DB)
+
+	httpClient := httpclient.NewHttpClient(cfg)
+	midtrans := httpclient.NewMidtransClient(cfg)
+	if cfg.Midtrans.Driver == "sandbox" {
+		// Transaksi sandbox hanya ada di memori proses server, status dari worker ini selalu tidak ditemukan
+		log.Warnf("[RunReconcileWorker-2] MIDTRANS_DRIVER=sandbox, sandbox transactions are not visible to this worker")
+		midtrans = httpclient.NewMidtransSandboxClient(cfg)
+	}
+	gateways := gateway.NewRegistry(
+		gateway.NewMidtransProvider(cfg, midtrans),
+		gateway.NewVirtualAccountProvider(cfg, midtrans),
+		gateway.NewQrisProvider(cfg, midtrans),
+		gateway.NewCodProvider(),
+		gateway.NewBankTransferProvider(cfg),
+	)
+
+	publisherRabbitMQ := message.NewPublisherRabbitMQ(cfg)
+
+	fraudService := service.NewFraudService(fraudRepo, cfg, publisherRabbitMQ)
+
+	paymentService := service.NewPaymentService(paymentRepo, refundRepo, cfg, httpClient, gateways, publisherRabbitMQ, fraudService)
+
+	interval := time.Duration(cfg.App.ReconcileInterval) * time.Second
+	if interval <= 0 {
+		interval = 5 * time.Minute
+	}
+
+	lookback := time.Duration(cfg.App.ReconcileLookback) * time.Minute
+	if lookback <= 0 {
+		lookback = 60 * time.Minute
+	}
+
+	quit := make(chan os.Signal, 1)
+	signal.Notify(quit, os.Interrupt)
+	signal.Notify(quit, syscall.SIGTERM)
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if err := paymentService.ReconcilePayments(context.Background(), time.Now().Add(-lookback)); err != nil {
+			log.Errorf("[RunReconcileWorker-3] %v", err)
+		}
+
+		select {
+		case <-quit:
+			log.Print("[RunReconcileWorker-4] Shutting down reconcile worker...")
+			return
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,24 @@
+package entity
+
+type CodCollectionEntity struct {
+	ID              uint
+	PaymentID       uint
+	CourierID       uint
+	ExpectedAmount  float64
+	CollectedAmount float64
+	Note            string
+	ConfirmedBy     uint
+	CollectedAt     string
+}
+
+// CodReconciliationEntity adalah rekap uang tunai COD per kurir per hari.
+// Uncollected berisi payment COD yang masih AwaitingCollection pada periode tersebut.
+type CodReconciliationEntity struct {
+	Date              string
+	CourierID         uint
+	CollectionCount   int64
+	ExpectedAmount    float64
+	CollectedAmount   float64
+	UncollectedCount  int64
+	UncollectedAmount float64
+}
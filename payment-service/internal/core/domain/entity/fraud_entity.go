@@ -0,0 +1,36 @@
+package entity
+
+type FraudCheckEntity struct {
+	ID               uint
+	OrderID          uint
+	UserID           uint
+	ClientIP         string
+	Phone            string
+	GrossAmount      float64
+	AccountCreatedAt string
+	Decision         string
+	Status           string
+	ReviewNote       string
+	ReviewedBy       uint
+	ReviewedAt       string
+	CreatedAt        string
+	RuleHits         []FraudRuleHitEntity
+
+	// Jumlah order lain dalam satu jam terakhir dari order-service, tidak disimpan
+	RecentOrdersByUser  int64
+	RecentOrdersByIP    int64
+	RecentOrdersByPhone int64
+}
+
+type FraudRuleHitEntity struct {
+	Rule   string
+	Action string
+	Detail string
+}
+
+type FraudCheckQueryEntity struct {
+	Page     int64
+	Limit    int64
+	Status   string
+	Decision string
+}
@@ -0,0 +1,35 @@
+package entity
+
+type GatewayNotificationEntity struct {
+	Provider          string
+	OrderID           string
+	TransactionID     string
+	TransactionStatus string
+	FraudStatus       string
+	PaymentType       string
+	PaymentStatus     string
+	StatusCode        string
+	GrossAmount       string
+	SignatureKey      string
+	RawPayload        string
+}
+
+type GatewayTransactionEntity struct {
+	PaymentStatus    string
+	PaymentGatewayID string
+	PaymentURL       string
+	Instructions     string
+	PaymentChannel   string
+	PaymentCode      string
+	ExpiresAt        string
+}
+
+// GatewayChargeEntity adalah request charge core API untuk metode tanpa halaman redirect (VA, QRIS).
+type GatewayChargeEntity struct {
+	OrderID       string
+	PaymentType   string
+	Bank          string
+	GrossAmount   int64
+	CustomerName  string
+	CustomerEmail string
+}
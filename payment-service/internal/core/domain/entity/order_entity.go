@@ -12,6 +12,15 @@ type GetOrderIDByCodeResponse struct {
 	} `json:"data"`
 }
 
+type OrderVelocityHttpClientResponse struct {
+	Message string `json:"message"`
+	Data    struct {
+		ByUser  int64 `json:"by_user"`
+		ByIP    int64 `json:"by_ip"`
+		ByPhone int64 `json:"by_phone"`
+	} `json:"data"`
+}
+
 type OrderDetailHttpResponse struct {
 	ID            int64         `json:"id"`
 	OrderCode     string        `json:"order_code"`
@@ -22,6 +31,8 @@ type OrderDetailHttpResponse struct {
 	ShippingFee   int64         `json:"shipping_fee"`
 	Remarks       string        `json:"remarks"`
 	ShippingType  string        `json:"shipping_type"`
+	TaxAmount     int64         `json:"tax_amount"`
+	TaxInclusive  bool          `json:"tax_inclusive"`
 	TotalAmount   int64         `json:"total_amount"`
 	Customer      CustomerOrder `json:"customer"`
 	OrderDetail   []OrderDetail `json:"order_detail"`
@@ -36,8 +47,11 @@ type CustomerOrder struct {
 }
 
 type OrderDetail struct {
-	ProductName  string `json:"product_name"`
-	ProductImage string `json:"product_image"`
-	ProductPrice int64  `json:"product_price"`
-	Quantity     int64  `json:"quantity"`
+	ProductName  string  `json:"product_name"`
+	ProductImage string  `json:"product_image"`
+	ProductPrice int64   `json:"product_price"`
+	Quantity     int64   `json:"quantity"`
+	Subtotal     int64   `json:"subtotal"`
+	TaxRate      float64 `json:"tax_rate"`
+	TaxAmount    int64   `json:"tax_amount"`
 }
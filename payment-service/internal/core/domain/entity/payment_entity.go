@@ -3,15 +3,23 @@ package entity
 type PaymentEntity struct {
 	ID                uint
 	OrderID           uint
+	GatewayOrderID    string
+	AttemptNumber     int
 	UserID            uint
 	PaymentMethod     string
 	PaymentStatus     string
 	PaymentGatewayID  string
 	GrossAmount       float64
 	PaymentURL        string
+	Instructions      string
+	PaymentChannel    string
+	PaymentCode       string
+	ExpiresAt         string
+	OrderSnapshot     string
 	PaymentLogs       []PaymentLogEntity
 	PaymentAt         string
 	Remarks           string
+	ClientIP          string
 	OrderCode         string
 	OrderShippingType string
 	CustomerName      string
@@ -20,6 +28,10 @@ type PaymentEntity struct {
 	OrderAt           string
 	OrderRemarks      string
 	OrderStatus       string
+	OrderTaxAmount    int64
+	OrderTaxInclusive bool
+	OrderItems        []OrderDetail
+	Attempts          []PaymentEntity
 }
 
 type PaymentQueryStringRequest struct {
@@ -4,4 +4,8 @@ type PaymentLogEntity struct {
 	ID        uint
 	PaymentID uint
 	Status    string
+	Source    string
+	EventKey  string
+	Payload   string
+	CreatedAt string
 }
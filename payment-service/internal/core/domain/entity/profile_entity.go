@@ -6,13 +6,14 @@ type UserHttpClientResponse struct {
 }
 
 type ProfileHttpResponse struct {
-	RoleName string `json:"role"`
-	ID       int64  `json:"id"`
-	Name     string `json:"name"`
-	Email    string `json:"email"`
-	Phone    string `json:"phone"`
-	Lat      string `json:"lat"`
-	Lng      string `json:"lng"`
-	Address  string `json:"address"`
-	Photo    string `json:"photo"`
+	RoleName  string `json:"role"`
+	ID        int64  `json:"id"`
+	Name      string `json:"name"`
+	Email     string `json:"email"`
+	Phone     string `json:"phone"`
+	Lat       string `json:"lat"`
+	Lng       string `json:"lng"`
+	Address   string `json:"address"`
+	Photo     string `json:"photo"`
+	CreatedAt string `json:"created_at"`
 }
@@ -0,0 +1,14 @@
+package entity
+
+type RefundEntity struct {
+	ID              uint
+	PaymentID       uint
+	RefundKey       string
+	Amount          float64
+	Reason          string
+	RefundMethod    string
+	Status          string
+	GatewayResponse string
+	CreatedBy       uint
+	CreatedAt       string
+}
@@ -0,0 +1,34 @@
+package entity
+
+type DailyPaymentSummaryEntity struct {
+	Date             string
+	PaymentMethod    string
+	PaymentStatus    string
+	TransactionCount int64
+	GrossAmount      float64
+	FeeAmount        float64
+	RefundedAmount   float64
+}
+
+type SettlementRowEntity struct {
+	Line              int
+	OrderID           string
+	TransactionID     string
+	TransactionStatus string
+	GrossAmount       float64
+	FeeAmount         float64
+}
+
+type SettlementFlagEntity struct {
+	Line      int
+	OrderID   string
+	PaymentID uint
+	Flag      string
+	Detail    string
+}
+
+type SettlementImportResultEntity struct {
+	TotalRows int
+	Matched   int
+	Flags     []SettlementFlagEntity
+}
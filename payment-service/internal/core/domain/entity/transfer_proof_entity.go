@@ -0,0 +1,16 @@
+package entity
+
+type TransferProofEntity struct {
+	ID          uint
+	PaymentID   uint
+	UserID      uint
+	BankName    string
+	AccountName string
+	Amount      float64
+	ImageURL    string
+	Status      string
+	Note        string
+	ReviewedBy  uint
+	ReviewedAt  string
+	CreatedAt   string
+}
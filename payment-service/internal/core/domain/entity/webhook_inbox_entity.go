@@ -0,0 +1,20 @@
+package entity
+
+type WebhookInboxEntity struct {
+	ID            uint
+	Provider      string
+	Payload       string
+	Status        string
+	Attempts      int
+	LastError     string
+	NextAttemptAt string
+	ProcessedAt   string
+	CreatedAt     string
+}
+
+type WebhookInboxQueryEntity struct {
+	Page     int64
+	Limit    int64
+	Status   string
+	Provider string
+}
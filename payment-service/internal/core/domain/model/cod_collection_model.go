@@ -0,0 +1,16 @@
+package model
+
+import "time"
+
+type CodCollection struct {
+	ID              uint      `gorm:"primaryKey" json:"id"`
+	PaymentID       uint      `gorm:"not null;uniqueIndex" json:"payment_id"`
+	CourierID       uint      `gorm:"not null;index" json:"courier_id"`
+	ExpectedAmount  float64   `gorm:"type:decimal(10,2);not null" json:"expected_amount"`
+	CollectedAmount float64   `gorm:"type:decimal(10,2);not null" json:"collected_amount"`
+	Note            *string   `gorm:"type:text;null" json:"note,omitempty"`
+	ConfirmedBy     uint      `gorm:"not null" json:"confirmed_by"`
+	CollectedAt     time.Time `gorm:"not null;index" json:"collected_at"`
+	CreatedAt       time.Time `json:"created_at"`
+	UpdatedAt       time.Time `json:"updated_at"`
+}
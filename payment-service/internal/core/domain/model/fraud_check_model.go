@@ -0,0 +1,29 @@
+package model
+
+import "time"
+
+type FraudCheck struct {
+	ID          uint           `gorm:"primaryKey" json:"id"`
+	OrderID     uint           `gorm:"not null;index" json:"order_id"`
+	UserID      uint           `gorm:"not null;index" json:"user_id"`
+	ClientIP    string         `gorm:"type:varchar(64);index" json:"client_ip"`
+	Phone       string         `gorm:"type:varchar(30);index" json:"phone"`
+	GrossAmount float64        `gorm:"type:decimal(10,2);not null" json:"gross_amount"`
+	Decision    string         `gorm:"type:varchar(20);not null" json:"decision"`
+	Status      string         `gorm:"type:varchar(50);not null;index" json:"status"`
+	ReviewNote  *string        `gorm:"type:text;null" json:"review_note,omitempty"`
+	ReviewedBy  *uint          `gorm:"null" json:"reviewed_by,omitempty"`
+	ReviewedAt  *time.Time     `gorm:"null" json:"reviewed_at,omitempty"`
+	CreatedAt   time.Time      `gorm:"index" json:"created_at"`
+	UpdatedAt   time.Time      `json:"updated_at"`
+	RuleHits    []FraudRuleHit `gorm:"foreignKey:FraudCheckID;constraint:OnDelete:CASCADE"`
+}
+
+type FraudRuleHit struct {
+	ID           uint      `gorm:"primaryKey" json:"id"`
+	FraudCheckID uint      `gorm:"not null;index" json:"fraud_check_id"`
+	Rule         string    `gorm:"type:varchar(50);not null" json:"rule"`
+	Action       string    `gorm:"type:varchar(20);not null" json:"action"`
+	Detail       string    `gorm:"type:text" json:"detail"`
+	CreatedAt    time.Time `json:"created_at"`
+}
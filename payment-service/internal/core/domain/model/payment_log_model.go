@@ -3,9 +3,13 @@ package model
 import "time"
 
 type PaymentLog struct {
-	ID        uint      `gorm:"primaryKey" json:"id"`
-	PaymentID uint      `gorm:"not null;index" json:"payment_id"`
-	Status    string    `gorm:"type:varchar(50);not null" json:"status"`
+	ID        uint   `gorm:"primaryKey" json:"id"`
+	PaymentID uint   `gorm:"not null;index" json:"payment_id"`
+	Status    string `gorm:"type:varchar(50);not null" json:"status"`
+	Source    string `gorm:"type:varchar(50);not null;default:'system'" json:"source"`
+	// EventKey unik untuk event yang sudah diproses, baris _rejected/_duplicate tidak menyimpan event key
+	EventKey  *string   `gorm:"type:varchar(150);null;uniqueIndex:idx_payment_logs_event_key" json:"event_key,omitempty"`
+	Payload   *string   `gorm:"type:text;null" json:"payload,omitempty"`
 	CreatedAt time.Time `json:"created_at"`
 	UpdatedAt time.Time `json:"updated_at"`
 }
@@ -3,16 +3,27 @@ package model
 import "time"
 
 type Payment struct {
-	ID               uint         `gorm:"primaryKey" json:"id"`
-	OrderID          uint         `gorm:"not null" json:"order_id"`
-	UserID           uint         `gorm:"not null" json:"user_id"`
-	PaymentMethod    string       `gorm:"type:varchar(50);not null" json:"payment_method"`
-	PaymentStatus    string       `gorm:"type:varchar(50);not null" json:"payment_status"`
-	PaymentGatewayID *string      `gorm:"type:varchar(50);null" json:"payment_gateway_id,omitempty"`
-	GrossAmount      float64      `gorm:"type:decimal(10,2);not null" json:"gross_amount"`
-	PaymentURL       *string      `gorm:"type:text;null" json:"payment_url,omitempty"`
-	CreatedAt        time.Time    `json:"created_at"`
-	UpdatedAt        time.Time    `json:"updated_at"`
-	DeletedAt        *time.Time   `gorm:"index" json:"deleted_at,omitempty"`
-	PaymentLogs      []PaymentLog `gorm:"foreignKey:PaymentID;constraint:OnDelete:CASCADE"`
+	ID               uint            `gorm:"primaryKey" json:"id"`
+	OrderID          uint            `gorm:"not null;uniqueIndex:idx_payments_order_attempt" json:"order_id"`
+	OrderCode        string          `gorm:"type:varchar(100);index" json:"order_code"`
+	GatewayOrderID   string          `gorm:"type:varchar(120);index" json:"gateway_order_id"`
+	AttemptNumber    int             `gorm:"not null;default:1;uniqueIndex:idx_payments_order_attempt" json:"attempt_number"`
+	UserID           uint            `gorm:"not null" json:"user_id"`
+	PaymentMethod    string          `gorm:"type:varchar(50);not null" json:"payment_method"`
+	PaymentStatus    string          `gorm:"type:varchar(50);not null" json:"payment_status"`
+	PaymentGatewayID *string         `gorm:"type:varchar(50);null" json:"payment_gateway_id,omitempty"`
+	GrossAmount      float64         `gorm:"type:decimal(10,2);not null" json:"gross_amount"`
+	GatewayFee       float64         `gorm:"type:decimal(10,2);not null;default:0" json:"gateway_fee"`
+	PaymentURL       *string         `gorm:"type:text;null" json:"payment_url,omitempty"`
+	PaymentChannel   string          `gorm:"type:varchar(50)" json:"payment_channel"`
+	PaymentCode      *string         `gorm:"type:text;null" json:"payment_code,omitempty"`
+	ExpiresAt        *time.Time      `gorm:"null" json:"expires_at,omitempty"`
+	OrderSnapshot    *string         `gorm:"type:text;null" json:"order_snapshot,omitempty"`
+	CreatedAt        time.Time       `json:"created_at"`
+	UpdatedAt        time.Time       `json:"updated_at"`
+	DeletedAt        *time.Time      `gorm:"index" json:"deleted_at,omitempty"`
+	PaymentLogs      []PaymentLog    `gorm:"foreignKey:PaymentID;constraint:OnDelete:CASCADE"`
+	Refunds          []Refund        `gorm:"foreignKey:PaymentID;constraint:OnDelete:CASCADE"`
+	TransferProofs   []TransferProof `gorm:"foreignKey:PaymentID;constraint:OnDelete:CASCADE"`
+	CodCollection    *CodCollection  `gorm:"foreignKey:PaymentID;constraint:OnDelete:CASCADE"`
 }
@@ -0,0 +1,17 @@
+package model
+
+import "time"
+
+type Refund struct {
+	ID              uint      `gorm:"primaryKey" json:"id"`
+	PaymentID       uint      `gorm:"not null;index" json:"payment_id"`
+	RefundKey       string    `gorm:"type:varchar(100);not null;uniqueIndex" json:"refund_key"`
+	Amount          float64   `gorm:"type:decimal(10,2);not null" json:"amount"`
+	Reason          string    `gorm:"type:text;not null" json:"reason"`
+	RefundMethod    string    `gorm:"type:varchar(50);not null" json:"refund_method"`
+	Status          string    `gorm:"type:varchar(50);not null" json:"status"`
+	GatewayResponse *string   `gorm:"type:text;null" json:"gateway_response,omitempty"`
+	CreatedBy       uint      `gorm:"not null" json:"created_by"`
+	CreatedAt       time.Time `json:"created_at"`
+	UpdatedAt       time.Time `json:"updated_at"`
+}
@@ -0,0 +1,19 @@
+package model
+
+import "time"
+
+type TransferProof struct {
+	ID          uint       `gorm:"primaryKey" json:"id"`
+	PaymentID   uint       `gorm:"not null;index" json:"payment_id"`
+	UserID      uint       `gorm:"not null" json:"user_id"`
+	BankName    string     `gorm:"type:varchar(100);not null" json:"bank_name"`
+	AccountName string     `gorm:"type:varchar(150);not null" json:"account_name"`
+	Amount      float64    `gorm:"type:decimal(10,2);not null" json:"amount"`
+	ImageURL    string     `gorm:"type:text;not null" json:"image_url"`
+	Status      string     `gorm:"type:varchar(50);not null" json:"status"`
+	Note        *string    `gorm:"type:text;null" json:"note,omitempty"`
+	ReviewedBy  *uint      `gorm:"null" json:"reviewed_by,omitempty"`
+	ReviewedAt  *time.Time `gorm:"null" json:"reviewed_at,omitempty"`
+	CreatedAt   time.Time  `json:"created_at"`
+	UpdatedAt   time.Time  `json:"updated_at"`
+}
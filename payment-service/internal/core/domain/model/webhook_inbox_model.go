@@ -0,0 +1,16 @@
+package model
+
+import "time"
+
+type WebhookInbox struct {
+	ID            uint       `gorm:"primaryKey" json:"id"`
+	Provider      string     `gorm:"type:varchar(50);not null" json:"provider"`
+	Payload       string     `gorm:"type:text;not null" json:"payload"`
+	Status        string     `gorm:"type:varchar(30);not null;index" json:"status"`
+	Attempts      int        `gorm:"not null;default:0" json:"attempts"`
+	LastError     *string    `gorm:"type:text;null" json:"last_error,omitempty"`
+	NextAttemptAt time.Time  `gorm:"index" json:"next_attempt_at"`
+	ProcessedAt   *time.Time `gorm:"null" json:"processed_at,omitempty"`
+	CreatedAt     time.Time  `json:"created_at"`
+	UpdatedAt     time.Time  `json:"updated_at"`
+}
@@ -0,0 +1,130 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"payment-service/config"
+	"payment-service/internal/adapter/message"
+	"payment-service/internal/adapter/repository"
+	"payment-service/internal/core/domain/entity"
+	"time"
+
+	"github.com/labstack/gommon/log"
+)
+
+type CodCollectionServiceInterface interface {
+	ConfirmCollection(ctx context.Context, collection entity.CodCollectionEntity, accessToken string) (*entity.CodCollectionEntity, error)
+	GetReconciliation(ctx context.Context, startDate, endDate time.Time, courierID uint) ([]entity.CodReconciliationEntity, error)
+}
+
+type codCollectionService struct {
+	repo              repository.CodCollectionRepositoryInterface
+	paymentRepo       repository.PaymentRepositoryInterface
+	cfg               *config.Config
+	publisherRabbitMQ message.PublishRabbitMQInterface
+}
+
+// ConfirmCollection implements CodCollectionServiceInterface.
+// Kurir mengonfirmasi uang tunai yang diterimanya sendiri, admin mengonfirmasi atas nama kurir yang disebutkan.
+func (c *codCollectionService) ConfirmCollection(ctx context.Context, collection entity.CodCollectionEntity, accessToken string) (*entity.CodCollectionEntity, error) {
+	var token entity.JwtUserData
+	if err := json.Unmarshal([]byte(accessToken), &token); err != nil {
+		log.Errorf("[CodCollectionService-1] ConfirmCollection: %v", err)
+		return nil, err
+	}
+
+	switch token.RoleName {
+	case "Courier":
+		collection.CourierID = uint(token.UserID)
+	case "Super Admin":
+		if collection.CourierID == 0 {
+			log.Infof("[CodCollectionService-2] ConfirmCollection: courier id is required for admin confirmation")
+			return nil, errors.New("400")
+		}
+	default:
+		log.Infof("[CodCollectionService-3] ConfirmCollection: role %s is not allowed to confirm collection", token.RoleName)
+		return nil, errors.New("403")
+	}
+
+	payment, err := c.paymentRepo.GetDetail(ctx, collection.PaymentID)
+	if err != nil {
+		log.Errorf("[CodCollectionService-4] ConfirmCollection: %v", err)
+		return nil, err
+	}
+
+	if payment.PaymentMethod != "cod" {
+		log.Infof("[CodCollectionService-5] ConfirmCollection: payment %d uses %s", payment.ID, payment.PaymentMethod)
+		return nil, errors.New("Payment method is not cash on delivery")
+	}
+
+	if payment.PaymentStatus != "AwaitingCollection" {
+		log.Infof("[CodCollectionService-6] ConfirmCollection: payment %d has status %s", payment.ID, payment.PaymentStatus)
+		return nil, errors.New("409")
+	}
+
+	collection.ExpectedAmount = payment.GrossAmount
+	collection.ConfirmedBy = uint(token.UserID)
+	collection.ID, err = c.repo.CreateCollection(ctx, collection)
+	if err != nil {
+		log.Errorf("[CodCollectionService-7] ConfirmCollection: %v", err)
+		return nil, err
+	}
+	collection.CollectedAt = time.Now().Format("2006-01-02 15:04:05")
+
+	payload, _ := json.Marshal(map[string]interface{}{
+		"cod_collection_id": collection.ID,
+		"courier_id":        collection.CourierID,
+		"expected_amount":   collection.ExpectedAmount,
+		"collected_amount":  collection.CollectedAmount,
+		"note":              collection.Note,
+		"confirmed_by":      collection.ConfirmedBy,
+	})
+	if err := c.paymentRepo.LogPayment(ctx, entity.PaymentLogEntity{
+		PaymentID: payment.ID,
+		Status:    "Success",
+		Source:    "cod_collection",
+		EventKey:  fmt.Sprintf("COD-%d", collection.ID),
+		Payload:   string(payload),
+	}); err != nil {
+		log.Errorf("[CodCollectionService-8] ConfirmCollection: %v", err)
+	}
+
+	payment.PaymentStatus = "Success"
+	if err := c.publisherRabbitMQ.PublishPaymentSuccess(*payment); err != nil {
+		log.Errorf("[CodCollectionService-9] ConfirmCollection: %v", err)
+	}
+
+	message := fmt.Sprintf("Pembayaran tunai untuk order %s telah diterima kurir", payment.OrderCode)
+	if err := c.publisherRabbitMQ.PublishNotification(c.cfg.PublisherName.PushNotif, "PUSH", "Pembayaran COD", message, "", int64(payment.UserID)); err != nil {
+		log.Errorf("[CodCollectionService-10] ConfirmCollection: %v", err)
+	}
+
+	return &collection, nil
+}
+
+// GetReconciliation implements CodCollectionServiceInterface.
+func (c *codCollectionService) GetReconciliation(ctx context.Context, startDate time.Time, endDate time.Time, courierID uint) ([]entity.CodReconciliationEntity, error) {
+	if endDate.Before(startDate) {
+		log.Infof("[CodCollectionService-1] GetReconciliation: end date %s before start date %s", endDate, startDate)
+		return nil, errors.New("400")
+	}
+
+	results, err := c.repo.GetReconciliation(ctx, startDate, endDate.AddDate(0, 0, 1), courierID)
+	if err != nil {
+		log.Errorf("[CodCollectionService-2] GetReconciliation: %v", err)
+		return nil, err
+	}
+
+	return results, nil
+}
+
+func NewCodCollectionService(repo repository.CodCollectionRepositoryInterface, paymentRepo repository.PaymentRepositoryInterface, cfg *config.Config, publisherRabbitMQ message.PublishRabbitMQInterface) CodCollectionServiceInterface {
+	return &codCollectionService{
+		repo:              repo,
+		paymentRepo:       paymentRepo,
+		cfg:               cfg,
+		publisherRabbitMQ: publisherRabbitMQ,
+	}
+}
@@ -0,0 +1,240 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"payment-service/config"
+	"payment-service/internal/adapter/message"
+	"payment-service/internal/adapter/repository"
+	"payment-service/internal/core/domain/entity"
+	"time"
+
+	"github.com/labstack/gommon/log"
+)
+
+var fraudDecisionRank = map[string]int{
+	"allow":  0,
+	"review": 1,
+	"block":  2,
+}
+
+type FraudServiceInterface interface {
+	Evaluate(ctx context.Context, check entity.FraudCheckEntity) (*entity.FraudCheckEntity, error)
+	GetAll(ctx context.Context, query entity.FraudCheckQueryEntity) ([]entity.FraudCheckEntity, int64, int64, error)
+	GetByID(ctx context.Context, checkID uint) (*entity.FraudCheckEntity, error)
+	Review(ctx context.Context, checkID uint, approve bool, note string, accessToken string) (*entity.FraudCheckEntity, error)
+}
+
+type fraudService struct {
+	repo              repository.FraudRepositoryInterface
+	cfg               *config.Config
+	publisherRabbitMQ message.PublishRabbitMQInterface
+}
+
+// Evaluate implements FraudServiceInterface.
+// Semua rule dijalankan dan setiap rule yang terpenuhi dicatat, keputusan akhir adalah action paling berat.
+// Order yang sudah direview memakai hasil review, sehingga customer bisa membayar ulang setelah disetujui admin.
+func (f *fraudService) Evaluate(ctx context.Context, check entity.FraudCheckEntity) (*entity.FraudCheckEntity, error) {
+	if !f.cfg.Fraud.Enabled {
+		check.Decision = "allow"
+		return &check, nil
+	}
+
+	previous, err := f.repo.GetLatestByOrderID(ctx, check.OrderID)
+	if err != nil && err.Error() != "404" {
+		log.Errorf("[FraudService-1] Evaluate: %v", err)
+		return nil, err
+	}
+
+	if previous != nil {
+		switch previous.Status {
+		case "Approved":
+			previous.Decision = "allow"
+			return previous, nil
+		case "Rejected":
+			previous.Decision = "block"
+			return previous, nil
+		case "PendingReview":
+			return previous, nil
+		}
+	}
+
+	hits, err := f.evaluateRules(ctx, check)
+	if err != nil {
+		log.Errorf("[FraudService-2] Evaluate: %v", err)
+		return nil, err
+	}
+
+	check.RuleHits = hits
+	check.Decision = "allow"
+	for _, hit := range hits {
+		if fraudDecisionRank[hit.Action] > fraudDecisionRank[check.Decision] {
+			check.Decision = hit.Action
+		}
+	}
+
+	switch check.Decision {
+	case "allow":
+		check.Status = "Allowed"
+	case "review":
+		check.Status = "PendingReview"
+	case "block":
+		check.Status = "Blocked"
+	}
+
+	check.ID, err = f.repo.CreateCheck(ctx, check)
+	if err != nil {
+		log.Errorf("[FraudService-3] Evaluate: %v", err)
+		return nil, err
+	}
+
+	if check.Decision != "allow" {
+		log.Infof("[FraudService-4] Evaluate: order %d user %d decision %s with %d rule hits", check.OrderID, check.UserID, check.Decision, len(hits))
+	}
+
+	return &check, nil
+}
+
+func (f *fraudService) evaluateRules(ctx context.Context, check entity.FraudCheckEntity) ([]entity.FraudRuleHitEntity, error) {
+	rules := f.cfg.Fraud
+	hits := []entity.FraudRuleHitEntity{}
+	now := time.Now()
+
+	velocities := []struct {
+		rule   string
+		column string
+		value  string
+		count  int64
+		limit  int
+	}{
+		{rule: "velocity_user", column: "user_id", value: fmt.Sprintf("%d", check.UserID), count: check.RecentOrdersByUser, limit: rules.MaxOrdersPerUser},
+		{rule: "velocity_ip", column: "client_ip", value: check.ClientIP, count: check.RecentOrdersByIP, limit: rules.MaxOrdersPerIP},
+		{rule: "velocity_phone", column: "phone", value: check.Phone, count: check.RecentOrdersByPhone, limit: rules.MaxOrdersPerPhone},
+	}
+	for _, velocity := range velocities {
+		if velocity.limit <= 0 || velocity.value == "" {
+			continue
+		}
+
+		// Order yang sedang dicek ikut dihitung
+		if velocity.count+1 > int64(velocity.limit) {
+			hits = append(hits, entity.FraudRuleHitEntity{
+				Rule:   velocity.rule,
+				Action: fraudAction(rules.VelocityAction, "block"),
+				Detail: fmt.Sprintf("%d orders in the last hour from %s %s, limit %d", velocity.count+1, velocity.column, velocity.value, velocity.limit),
+			})
+		}
+	}
+
+	if rules.BlockAmount > 0 && check.GrossAmount >= rules.BlockAmount {
+		hits = append(hits, entity.FraudRuleHitEntity{
+			Rule:   "amount_threshold",
+			Action: "block",
+			Detail: fmt.Sprintf("amount %.2f reaches block threshold %.2f", check.GrossAmount, rules.BlockAmount),
+		})
+	} else if rules.ReviewAmount > 0 && check.GrossAmount >= rules.ReviewAmount {
+		hits = append(hits, entity.FraudRuleHitEntity{
+			Rule:   "amount_threshold",
+			Action: "review",
+			Detail: fmt.Sprintf("amount %.2f reaches review threshold %.2f", check.GrossAmount, rules.ReviewAmount),
+		})
+	}
+
+	if rules.MinAccountAgeHours > 0 && check.AccountCreatedAt != "" {
+		createdAt, err := time.ParseInLocation("2006-01-02 15:04:05", check.AccountCreatedAt, time.Local)
+		if err != nil {
+			log.Errorf("[FraudService-1] evaluateRules: invalid account created at %s: %v", check.AccountCreatedAt, err)
+		} else if age := now.Sub(createdAt); age < time.Duration(rules.MinAccountAgeHours)*time.Hour {
+			hits = append(hits, entity.FraudRuleHitEntity{
+				Rule:   "account_age",
+				Action: fraudAction(rules.AccountAgeAction, "review"),
+				Detail: fmt.Sprintf("account created %.1f hours ago, minimum %d hours", age.Hours(), rules.MinAccountAgeHours),
+			})
+		}
+	}
+
+	if rules.MaxFailedPayments > 0 {
+		days := rules.FailedPaymentDays
+		if days <= 0 {
+			days = 30
+		}
+
+		count, err := f.repo.CountFailedPayments(ctx, check.UserID, now.AddDate(0, 0, -days))
+		if err != nil {
+			return nil, err
+		}
+
+		if count >= int64(rules.MaxFailedPayments) {
+			hits = append(hits, entity.FraudRuleHitEntity{
+				Rule:   "failed_payments",
+				Action: fraudAction(rules.FailedPaymentAction, "review"),
+				Detail: fmt.Sprintf("%d failed or expired payments in the last %d days, limit %d", count, days, rules.MaxFailedPayments),
+			})
+		}
+	}
+
+	return hits, nil
+}
+
+// GetAll implements FraudServiceInterface.
+func (f *fraudService) GetAll(ctx context.Context, query entity.FraudCheckQueryEntity) ([]entity.FraudCheckEntity, int64, int64, error) {
+	return f.repo.GetAll(ctx, query)
+}
+
+// GetByID implements FraudServiceInterface.
+func (f *fraudService) GetByID(ctx context.Context, checkID uint) (*entity.FraudCheckEntity, error) {
+	return f.repo.GetByID(ctx, checkID)
+}
+
+// Review implements FraudServiceInterface.
+func (f *fraudService) Review(ctx context.Context, checkID uint, approve bool, note string, accessToken string) (*entity.FraudCheckEntity, error) {
+	var token entity.JwtUserData
+	if err := json.Unmarshal([]byte(accessToken), &token); err != nil {
+		log.Errorf("[FraudService-1] Review: %v", err)
+		return nil, err
+	}
+
+	status := "Rejected"
+	if approve {
+		status = "Approved"
+	}
+
+	if err := f.repo.UpdateReview(ctx, checkID, status, note, uint(token.UserID)); err != nil {
+		log.Errorf("[FraudService-2] Review: %v", err)
+		return nil, err
+	}
+
+	check, err := f.repo.GetByID(ctx, checkID)
+	if err != nil {
+		log.Errorf("[FraudService-3] Review: %v", err)
+		return nil, err
+	}
+
+	message := fmt.Sprintf("Pembayaran order #%d tidak dapat diproses", check.OrderID)
+	if approve {
+		message = fmt.Sprintf("Pembayaran order #%d telah disetujui, silakan lanjutkan pembayaran", check.OrderID)
+	}
+	if err := f.publisherRabbitMQ.PublishNotification(f.cfg.PublisherName.PushNotif, "PUSH", "Verifikasi Pembayaran", message, "", int64(check.UserID)); err != nil {
+		log.Errorf("[FraudService-4] Review: %v", err)
+	}
+
+	return check, nil
+}
+
+// fraudAction memakai action dari konfigurasi jika valid, selain itu memakai action bawaan rule.
+func fraudAction(action, defaultAction string) string {
+	if action == "review" || action == "block" {
+		return action
+	}
+
+	return defaultAction
+}
+
+func NewFraudService(repo repository.FraudRepositoryInterface, cfg *config.Config, publisherRabbitMQ message.PublishRabbitMQInterface) FraudServiceInterface {
+	return &fraudService{
+		repo:              repo,
+		cfg:               cfg,
+		publisherRabbitMQ: publisherRabbitMQ,
+	}
+}
@@ -6,29 +6,49 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"math"
 	"payment-service/config"
+	"payment-service/internal/adapter/gateway"
 	httpclient "payment-service/internal/adapter/http_client"
 	"payment-service/internal/adapter/message"
 	"payment-service/internal/adapter/repository"
 	"payment-service/internal/core/domain/entity"
 	"strconv"
+	"strings"
+	"time"
 
 	"github.com/labstack/gommon/log"
 )
 
+// paymentStatusTransitions memetakan status payment ke status gateway yang boleh menggantikannya.
+// Status final hanya menerima refund, transisi lain dari notifikasi yang terlambat atau diulang diabaikan.
+var paymentStatusTransitions = map[string][]string{
+	"Pending":            {"Success", "Failed", "Expired"},
+	"Success":            {"Partially Refunded", "Refunded"},
+	"Partially Refunded": {"Refunded"},
+}
+
 type PaymentServiceInterface interface {
 	ProcessPayment(ctx context.Context, payment entity.PaymentEntity, accessToken string) (*entity.PaymentEntity, error)
 	UpdateStatusByOrderCode(ctx context.Context, orderCode, status string) error
+	VerifyWebhook(ctx context.Context, providerName string, body []byte) error
+	ProcessWebhook(ctx context.Context, providerName string, body []byte) error
+	ReconcilePayments(ctx context.Context, updatedSince time.Time) error
 	GetAll(ctx context.Context, req entity.PaymentQueryStringRequest, accessToken string) ([]entity.PaymentEntity, int64, int64, error)
 	GetDetail(ctx context.Context, paymentID uint, accessToken string) (*entity.PaymentEntity, error)
+	CreateRefund(ctx context.Context, refund entity.RefundEntity, accessToken string) (*entity.RefundEntity, error)
+	GetRefunds(ctx context.Context, paymentID uint) ([]entity.RefundEntity, error)
+	GetTimeline(ctx context.Context, paymentID uint, accessToken string) ([]entity.PaymentLogEntity, error)
 }
 
 type paymentService struct {
 	repo                repository.PaymentRepositoryInterface
+	refundRepo          repository.RefundRepositoryInterface
 	httpClientToService httpclient.HttpClientToService
-	midtrans            httpclient.MidtransClientInterface
+	gateways            gateway.RegistryInterface
 	cfg                 *config.Config
 	publisherRabbitMQ   message.PublishRabbitMQInterface
+	fraudService        FraudServiceInterface
 }
 
 // GetDetail implements PaymentServiceInterface.
@@ -76,6 +96,15 @@ func (p *paymentService) GetDetail(ctx context.Context, paymentID uint, accessTo
 	result.OrderShippingType = orderDetail.ShippingType
 	result.OrderAt = orderDetail.OrderDatetime
 	result.OrderRemarks = orderDetail.Remarks
+	result.OrderTaxAmount = orderDetail.TaxAmount
+	result.OrderTaxInclusive = orderDetail.TaxInclusive
+	result.OrderItems = orderDetail.OrderDetail
+
+	result.Attempts, err = p.repo.GetAttemptsByOrderID(ctx, result.OrderID)
+	if err != nil {
+		log.Errorf("[PaymentService] GetDetail-5: %v", err)
+		return nil, err
+	}
 
 	return result, nil
 }
@@ -107,6 +136,442 @@ func (p *paymentService) GetAll(ctx context.Context, req entity.PaymentQueryStri
 	return results, count, total, nil
 }
 
+// GetTimeline implements PaymentServiceInterface.
+func (p *paymentService) GetTimeline(ctx context.Context, paymentID uint, accessToken string) ([]entity.PaymentLogEntity, error) {
+	var token entity.JwtUserData
+	if err := json.Unmarshal([]byte(accessToken), &token); err != nil {
+		log.Errorf("[PaymentService] GetTimeline-1: %v", err)
+		return nil, err
+	}
+
+	payment, err := p.repo.GetDetail(ctx, paymentID)
+	if err != nil {
+		log.Errorf("[PaymentService] GetTimeline-2: %v", err)
+		return nil, err
+	}
+
+	isAdmin := token.RoleName == "Super Admin"
+	if !isAdmin && int64(payment.UserID) != token.UserID {
+		log.Infof("[PaymentService] GetTimeline-3: payment %d does not belong to user %d", payment.ID, token.UserID)
+		return nil, errors.New("404")
+	}
+
+	results, err := p.repo.GetLogsByPaymentID(ctx, paymentID)
+	if err != nil {
+		log.Errorf("[PaymentService] GetTimeline-4: %v", err)
+		return nil, err
+	}
+
+	if isAdmin {
+		return results, nil
+	}
+
+	// Customer hanya melihat perubahan status, tanpa payload gateway dan notifikasi yang ditolak/duplikat
+	timeline := []entity.PaymentLogEntity{}
+	for _, val := range results {
+		if strings.HasSuffix(val.Source, "_duplicate") || strings.HasSuffix(val.Source, "_rejected") {
+			continue
+		}
+		val.EventKey = ""
+		val.Payload = ""
+		timeline = append(timeline, val)
+	}
+
+	return timeline, nil
+}
+
+// GetRefunds implements PaymentServiceInterface.
+func (p *paymentService) GetRefunds(ctx context.Context, paymentID uint) ([]entity.RefundEntity, error) {
+	if _, err := p.repo.GetDetail(ctx, paymentID); err != nil {
+		log.Errorf("[PaymentService] GetRefunds-1: %v", err)
+		return nil, err
+	}
+
+	results, err := p.refundRepo.GetByPaymentID(ctx, paymentID)
+	if err != nil {
+		log.Errorf("[PaymentService] GetRefunds-2: %v", err)
+		return nil, err
+	}
+
+	return results, nil
+}
+
+// CreateRefund implements PaymentServiceInterface.
+func (p *paymentService) CreateRefund(ctx context.Context, refund entity.RefundEntity, accessToken string) (*entity.RefundEntity, error) {
+	var token map[string]interface{}
+	if err := json.Unmarshal([]byte(accessToken), &token); err != nil {
+		log.Errorf("[PaymentService] CreateRefund-1: %v", err)
+		return nil, err
+	}
+
+	payment, err := p.repo.GetDetail(ctx, refund.PaymentID)
+	if err != nil {
+		log.Errorf("[PaymentService] CreateRefund-2: %v", err)
+		return nil, err
+	}
+
+	provider, err := p.gateways.Get(payment.PaymentMethod)
+	if err != nil {
+		log.Errorf("[PaymentService] CreateRefund-3: %v", err)
+		return nil, err
+	}
+
+	if payment.OrderCode == "" {
+		orderDetail, err := p.httpClientOrderService(int64(payment.OrderID), token["token"].(string))
+		if err != nil {
+			log.Errorf("[PaymentService] CreateRefund-4: %v", err)
+			return nil, err
+		}
+		payment.OrderCode = orderDetail.OrderCode
+	}
+
+	// Refund dicatat Pending lebih dulu agar sisa dana terkunci selama gateway diproses
+	refund.RefundKey = fmt.Sprintf("RF-%d-%d", payment.ID, time.Now().UnixNano())
+	refund.RefundMethod = "gateway"
+	reserved, err := p.refundRepo.ReserveRefund(ctx, refund)
+	if err != nil {
+		log.Errorf("[PaymentService] CreateRefund-5: %v", err)
+		return nil, err
+	}
+	refund = *reserved
+
+	// Provider tanpa API refund (COD, transfer bank, virtual account) dicatat sebagai refund manual oleh admin
+	refund.Status = "Success"
+	refund.GatewayResponse, err = provider.Refund(ctx, gatewayOrderID(*payment), refund.RefundKey, int64(math.Round(refund.Amount)), refund.Reason)
+	if errors.Is(err, gateway.ErrNotSupported) {
+		refund.RefundMethod = "manual"
+		err = nil
+	}
+	if err != nil {
+		log.Errorf("[PaymentService] CreateRefund-6: %v", err)
+		refund.Status = "Failed"
+	}
+
+	paymentStatus, errComplete := p.refundRepo.CompleteRefund(ctx, refund)
+	if errComplete != nil {
+		log.Errorf("[PaymentService] CreateRefund-7: %v", errComplete)
+		return nil, errComplete
+	}
+
+	if refund.Status == "Failed" {
+		if errLog := p.repo.LogPayment(ctx, entity.PaymentLogEntity{
+			PaymentID: payment.ID,
+			Status:    "Refund Failed",
+			Source:    "refund",
+			EventKey:  refund.RefundKey,
+			Payload:   refund.GatewayResponse,
+		}); errLog != nil {
+			log.Errorf("[PaymentService] CreateRefund-8: %v", errLog)
+		}
+		return nil, err
+	}
+
+	payment.PaymentStatus = paymentStatus
+
+	payload, _ := json.Marshal(map[string]interface{}{
+		"refund_key":       refund.RefundKey,
+		"amount":           refund.Amount,
+		"reason":           refund.Reason,
+		"refund_method":    refund.RefundMethod,
+		"gateway_response": refund.GatewayResponse,
+	})
+	if err := p.repo.LogPayment(ctx, entity.PaymentLogEntity{
+		PaymentID: payment.ID,
+		Status:    payment.PaymentStatus,
+		Source:    "refund",
+		EventKey:  refund.RefundKey,
+		Payload:   string(payload),
+	}); err != nil {
+		log.Errorf("[PaymentService] CreateRefund-9: %v", err)
+	}
+
+	if err := p.publisherRabbitMQ.PublishPaymentRefunded(*payment, refund); err != nil {
+		log.Errorf("[PaymentService] CreateRefund-10: %v", err)
+	}
+
+	userDetail, err := p.httpClientUserService(token["token"].(string), int64(payment.UserID), true)
+	if err != nil {
+		log.Errorf("[PaymentService] CreateRefund-11: %v", err)
+		return &refund, nil
+	}
+
+	message := fmt.Sprintf("Refund sebesar Rp %.0f untuk pembayaran order %s telah diproses. Alasan: %s", refund.Amount, payment.OrderCode, refund.Reason)
+	if err := p.publisherRabbitMQ.PublishNotification(p.cfg.PublisherName.EmailPaymentRefunded, "EMAIL", "Refund Pembayaran", message, userDetail.Email, int64(payment.UserID)); err != nil {
+		log.Errorf("[PaymentService] CreateRefund-12: %v", err)
+	}
+
+	if err := p.publisherRabbitMQ.PublishNotification(p.cfg.PublisherName.PushNotif, "PUSH", "Refund Pembayaran", message, "", int64(payment.UserID)); err != nil {
+		log.Errorf("[PaymentService] CreateRefund-13: %v", err)
+	}
+
+	return &refund, nil
+}
+
+// VerifyWebhook implements PaymentServiceInterface.
+// Hanya memeriksa payload dan signature notifikasi tanpa mengubah status payment.
+func (p *paymentService) VerifyWebhook(ctx context.Context, providerName string, body []byte) error {
+	if _, err := p.parseWebhook(ctx, providerName, body); err != nil {
+		log.Errorf("[PaymentService] VerifyWebhook-1: %v", err)
+		return err
+	}
+
+	return nil
+}
+
+// parseWebhook membaca notifikasi lewat provider gateway, error 404 untuk provider yang tidak menerima webhook,
+// 400 untuk payload rusak dan 401 untuk signature yang salah.
+func (p *paymentService) parseWebhook(ctx context.Context, providerName string, body []byte) (*entity.GatewayNotificationEntity, error) {
+	provider, err := p.gateways.Get(providerName)
+	if err != nil {
+		return nil, errors.New("404")
+	}
+
+	notification, err := provider.ParseWebhook(ctx, body)
+	if err != nil {
+		if errors.Is(err, gateway.ErrNotSupported) {
+			return nil, errors.New("404")
+		}
+		return nil, err
+	}
+
+	return notification, nil
+}
+
+// ProcessWebhook implements PaymentServiceInterface.
+func (p *paymentService) ProcessWebhook(ctx context.Context, providerName string, body []byte) error {
+	notification, err := p.parseWebhook(ctx, providerName, body)
+	if err != nil {
+		log.Errorf("[PaymentService] ProcessWebhook-1: %v", err)
+		return err
+	}
+
+	payment, err := p.repo.GetDetailByGatewayOrderID(ctx, notification.OrderID)
+	if err != nil && err.Error() != "404" {
+		log.Errorf("[PaymentService] ProcessWebhook-2: %v", err)
+		return err
+	}
+
+	// Payment lama dibuat sebelum ada gateway_order_id, gateway memakai order code sebagai order_id
+	if payment == nil {
+		orderID, err := p.httpClientPublicOrderIDByCodeService(notification.OrderID)
+		if err != nil {
+			log.Errorf("[PaymentService] ProcessWebhook-3: %v", err)
+			return errors.New("404")
+		}
+
+		payment, err = p.repo.GetDetailByOrderID(ctx, uint(orderID))
+		if err != nil {
+			log.Errorf("[PaymentService] ProcessWebhook-4: %v", err)
+			return err
+		}
+		if payment.OrderCode == "" {
+			payment.OrderCode = notification.OrderID
+		}
+	}
+
+	return p.applyGatewayStatus(ctx, *payment, *notification, "webhook")
+}
+
+// ReconcilePayments implements PaymentServiceInterface.
+// Status final dari percobaan yang baru berubah ikut dicek agar refund atau chargeback di gateway tercatat,
+// transisi yang tidak ada di paymentStatusTransitions tetap diabaikan.
+func (p *paymentService) ReconcilePayments(ctx context.Context, updatedSince time.Time) error {
+	payments, err := p.repo.GetForReconciliation(ctx, updatedSince)
+	if err != nil {
+		log.Errorf("[PaymentService] ReconcilePayments-1: %v", err)
+		return err
+	}
+
+	for _, payment := range payments {
+		provider, err := p.gateways.Get(payment.PaymentMethod)
+		if err != nil {
+			log.Errorf("[PaymentService] ReconcilePayments-2: order %s: %v", payment.OrderCode, err)
+			continue
+		}
+
+		status, err := provider.GetStatus(ctx, gatewayOrderID(payment))
+		if errors.Is(err, gateway.ErrNotSupported) {
+			continue
+		}
+		if err != nil {
+			log.Errorf("[PaymentService] ReconcilePayments-3: order %s: %v", payment.OrderCode, err)
+			continue
+		}
+
+		if err := p.applyGatewayStatus(ctx, payment, *status, "reconciliation"); err != nil {
+			log.Errorf("[PaymentService] ReconcilePayments-4: order %s: %v", payment.OrderCode, err)
+		}
+	}
+
+	return nil
+}
+
+// applyGatewayStatus mencatat status dari gateway ke PaymentLog lalu memperbarui status payment jika berubah.
+// Dipakai bersama oleh webhook dan worker rekonsiliasi sehingga mapping dan dedupe-nya sama.
+func (p *paymentService) applyGatewayStatus(ctx context.Context, payment entity.PaymentEntity, notification entity.GatewayNotificationEntity, source string) error {
+	newStatus := notification.PaymentStatus
+	eventKey := notification.TransactionID
+	if eventKey == "" {
+		eventKey = notification.OrderID
+	}
+	eventKey = fmt.Sprintf("%s:%s:%s", eventKey, notification.TransactionStatus, notification.StatusCode)
+
+	paymentLog := entity.PaymentLogEntity{
+		PaymentID: payment.ID,
+		Status:    newStatus,
+		Source:    source,
+		EventKey:  eventKey,
+		Payload:   notification.RawPayload,
+	}
+
+	grossAmount, err := strconv.ParseFloat(notification.GrossAmount, 64)
+	if err != nil || math.Abs(grossAmount-payment.GrossAmount) > 0.01 {
+		log.Errorf("[PaymentService] applyGatewayStatus-1: gross amount %s does not match payment %d", notification.GrossAmount, payment.ID)
+		paymentLog.Source = source + "_rejected"
+		paymentLog.EventKey = ""
+		if err := p.repo.LogPayment(ctx, paymentLog); err != nil {
+			log.Errorf("[PaymentService] applyGatewayStatus-2: %v", err)
+		}
+		return errors.New("422")
+	}
+
+	isLogged, err := p.repo.IsEventLogged(ctx, eventKey)
+	if err != nil {
+		log.Errorf("[PaymentService] applyGatewayStatus-3: %v", err)
+		return err
+	}
+
+	if isLogged {
+		p.logDuplicateEvent(ctx, paymentLog)
+		return nil
+	}
+
+	// Notifikasi tetap dicatat di PaymentLog, status hanya diubah jika transisinya ada di paymentStatusTransitions
+	targetStatus := ""
+	if newStatus != payment.PaymentStatus {
+		if isStatusTransitionAllowed(payment.PaymentStatus, newStatus) {
+			targetStatus = newStatus
+		} else {
+			log.Infof("[PaymentService] applyGatewayStatus-4: ignore transition %s to %s for payment %d", payment.PaymentStatus, newStatus, payment.ID)
+		}
+	}
+
+	// Log dan status ditulis dalam satu transaksi, unique index pada event_key menjadi penentu akhir
+	// saat webhook, retry inbox dan rekonsiliasi berjalan bersamaan
+	updated, err := p.repo.LogPaymentWithStatus(ctx, paymentLog, payment.PaymentStatus, targetStatus)
+	if err != nil {
+		if err.Error() == "409" {
+			p.logDuplicateEvent(ctx, paymentLog)
+			return nil
+		}
+		log.Errorf("[PaymentService] applyGatewayStatus-5: %v", err)
+		return err
+	}
+
+	if targetStatus == "" {
+		return nil
+	}
+
+	if !updated {
+		// Status payment berubah sejak dibaca atau percobaan lain untuk order yang sama sudah Success,
+		// status percobaan ini tidak diubah dan catatan dari gateway di PaymentLog menjadi dasar refund manual oleh admin
+		log.Errorf("[PaymentService] applyGatewayStatus-6: payment %d of order %d was not changed from %s", payment.ID, payment.OrderID, payment.PaymentStatus)
+		return nil
+	}
+
+	payment.PaymentStatus = newStatus
+	if err := p.publishPaymentOutcome(payment); err != nil {
+		log.Errorf("[PaymentService] applyGatewayStatus-7: %v", err)
+	}
+
+	return nil
+}
+
+// isStatusTransitionAllowed mengecek apakah status payment boleh berubah dari from ke to.
+func isStatusTransitionAllowed(from, to string) bool {
+	for _, status := range paymentStatusTransitions[from] {
+		if status == to {
+			return true
+		}
+	}
+	return false
+}
+
+// logDuplicateEvent mencatat notifikasi webhook yang sudah pernah diproses tanpa event key.
+// Rekonsiliasi berjalan berkala, status yang sama tidak perlu dicatat ulang.
+func (p *paymentService) logDuplicateEvent(ctx context.Context, paymentLog entity.PaymentLogEntity) {
+	if paymentLog.Source != "webhook" {
+		return
+	}
+
+	log.Infof("[PaymentService] logDuplicateEvent-1: duplicate notification %s", paymentLog.EventKey)
+	paymentLog.Source = paymentLog.Source + "_duplicate"
+	paymentLog.EventKey = ""
+	if err := p.repo.LogPayment(ctx, paymentLog); err != nil {
+		log.Errorf("[PaymentService] logDuplicateEvent-2: %v", err)
+	}
+}
+
+// cancelPendingAttempts membatalkan percobaan sebelumnya yang masih Pending setelah percobaan baru dibuat,
+// sehingga customer tetap bisa membayar ulang walau token gateway sebelumnya belum kedaluwarsa.
+// Percobaan yang gagal dibatalkan hanya dicatat, pembayaran gandanya ditolak UpdateStatus dan menjadi dasar refund.
+func (p *paymentService) cancelPendingAttempts(ctx context.Context, current entity.PaymentEntity) error {
+	attempts, err := p.repo.GetAttemptsByOrderID(ctx, current.OrderID)
+	if err != nil {
+		return err
+	}
+
+	for _, attempt := range attempts {
+		if attempt.PaymentStatus != "Pending" || attempt.AttemptNumber >= current.AttemptNumber {
+			continue
+		}
+
+		provider, err := p.gateways.Get(attempt.PaymentMethod)
+		if err != nil {
+			log.Errorf("[PaymentService] cancelPendingAttempts-1: payment %d: %v", attempt.ID, err)
+			continue
+		}
+
+		if err := provider.Cancel(ctx, gatewayOrderID(attempt)); err != nil && !errors.Is(err, gateway.ErrNotSupported) {
+			log.Errorf("[PaymentService] cancelPendingAttempts-2: payment %d: %v", attempt.ID, err)
+			continue
+		}
+
+		if _, err := p.repo.LogPaymentWithStatus(ctx, entity.PaymentLogEntity{
+			PaymentID: attempt.ID,
+			Status:    "Cancelled",
+			Source:    "attempt",
+		}, "Pending", "Cancelled"); err != nil {
+			log.Errorf("[PaymentService] cancelPendingAttempts-3: %v", err)
+		}
+	}
+
+	return nil
+}
+
+// gatewayOrderID mengembalikan order_id yang dikirim ke gateway, payment lama memakai order code.
+func gatewayOrderID(payment entity.PaymentEntity) string {
+	if payment.GatewayOrderID != "" {
+		return payment.GatewayOrderID
+	}
+
+	return payment.OrderCode
+}
+
+// publishPaymentOutcome mengirim event sesuai status akhir payment agar order-service bisa memindahkan status order.
+func (p *paymentService) publishPaymentOutcome(payment entity.PaymentEntity) error {
+	switch payment.PaymentStatus {
+	case "Success":
+		return p.publisherRabbitMQ.PublishPaymentSuccess(payment)
+	case "Failed":
+		return p.publisherRabbitMQ.PublishPaymentFailed(payment)
+	case "Expired":
+		return p.publisherRabbitMQ.PublishPaymentExpired(payment)
+	}
+
+	return nil
+}
+
 // UpdateStatusByOrderCode implements PaymentServiceInterface.
 func (p *paymentService) UpdateStatusByOrderCode(ctx context.Context, orderCode string, status string) error {
 	orderDetailID, err := p.httpClientPublicOrderIDByCodeService(orderCode)
@@ -115,83 +580,202 @@ func (p *paymentService) UpdateStatusByOrderCode(ctx context.Context, orderCode
 		return err
 	}
 
-	if err = p.repo.UpdateStatusByOrderCode(ctx, uint(orderDetailID), status); err != nil {
+	payment, err := p.repo.GetDetailByOrderID(ctx, uint(orderDetailID))
+	if err != nil {
 		log.Errorf("[PaymentService] UpdateStatusByOrderCode-2: %v", err)
 		return err
 	}
 
+	if err = p.repo.UpdateStatus(ctx, payment.ID, status); err != nil {
+		log.Errorf("[PaymentService] UpdateStatusByOrderCode-3: %v", err)
+		return err
+	}
+
+	if err = p.repo.LogPayment(ctx, entity.PaymentLogEntity{
+		PaymentID: payment.ID,
+		Status:    status,
+		Source:    "system",
+	}); err != nil {
+		log.Errorf("[PaymentService] UpdateStatusByOrderCode-4: %v", err)
+	}
+
 	return nil
 }
 
 // ProcessPayment implements PaymentServiceInterface.
 func (p *paymentService) ProcessPayment(ctx context.Context, payment entity.PaymentEntity, accessToken string) (*entity.PaymentEntity, error) {
-	err := p.repo.GetByOrderID(ctx, uint(payment.OrderID))
-	if err == nil {
-		log.Infof("[PaymentService] ProcessPayment-1: Payment already exists")
-		return nil, errors.New("Payment already exists")
+	attempts, err := p.repo.GetAttemptsByOrderID(ctx, uint(payment.OrderID))
+	if err != nil {
+		log.Errorf("[PaymentService] ProcessPayment-1: %v", err)
+		return nil, err
 	}
 
-	if payment.PaymentMethod == "cod" {
-		payment.PaymentStatus = "Success"
-
-		if err := p.repo.CreatePayment(ctx, payment); err != nil {
-			log.Errorf("[PaymentService] ProcessPayment-2: %v", err)
-			return nil, err
+	for _, attempt := range attempts {
+		if attempt.PaymentStatus == "Success" || attempt.PaymentStatus == "Refunded" || attempt.PaymentStatus == "Partially Refunded" || attempt.PaymentStatus == "AwaitingCollection" {
+			log.Infof("[PaymentService] ProcessPayment-1: order %d already paid by payment %d", payment.OrderID, attempt.ID)
+			return nil, errors.New("Payment already exists")
 		}
+	}
 
-		if err := p.publisherRabbitMQ.PublishPaymentSuccess(payment); err != nil {
-			log.Errorf("[PaymentService] ProcessPayment-3: %v", err)
-		}
+	provider, err := p.gateways.Get(payment.PaymentMethod)
+	if err != nil {
+		log.Errorf("[PaymentService] ProcessPayment-2: %v", err)
+		return nil, err
+	}
+
+	var token map[string]interface{}
+	err = json.Unmarshal([]byte(accessToken), &token)
+	if err != nil {
+		log.Errorf("[PaymentService] ProcessPayment-3: %v", err)
+		return nil, err
+	}
 
-		return &payment, nil
+	isAdmin := false
+	if token["role_name"].(string) == "Super Admin" {
+		isAdmin = true
 	}
 
-	if payment.PaymentMethod == "midtrans" {
-		var token map[string]interface{}
-		err := json.Unmarshal([]byte(accessToken), &token)
-		if err != nil {
-			log.Errorf("[PaymentService] ProcessPayment-4: %v", err)
-			return nil, err
-		}
+	orderDetail, err := p.httpClientOrderService(int64(payment.OrderID), token["token"].(string))
+	if err != nil {
+		log.Errorf("[PaymentService] ProcessPayment-4: %v", err)
+		return nil, err
+	}
 
-		isAdmin := false
-		if token["role_name"].(string) == "Super Admin" {
-			isAdmin = true
+	// Customer hanya boleh membayar order miliknya sendiri, admin membayar atas nama pemilik order
+	if !isAdmin {
+		userID, _ := token["user_id"].(float64)
+		if orderDetail.Customer.CustomerID != int64(userID) {
+			log.Infof("[PaymentService] ProcessPayment-5: order %d does not belong to user %d", payment.OrderID, int64(userID))
+			return nil, errors.New("403")
 		}
+	}
+	payment.UserID = uint(orderDetail.Customer.CustomerID)
 
-		userResponse, err := p.httpClientUserService(token["token"].(string), int64(payment.UserID), isAdmin)
-		if err != nil {
-			log.Errorf("[PaymentService] ProcessPayment-5: %v", err)
-			return nil, err
+	if orderDetail.Status != "Pending" {
+		log.Infof("[PaymentService] ProcessPayment-6: order %d has status %s", payment.OrderID, orderDetail.Status)
+		return nil, errors.New("409")
+	}
+
+	// Nominal yang ditagihkan selalu total order dari order-service, bukan nominal kiriman client
+	orderTotal := float64(orderDetail.TotalAmount)
+	if math.Abs(payment.GrossAmount-orderTotal) > 0.01 {
+		log.Infof("[PaymentService] ProcessPayment-7: gross amount %.2f does not match order total %.2f", payment.GrossAmount, orderTotal)
+		return nil, errors.New("422")
+	}
+	payment.GrossAmount = orderTotal
+	payment.OrderCode = orderDetail.OrderCode
+
+	orderSnapshot, err := json.Marshal(orderDetail)
+	if err != nil {
+		log.Errorf("[PaymentService] ProcessPayment-8: %v", err)
+		return nil, err
+	}
+	payment.OrderSnapshot = string(orderSnapshot)
+
+	userResponse, err := p.httpClientUserService(token["token"].(string), int64(payment.UserID), isAdmin)
+	if err != nil {
+		log.Errorf("[PaymentService] ProcessPayment-9: %v", err)
+		return nil, err
+	}
+
+	// Pembayaran yang dibuat admin atas nama customer tidak melewati rule fraud
+	if !isAdmin {
+		check := entity.FraudCheckEntity{
+			OrderID:          payment.OrderID,
+			UserID:           payment.UserID,
+			ClientIP:         payment.ClientIP,
+			Phone:            userResponse.Phone,
+			GrossAmount:      payment.GrossAmount,
+			AccountCreatedAt: userResponse.CreatedAt,
 		}
 
-		orderDetail, err := p.httpClientOrderService(int64(payment.OrderID), token["token"].(string))
-		if err != nil {
-			log.Errorf("[PaymentService] ProcessPayment-6: %v", err)
-			return nil, err
+		// Velocity dihitung dari data order di order-service, bukan dari order yang sudah sampai ke pembayaran
+		if p.cfg.Fraud.Enabled {
+			velocity, err := p.httpClientOrderVelocityService(int64(payment.OrderID), token["token"].(string))
+			if err != nil {
+				log.Errorf("[PaymentService] ProcessPayment-10: %v", err)
+				return nil, err
+			}
+			check.RecentOrdersByUser = velocity.Data.ByUser
+			check.RecentOrdersByIP = velocity.Data.ByIP
+			check.RecentOrdersByPhone = velocity.Data.ByPhone
 		}
 
-		transactionID, err := p.midtrans.CreateTransaction(orderDetail.OrderCode, int64(payment.GrossAmount), userResponse.Name, userResponse.Email)
+		fraudCheck, err := p.fraudService.Evaluate(ctx, check)
 		if err != nil {
-			log.Errorf("[PaymentService] ProcessPayment-7: %v", err)
+			log.Errorf("[PaymentService] ProcessPayment-11: %v", err)
 			return nil, err
 		}
-		payment.PaymentStatus = "Pending"
-		payment.PaymentGatewayID = transactionID
 
-		if err := p.repo.CreatePayment(ctx, payment); err != nil {
-			log.Errorf("[PaymentService] ProcessPayment-8: %v", err)
-			return nil, err
+		switch fraudCheck.Decision {
+		case "block":
+			log.Infof("[PaymentService] ProcessPayment-12: order %d blocked by fraud check %d", payment.OrderID, fraudCheck.ID)
+			return nil, errors.New("Payment blocked by fraud check")
+		case "review":
+			log.Infof("[PaymentService] ProcessPayment-13: order %d held by fraud check %d", payment.OrderID, fraudCheck.ID)
+			return nil, errors.New("Payment is under review")
 		}
+	}
+
+	// Nomor percobaan dan order_id gateway dialokasikan repository saat percobaan order dikunci
+	created, err := p.repo.CreatePayment(ctx, payment)
+	if err != nil {
+		log.Errorf("[PaymentService] ProcessPayment-14: %v", err)
+		return nil, err
+	}
+	payment = *created
 
-		if err := p.publisherRabbitMQ.PublishPaymentSuccess(payment); err != nil {
-			log.Errorf("[PaymentService] ProcessPayment-9: %v", err)
+	transaction, err := provider.CreateTransaction(ctx, payment, *userResponse)
+	if err != nil {
+		log.Errorf("[PaymentService] ProcessPayment-15: %v", err)
+		// Percobaan yang gagal dibuat di gateway ditandai Failed agar nomor percobaannya tidak dipakai ulang
+		if errStatus := p.repo.UpdateStatus(ctx, payment.ID, "Failed"); errStatus != nil {
+			log.Errorf("[PaymentService] ProcessPayment-16: %v", errStatus)
 		}
+		return nil, err
+	}
+	payment.PaymentStatus = transaction.PaymentStatus
+	payment.PaymentGatewayID = transaction.PaymentGatewayID
+	payment.PaymentURL = transaction.PaymentURL
+	payment.Instructions = transaction.Instructions
+	payment.PaymentCode = transaction.PaymentCode
+	payment.ExpiresAt = transaction.ExpiresAt
+	if transaction.PaymentChannel != "" {
+		payment.PaymentChannel = transaction.PaymentChannel
+	}
+
+	transactionPayload, _ := json.Marshal(map[string]interface{}{
+		"payment_gateway_id": transaction.PaymentGatewayID,
+		"payment_url":        transaction.PaymentURL,
+		"instructions":       transaction.Instructions,
+		"payment_channel":    transaction.PaymentChannel,
+		"payment_code":       transaction.PaymentCode,
+		"expiry_time":        transaction.ExpiresAt,
+	})
+	if err := p.repo.SaveTransaction(ctx, payment, entity.PaymentLogEntity{
+		PaymentID: payment.ID,
+		Status:    payment.PaymentStatus,
+		Source:    provider.Name(),
+		Payload:   string(transactionPayload),
+	}); err != nil {
+		log.Errorf("[PaymentService] ProcessPayment-17: %v", err)
+		return nil, err
+	}
+
+	// Percobaan lama baru dibatalkan setelah percobaan baru tersimpan, sehingga order tidak pernah tanpa percobaan aktif
+	if err := p.cancelPendingAttempts(ctx, payment); err != nil {
+		log.Errorf("[PaymentService] ProcessPayment-18: %v", err)
+	}
+
+	if err := p.publisherRabbitMQ.PublishPaymentCreated(payment); err != nil {
+		log.Errorf("[PaymentService] ProcessPayment-19: %v", err)
+	}
 
-		return &payment, nil
+	if err := p.publishPaymentOutcome(payment); err != nil {
+		log.Errorf("[PaymentService] ProcessPayment-20: %v", err)
 	}
 
-	return nil, errors.New("Invalid payment method")
+	return &payment, nil
 }
 
 func (p *paymentService) httpClientOrderService(orderId int64, accessToken string) (*entity.OrderDetailHttpResponse, error) {
@@ -224,6 +808,41 @@ func (p *paymentService) httpClientOrderService(orderId int64, accessToken strin
 	return &orderDetail.Data, nil
 }
 
+func (p *paymentService) httpClientOrderVelocityService(orderId int64, accessToken string) (*entity.OrderVelocityHttpClientResponse, error) {
+	baseUrlOrder := fmt.Sprintf("%s/%s", p.cfg.App.OrderServiceUrl, "auth/orders/"+strconv.FormatInt(orderId, 10)+"/velocity?minutes=60")
+	header := map[string]string{
+		"Authorization": "Bearer " + accessToken,
+		"Accept":        "application/json",
+	}
+	dataOrder, err := p.httpClientToService.CallURL("GET", baseUrlOrder, header, nil)
+	if err != nil {
+		log.Errorf("[PaymentService] httpClientOrderVelocityService-1: %v", err)
+		return nil, err
+	}
+
+	defer dataOrder.Body.Close()
+
+	if dataOrder.StatusCode != 200 {
+		log.Errorf("[PaymentService] httpClientOrderVelocityService-2: unexpected status %d", dataOrder.StatusCode)
+		return nil, fmt.Errorf("order velocity request failed with status %d", dataOrder.StatusCode)
+	}
+
+	body, err := io.ReadAll(dataOrder.Body)
+	if err != nil {
+		log.Errorf("[PaymentService] httpClientOrderVelocityService-3: %v", err)
+		return nil, err
+	}
+
+	var velocity entity.OrderVelocityHttpClientResponse
+	err = json.Unmarshal([]byte(body), &velocity)
+	if err != nil {
+		log.Errorf("[PaymentService] httpClientOrderVelocityService-4: %v", err)
+		return nil, err
+	}
+
+	return &velocity, nil
+}
+
 func (p *paymentService) httpClientUserService(accessToken string, userID int64, isAdmin bool) (*entity.ProfileHttpResponse, error) {
 	baseUrlUser := fmt.Sprintf("%s/%s", p.cfg.App.UserServiceUrl, "auth/profile")
 	if isAdmin {
@@ -321,12 +940,14 @@ func (p *paymentService) httpClientPublicOrderIDByCodeService(orderCode string)
 	return int64(orderDetail.Data.OrderID), nil
 }
 
-func NewPaymentService(repo repository.PaymentRepositoryInterface, cfg *config.Config, httpClientToService httpclient.HttpClientToService, midtrans httpclient.MidtransClientInterface, publisherRabbitMQ message.PublishRabbitMQInterface) PaymentServiceInterface {
+func NewPaymentService(repo repository.PaymentRepositoryInterface, refundRepo repository.RefundRepositoryInterface, cfg *config.Config, httpClientToService httpclient.HttpClientToService, gateways gateway.RegistryInterface, publisherRabbitMQ message.PublishRabbitMQInterface, fraudService FraudServiceInterface) PaymentServiceInterface {
 	return &paymentService{
 		repo:                repo,
+		refundRepo:          refundRepo,
 		httpClientToService: httpClientToService,
-		midtrans:            midtrans,
+		gateways:            gateways,
 		cfg:                 cfg,
 		publisherRabbitMQ:   publisherRabbitMQ,
+		fraudService:        fraudService,
 	}
 }
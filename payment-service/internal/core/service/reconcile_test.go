@@ -0,0 +1,146 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"payment-service/config"
+	"payment-service/internal/adapter/gateway"
+	httpclient "payment-service/internal/adapter/http_client"
+	"payment-service/internal/adapter/message"
+	"payment-service/internal/adapter/repository"
+	"payment-service/internal/core/domain/entity"
+	"testing"
+	"time"
+)
+
+// reconcileRepoStub menyimpan log dan status di memori, method lain dari interface tidak dipakai rekonsiliasi.
+type reconcileRepoStub struct {
+	repository.PaymentRepositoryInterface
+	payments []entity.PaymentEntity
+	events   map[string]bool
+	statuses map[uint]string
+}
+
+func (r *reconcileRepoStub) GetForReconciliation(ctx context.Context, updatedSince time.Time) ([]entity.PaymentEntity, error) {
+	return r.payments, nil
+}
+
+func (r *reconcileRepoStub) IsEventLogged(ctx context.Context, eventKey string) (bool, error) {
+	return r.events[eventKey], nil
+}
+
+func (r *reconcileRepoStub) LogPayment(ctx context.Context, paymentLog entity.PaymentLogEntity) error {
+	return nil
+}
+
+func (r *reconcileRepoStub) LogPaymentWithStatus(ctx context.Context, paymentLog entity.PaymentLogEntity, fromStatus, status string) (bool, error) {
+	if r.events[paymentLog.EventKey] {
+		return false, errors.New("409")
+	}
+
+	r.events[paymentLog.EventKey] = true
+	if status == "" {
+		return false, nil
+	}
+
+	r.statuses[paymentLog.PaymentID] = status
+	return true, nil
+}
+
+type reconcilePublisherStub struct {
+	message.PublishRabbitMQInterface
+	published []string
+}
+
+func (p *reconcilePublisherStub) PublishPaymentSuccess(payment entity.PaymentEntity) error {
+	p.published = append(p.published, payment.OrderCode+":Success")
+	return nil
+}
+
+func (p *reconcilePublisherStub) PublishPaymentExpired(payment entity.PaymentEntity) error {
+	p.published = append(p.published, payment.OrderCode+":Expired")
+	return nil
+}
+
+func (p *reconcilePublisherStub) PublishPaymentFailed(payment entity.PaymentEntity) error {
+	p.published = append(p.published, payment.OrderCode+":Failed")
+	return nil
+}
+
+func TestReconcilePayments(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/v2/ORD-PAID-1/status":
+			w.Write([]byte(`{"status_code":"200","order_id":"ORD-PAID-1","transaction_id":"trx-1","transaction_status":"settlement","fraud_status":"accept","gross_amount":"150000.00"}`))
+		case "/v2/ORD-EXPIRED-1/status":
+			w.Write([]byte(`{"status_code":"407","order_id":"ORD-EXPIRED-1","transaction_id":"trx-2","transaction_status":"expire","gross_amount":"75000.00"}`))
+		case "/v2/ORD-REFUND-1/status":
+			w.Write([]byte(`{"status_code":"200","order_id":"ORD-REFUND-1","transaction_id":"trx-4","transaction_status":"refund","gross_amount":"40000.00"}`))
+		case "/v2/ORD-CANCELLED-1/status":
+			w.Write([]byte(`{"status_code":"200","order_id":"ORD-CANCELLED-1","transaction_id":"trx-5","transaction_status":"settlement","gross_amount":"30000.00"}`))
+		case "/v2/ORD-SLOW-1/status":
+			time.Sleep(1500 * time.Millisecond)
+			w.Write([]byte(`{"status_code":"200","order_id":"ORD-SLOW-1","transaction_id":"trx-3","transaction_status":"settlement","gross_amount":"50000.00"}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"status_code":"404","status_message":"Transaction doesn't exist."}`))
+		}
+	}))
+	defer server.Close()
+
+	cfg := &config.Config{
+		App:      config.App{ServerTimeOut: 1},
+		Midtrans: config.Midtrans{ServerKey: "server-key", BaseUrl: server.URL},
+	}
+	midtrans := httpclient.NewMidtransClient(cfg)
+	gateways := gateway.NewRegistry(
+		gateway.NewMidtransProvider(cfg, midtrans),
+		gateway.NewVirtualAccountProvider(cfg, midtrans),
+		gateway.NewQrisProvider(cfg, midtrans),
+	)
+
+	repo := &reconcileRepoStub{
+		payments: []entity.PaymentEntity{
+			{ID: 1, OrderID: 10, OrderCode: "ORD", GatewayOrderID: "ORD-PAID-1", PaymentMethod: "virtual_account", PaymentStatus: "Pending", GrossAmount: 150000},
+			{ID: 2, OrderID: 11, OrderCode: "ORD", GatewayOrderID: "ORD-EXPIRED-1", PaymentMethod: "qris", PaymentStatus: "Pending", GrossAmount: 75000},
+			{ID: 3, OrderID: 12, OrderCode: "ORD", GatewayOrderID: "ORD-SLOW-1", PaymentMethod: "midtrans", PaymentStatus: "Pending", GrossAmount: 50000},
+			{ID: 4, OrderID: 13, OrderCode: "ORD", GatewayOrderID: "ORD-REFUND-1", PaymentMethod: "qris", PaymentStatus: "Success", GrossAmount: 40000},
+			{ID: 5, OrderID: 14, OrderCode: "ORD", GatewayOrderID: "ORD-CANCELLED-1", PaymentMethod: "qris", PaymentStatus: "Cancelled", GrossAmount: 30000},
+		},
+		events:   map[string]bool{},
+		statuses: map[uint]string{},
+	}
+	publisher := &reconcilePublisherStub{}
+	paymentService := NewPaymentService(repo, nil, cfg, nil, gateways, publisher, nil)
+
+	if err := paymentService.ReconcilePayments(context.Background(), time.Now().Add(-time.Hour)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	wantStatuses := map[uint]string{1: "Success", 2: "Expired", 4: "Refunded"}
+	for paymentID, want := range wantStatuses {
+		if got := repo.statuses[paymentID]; got != want {
+			t.Errorf("payment %d status = %q, want %q", paymentID, got, want)
+		}
+	}
+	if got, ok := repo.statuses[3]; ok {
+		t.Errorf("payment 3 timed out at gateway but status changed to %q", got)
+	}
+	if got, ok := repo.statuses[5]; ok {
+		t.Errorf("cancelled payment 5 changed to %q", got)
+	}
+	if len(publisher.published) != 2 {
+		t.Errorf("published events = %v, want 2 events", publisher.published)
+	}
+
+	// Status gateway yang sama pada putaran berikutnya tidak boleh diproses dua kali
+	if err := paymentService.ReconcilePayments(context.Background(), time.Now().Add(-time.Hour)); err != nil {
+		t.Fatalf("unexpected error on second pass: %v", err)
+	}
+	if len(publisher.published) != 2 {
+		t.Errorf("published events after second pass = %v, want 2 events", publisher.published)
+	}
+}
@@ -0,0 +1,260 @@
+package service
+
+import (
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"payment-service/internal/adapter/repository"
+	"payment-service/internal/core/domain/entity"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/labstack/gommon/log"
+)
+
+// settlementColumns memetakan nama kolom file settlement gateway (sudah dinormalisasi) ke field yang dipakai.
+var settlementColumns = map[string]string{
+	"order id":           "order_id",
+	"transaction id":     "transaction_id",
+	"gross amount":       "gross_amount",
+	"amount":             "gross_amount",
+	"fee":                "fee_amount",
+	"fee amount":         "fee_amount",
+	"total fee":          "fee_amount",
+	"mdr":                "fee_amount",
+	"transaction status": "transaction_status",
+	"status":             "transaction_status",
+}
+
+type SettlementServiceInterface interface {
+	GetDailyReport(ctx context.Context, startDate, endDate time.Time) ([]entity.DailyPaymentSummaryEntity, error)
+	ImportSettlement(ctx context.Context, file io.Reader, paymentMethod string, startDate, endDate time.Time) (*entity.SettlementImportResultEntity, error)
+}
+
+type settlementService struct {
+	repo repository.SettlementRepositoryInterface
+}
+
+// GetDailyReport implements SettlementServiceInterface.
+func (s *settlementService) GetDailyReport(ctx context.Context, startDate time.Time, endDate time.Time) ([]entity.DailyPaymentSummaryEntity, error) {
+	if endDate.Before(startDate) {
+		log.Infof("[SettlementService-1] GetDailyReport: end date %s before start date %s", endDate, startDate)
+		return nil, errors.New("400")
+	}
+
+	results, err := s.repo.GetDailySummary(ctx, startDate, endDate.AddDate(0, 0, 1))
+	if err != nil {
+		log.Errorf("[SettlementService-2] GetDailyReport: %v", err)
+		return nil, err
+	}
+
+	return results, nil
+}
+
+// ImportSettlement implements SettlementServiceInterface.
+// Setiap baris file dicocokkan dengan payment berdasarkan order_id gateway. Baris yang cocok menyimpan fee gateway,
+// selain itu ditandai duplicate, missing_in_system atau mismatch. Jika rentang tanggal diisi, payment lunas
+// pada rentang itu yang tidak ada di file ditandai missing_in_settlement.
+func (s *settlementService) ImportSettlement(ctx context.Context, file io.Reader, paymentMethod string, startDate time.Time, endDate time.Time) (*entity.SettlementImportResultEntity, error) {
+	rows, err := parseSettlementCSV(file)
+	if err != nil {
+		log.Errorf("[SettlementService-1] ImportSettlement: %v", err)
+		return nil, err
+	}
+
+	result := entity.SettlementImportResultEntity{
+		TotalRows: len(rows),
+		Flags:     []entity.SettlementFlagEntity{},
+	}
+
+	seenOrders := map[string]int{}
+	seenPayments := map[uint]bool{}
+	for _, row := range rows {
+		if line, ok := seenOrders[row.OrderID]; ok {
+			result.Flags = append(result.Flags, entity.SettlementFlagEntity{
+				Line:    row.Line,
+				OrderID: row.OrderID,
+				Flag:    "duplicate",
+				Detail:  fmt.Sprintf("order id already listed on line %d", line),
+			})
+			continue
+		}
+		seenOrders[row.OrderID] = row.Line
+
+		payment, err := s.repo.GetByGatewayReference(ctx, row.OrderID)
+		if err != nil {
+			if err.Error() != "404" {
+				log.Errorf("[SettlementService-2] ImportSettlement: %v", err)
+				return nil, err
+			}
+			result.Flags = append(result.Flags, entity.SettlementFlagEntity{
+				Line:    row.Line,
+				OrderID: row.OrderID,
+				Flag:    "missing_in_system",
+				Detail:  "no payment found for this order id",
+			})
+			continue
+		}
+		seenPayments[payment.ID] = true
+
+		if math.Abs(payment.GrossAmount-row.GrossAmount) > 0.01 {
+			result.Flags = append(result.Flags, entity.SettlementFlagEntity{
+				Line:      row.Line,
+				OrderID:   row.OrderID,
+				PaymentID: payment.ID,
+				Flag:      "mismatch",
+				Detail:    fmt.Sprintf("gross amount %.2f, payment records %.2f", row.GrossAmount, payment.GrossAmount),
+			})
+			continue
+		}
+
+		if !isSettledStatus(row.TransactionStatus) || !isPaidStatus(payment.PaymentStatus) {
+			result.Flags = append(result.Flags, entity.SettlementFlagEntity{
+				Line:      row.Line,
+				OrderID:   row.OrderID,
+				PaymentID: payment.ID,
+				Flag:      "mismatch",
+				Detail:    fmt.Sprintf("settlement status %s, payment status %s", row.TransactionStatus, payment.PaymentStatus),
+			})
+			continue
+		}
+
+		if err := s.repo.UpdateGatewayFee(ctx, payment.ID, row.FeeAmount); err != nil {
+			log.Errorf("[SettlementService-3] ImportSettlement: %v", err)
+			return nil, err
+		}
+		result.Matched++
+	}
+
+	if startDate.IsZero() || endDate.IsZero() {
+		return &result, nil
+	}
+
+	payments, err := s.repo.GetPaidPayments(ctx, paymentMethod, startDate, endDate.AddDate(0, 0, 1))
+	if err != nil {
+		log.Errorf("[SettlementService-4] ImportSettlement: %v", err)
+		return nil, err
+	}
+
+	for _, payment := range payments {
+		if seenPayments[payment.ID] {
+			continue
+		}
+		result.Flags = append(result.Flags, entity.SettlementFlagEntity{
+			OrderID:   gatewayOrderID(payment),
+			PaymentID: payment.ID,
+			Flag:      "missing_in_settlement",
+			Detail:    fmt.Sprintf("payment %s is not in the settlement file", payment.PaymentStatus),
+		})
+	}
+
+	return &result, nil
+}
+
+func parseSettlementCSV(file io.Reader) ([]entity.SettlementRowEntity, error) {
+	reader := csv.NewReader(file)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err != nil {
+		return nil, errors.New("400")
+	}
+
+	columns := map[string]int{}
+	for idx, name := range header {
+		name = strings.ToLower(strings.TrimSpace(strings.TrimPrefix(name, "\ufeff")))
+		name = strings.ReplaceAll(name, "_", " ")
+		if field, ok := settlementColumns[name]; ok {
+			if _, exists := columns[field]; !exists {
+				columns[field] = idx
+			}
+		}
+	}
+
+	if _, ok := columns["order_id"]; !ok {
+		return nil, errors.New("400")
+	}
+	if _, ok := columns["gross_amount"]; !ok {
+		return nil, errors.New("400")
+	}
+
+	value := func(record []string, field string) string {
+		idx, ok := columns[field]
+		if !ok || idx >= len(record) {
+			return ""
+		}
+		return strings.TrimSpace(record[idx])
+	}
+
+	rows := []entity.SettlementRowEntity{}
+	line := 1
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		line++
+		if err != nil {
+			return nil, errors.New("400")
+		}
+
+		orderID := value(record, "order_id")
+		if orderID == "" {
+			continue
+		}
+
+		grossAmount, err := parseSettlementAmount(value(record, "gross_amount"))
+		if err != nil {
+			return nil, fmt.Errorf("invalid gross amount on line %d", line)
+		}
+
+		feeAmount, err := parseSettlementAmount(value(record, "fee_amount"))
+		if err != nil {
+			return nil, fmt.Errorf("invalid fee on line %d", line)
+		}
+
+		rows = append(rows, entity.SettlementRowEntity{
+			Line:              line,
+			OrderID:           orderID,
+			TransactionID:     value(record, "transaction_id"),
+			TransactionStatus: strings.ToLower(value(record, "transaction_status")),
+			GrossAmount:       grossAmount,
+			FeeAmount:         feeAmount,
+		})
+	}
+
+	return rows, nil
+}
+
+func parseSettlementAmount(amount string) (float64, error) {
+	amount = strings.TrimSpace(strings.TrimPrefix(amount, "Rp"))
+	amount = strings.ReplaceAll(amount, ",", "")
+	if amount == "" {
+		return 0, nil
+	}
+
+	return strconv.ParseFloat(amount, 64)
+}
+
+// isSettledStatus menganggap baris tanpa kolom status sebagai settlement karena file settlement hanya berisi transaksi lunas.
+func isSettledStatus(status string) bool {
+	switch status {
+	case "", "settlement", "settled", "success", "capture":
+		return true
+	}
+
+	return false
+}
+
+func isPaidStatus(status string) bool {
+	return status == "Success" || status == "Partially Refunded" || status == "Refunded"
+}
+
+func NewSettlementService(repo repository.SettlementRepositoryInterface) SettlementServiceInterface {
+	return &settlementService{repo: repo}
+}
@@ -0,0 +1,222 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"payment-service/config"
+	"payment-service/internal/adapter/message"
+	"payment-service/internal/adapter/repository"
+	"payment-service/internal/adapter/storage"
+	"payment-service/internal/core/domain/entity"
+	"time"
+
+	"github.com/labstack/gommon/log"
+)
+
+const transferProofURLExpiry = 15 * time.Minute
+
+type TransferProofServiceInterface interface {
+	SubmitTransferProof(ctx context.Context, proof entity.TransferProofEntity, file io.Reader, extension, contentType string) (*entity.TransferProofEntity, error)
+	GetByPaymentID(ctx context.Context, paymentID uint, accessToken string) ([]entity.TransferProofEntity, error)
+	ReviewTransferProof(ctx context.Context, proofID uint, approve bool, note string, accessToken string) (*entity.TransferProofEntity, error)
+}
+
+type transferProofService struct {
+	repo              repository.TransferProofRepositoryInterface
+	paymentRepo       repository.PaymentRepositoryInterface
+	storage           storage.StorageInterface
+	cfg               *config.Config
+	publisherRabbitMQ message.PublishRabbitMQInterface
+}
+
+// ReviewTransferProof implements TransferProofServiceInterface.
+func (t *transferProofService) ReviewTransferProof(ctx context.Context, proofID uint, approve bool, note string, accessToken string) (*entity.TransferProofEntity, error) {
+	var token entity.JwtUserData
+	if err := json.Unmarshal([]byte(accessToken), &token); err != nil {
+		log.Errorf("[TransferProofService-1] ReviewTransferProof: %v", err)
+		return nil, err
+	}
+
+	proof, err := t.repo.GetByID(ctx, proofID)
+	if err != nil {
+		log.Errorf("[TransferProofService-2] ReviewTransferProof: %v", err)
+		return nil, err
+	}
+
+	payment, err := t.paymentRepo.GetDetail(ctx, proof.PaymentID)
+	if err != nil {
+		log.Errorf("[TransferProofService-3] ReviewTransferProof: %v", err)
+		return nil, err
+	}
+
+	if payment.PaymentStatus != "Pending" {
+		log.Infof("[TransferProofService-4] ReviewTransferProof: payment %d has status %s", payment.ID, payment.PaymentStatus)
+		return nil, errors.New("409")
+	}
+
+	proof.Status = "Rejected"
+	if approve {
+		proof.Status = "Confirmed"
+	}
+
+	if err := t.repo.UpdateReview(ctx, proof.ID, proof.Status, note, uint(token.UserID)); err != nil {
+		log.Errorf("[TransferProofService-5] ReviewTransferProof: %v", err)
+		return nil, err
+	}
+	proof.Note = note
+	proof.ReviewedBy = uint(token.UserID)
+
+	payload, _ := json.Marshal(map[string]interface{}{
+		"transfer_proof_id": proof.ID,
+		"status":            proof.Status,
+		"note":              note,
+		"reviewed_by":       token.UserID,
+	})
+
+	// Bukti ditolak: payment tetap Pending agar customer bisa upload ulang
+	message := fmt.Sprintf("Bukti transfer untuk order %s ditolak: %s", payment.OrderCode, note)
+	if approve {
+		payment.PaymentStatus = "Success"
+		message = fmt.Sprintf("Pembayaran transfer untuk order %s telah dikonfirmasi", payment.OrderCode)
+
+		if err := t.paymentRepo.UpdateStatus(ctx, payment.ID, payment.PaymentStatus); err != nil {
+			log.Errorf("[TransferProofService-6] ReviewTransferProof: %v", err)
+			return nil, err
+		}
+	}
+
+	if err := t.paymentRepo.LogPayment(ctx, entity.PaymentLogEntity{
+		PaymentID: payment.ID,
+		Status:    payment.PaymentStatus,
+		Source:    "transfer_proof_review",
+		EventKey:  fmt.Sprintf("TP-%d-%s", proof.ID, proof.Status),
+		Payload:   string(payload),
+	}); err != nil {
+		log.Errorf("[TransferProofService-7] ReviewTransferProof: %v", err)
+	}
+
+	if approve {
+		if err := t.publisherRabbitMQ.PublishPaymentSuccess(*payment); err != nil {
+			log.Errorf("[TransferProofService-8] ReviewTransferProof: %v", err)
+		}
+	}
+
+	if err := t.publisherRabbitMQ.PublishNotification(t.cfg.PublisherName.PushNotif, "PUSH", "Konfirmasi Pembayaran", message, "", int64(payment.UserID)); err != nil {
+		log.Errorf("[TransferProofService-9] ReviewTransferProof: %v", err)
+	}
+
+	return proof, nil
+}
+
+// GetByPaymentID implements TransferProofServiceInterface.
+func (t *transferProofService) GetByPaymentID(ctx context.Context, paymentID uint, accessToken string) ([]entity.TransferProofEntity, error) {
+	var token entity.JwtUserData
+	if err := json.Unmarshal([]byte(accessToken), &token); err != nil {
+		log.Errorf("[TransferProofService-1] GetByPaymentID: %v", err)
+		return nil, err
+	}
+
+	payment, err := t.paymentRepo.GetDetail(ctx, paymentID)
+	if err != nil {
+		log.Errorf("[TransferProofService-2] GetByPaymentID: %v", err)
+		return nil, err
+	}
+
+	if token.RoleName != "Super Admin" && int64(payment.UserID) != token.UserID {
+		log.Infof("[TransferProofService-3] GetByPaymentID: payment %d does not belong to user %d", payment.ID, token.UserID)
+		return nil, errors.New("404")
+	}
+
+	results, err := t.repo.GetByPaymentID(ctx, paymentID)
+	if err != nil {
+		log.Errorf("[TransferProofService-4] GetByPaymentID: %v", err)
+		return nil, err
+	}
+
+	// Bukti transfer tidak disimpan di bawah public/, URL yang dikembalikan ditandatangani dengan masa berlaku singkat
+	for i, val := range results {
+		path := t.storage.PathFromURL(val.ImageURL)
+		if path == "" {
+			continue
+		}
+
+		signedURL, err := t.storage.SignedURL(path, transferProofURLExpiry)
+		if err != nil {
+			log.Errorf("[TransferProofService-5] GetByPaymentID: %v", err)
+			continue
+		}
+		results[i].ImageURL = signedURL
+	}
+
+	return results, nil
+}
+
+// SubmitTransferProof implements TransferProofServiceInterface.
+func (t *transferProofService) SubmitTransferProof(ctx context.Context, proof entity.TransferProofEntity, file io.Reader, extension string, contentType string) (*entity.TransferProofEntity, error) {
+	payment, err := t.paymentRepo.GetDetail(ctx, proof.PaymentID)
+	if err != nil {
+		log.Errorf("[TransferProofService-1] SubmitTransferProof: %v", err)
+		return nil, err
+	}
+
+	if payment.UserID != proof.UserID {
+		log.Infof("[TransferProofService-2] SubmitTransferProof: payment %d does not belong to user %d", payment.ID, proof.UserID)
+		return nil, errors.New("404")
+	}
+
+	if payment.PaymentMethod != "bank_transfer" {
+		log.Infof("[TransferProofService-3] SubmitTransferProof: payment %d uses %s", payment.ID, payment.PaymentMethod)
+		return nil, errors.New("Payment method does not accept transfer proof")
+	}
+
+	if payment.PaymentStatus != "Pending" {
+		log.Infof("[TransferProofService-4] SubmitTransferProof: payment %d has status %s", payment.ID, payment.PaymentStatus)
+		return nil, errors.New("Payment is not awaiting transfer proof")
+	}
+
+	uploadPath := fmt.Sprintf("transfer-proofs/%d/%d%s", payment.ID, time.Now().UnixNano(), extension)
+	proof.ImageURL, err = t.storage.UploadFile(uploadPath, contentType, file)
+	if err != nil {
+		log.Errorf("[TransferProofService-5] SubmitTransferProof: %v", err)
+		return nil, err
+	}
+
+	proof.Status = "Submitted"
+	proof.ID, err = t.repo.CreateTransferProof(ctx, proof)
+	if err != nil {
+		log.Errorf("[TransferProofService-6] SubmitTransferProof: %v", err)
+		return nil, err
+	}
+
+	payload, _ := json.Marshal(map[string]interface{}{
+		"transfer_proof_id": proof.ID,
+		"bank_name":         proof.BankName,
+		"account_name":      proof.AccountName,
+		"amount":            proof.Amount,
+		"image_url":         proof.ImageURL,
+	})
+	if err := t.paymentRepo.LogPayment(ctx, entity.PaymentLogEntity{
+		PaymentID: payment.ID,
+		Status:    payment.PaymentStatus,
+		Source:    "transfer_proof",
+		EventKey:  fmt.Sprintf("TP-%d-Submitted", proof.ID),
+		Payload:   string(payload),
+	}); err != nil {
+		log.Errorf("[TransferProofService-7] SubmitTransferProof: %v", err)
+	}
+
+	return &proof, nil
+}
+
+func NewTransferProofService(repo repository.TransferProofRepositoryInterface, paymentRepo repository.PaymentRepositoryInterface, storage storage.StorageInterface, cfg *config.Config, publisherRabbitMQ message.PublishRabbitMQInterface) TransferProofServiceInterface {
+	return &transferProofService{
+		repo:              repo,
+		paymentRepo:       paymentRepo,
+		storage:           storage,
+		cfg:               cfg,
+		publisherRabbitMQ: publisherRabbitMQ,
+	}
+}
@@ -0,0 +1,142 @@
+package service
+
+import (
+	"context"
+	"payment-service/config"
+	"payment-service/internal/adapter/repository"
+	"payment-service/internal/core/domain/entity"
+	"time"
+
+	"github.com/labstack/gommon/log"
+)
+
+type WebhookInboxServiceInterface interface {
+	Receive(ctx context.Context, provider string, body []byte) (uint, error)
+	ProcessDue(ctx context.Context) error
+	GetAll(ctx context.Context, query entity.WebhookInboxQueryEntity) ([]entity.WebhookInboxEntity, int64, int64, error)
+	GetByID(ctx context.Context, webhookID uint) (*entity.WebhookInboxEntity, error)
+	Replay(ctx context.Context, webhookID uint) error
+}
+
+type webhookInboxService struct {
+	repo           repository.WebhookInboxRepositoryInterface
+	paymentService PaymentServiceInterface
+	cfg            *config.Config
+}
+
+// Receive implements WebhookInboxServiceInterface.
+// Signature diperiksa sebelum disimpan agar payload palsu tidak masuk inbox,
+// payload yang valid disimpan apa adanya sehingga kegagalan proses tidak menghilangkan notifikasi gateway.
+func (w *webhookInboxService) Receive(ctx context.Context, provider string, body []byte) (uint, error) {
+	if err := w.paymentService.VerifyWebhook(ctx, provider, body); err != nil {
+		log.Errorf("[WebhookInboxService-1] Receive: %v", err)
+		return 0, err
+	}
+
+	webhookID, err := w.repo.CreateWebhook(ctx, provider, string(body))
+	if err != nil {
+		log.Errorf("[WebhookInboxService-2] Receive: %v", err)
+		return 0, err
+	}
+
+	go w.process(context.Background(), webhookID)
+
+	return webhookID, nil
+}
+
+// ProcessDue implements WebhookInboxServiceInterface.
+func (w *webhookInboxService) ProcessDue(ctx context.Context) error {
+	now := time.Now()
+	webhooks, err := w.repo.ClaimDue(ctx, now, now.Add(-5*time.Minute), 50)
+	if err != nil {
+		log.Errorf("[WebhookInboxService-1] ProcessDue: %v", err)
+		return err
+	}
+
+	for _, webhook := range webhooks {
+		w.handle(ctx, webhook)
+	}
+
+	return nil
+}
+
+// GetAll implements WebhookInboxServiceInterface.
+func (w *webhookInboxService) GetAll(ctx context.Context, query entity.WebhookInboxQueryEntity) ([]entity.WebhookInboxEntity, int64, int64, error) {
+	return w.repo.GetAll(ctx, query)
+}
+
+// GetByID implements WebhookInboxServiceInterface.
+func (w *webhookInboxService) GetByID(ctx context.Context, webhookID uint) (*entity.WebhookInboxEntity, error) {
+	return w.repo.GetByID(ctx, webhookID)
+}
+
+// Replay implements WebhookInboxServiceInterface.
+// Replay aman untuk webhook yang sudah Processed karena event yang sama hanya dicatat sebagai duplikat.
+func (w *webhookInboxService) Replay(ctx context.Context, webhookID uint) error {
+	if _, err := w.repo.GetByID(ctx, webhookID); err != nil {
+		log.Errorf("[WebhookInboxService-1] Replay: %v", err)
+		return err
+	}
+
+	if err := w.repo.Requeue(ctx, webhookID); err != nil {
+		log.Errorf("[WebhookInboxService-2] Replay: %v", err)
+		return err
+	}
+
+	go w.process(context.Background(), webhookID)
+
+	return nil
+}
+
+func (w *webhookInboxService) process(ctx context.Context, webhookID uint) {
+	webhook, err := w.repo.Claim(ctx, webhookID)
+	if err != nil {
+		// Webhook sudah diambil worker retry, tidak perlu diproses dua kali
+		return
+	}
+
+	w.handle(ctx, *webhook)
+}
+
+func (w *webhookInboxService) handle(ctx context.Context, webhook entity.WebhookInboxEntity) {
+	err := w.paymentService.ProcessWebhook(ctx, webhook.Provider, []byte(webhook.Payload))
+	if err == nil {
+		if err := w.repo.MarkProcessed(ctx, webhook.ID); err != nil {
+			log.Errorf("[WebhookInboxService-1] handle: %v", err)
+		}
+		return
+	}
+
+	log.Errorf("[WebhookInboxService-2] handle: webhook %d attempt %d: %v", webhook.ID, webhook.Attempts, err)
+
+	// Payload rusak atau signature salah tidak akan berhasil walau diulang
+	status := "Pending"
+	if err.Error() == "400" || err.Error() == "401" {
+		status = "Rejected"
+	}
+
+	maxAttempts := w.cfg.App.WebhookMaxAttempts
+	if maxAttempts <= 0 {
+		maxAttempts = 5
+	}
+	if status == "Pending" && webhook.Attempts >= maxAttempts {
+		status = "Failed"
+	}
+
+	backoff := 30 * time.Second << uint(webhook.Attempts-1)
+	if backoff > time.Hour || backoff <= 0 {
+		backoff = time.Hour
+	}
+
+	if err := w.repo.MarkFailed(ctx, webhook.ID, status, err.Error(), time.Now().Add(backoff)); err != nil {
+		log.Errorf("[WebhookInboxService-3] handle: %v", err)
+	}
+}
+
+func NewWebhookInboxService(repo repository.WebhookInboxRepositoryInterface, paymentService PaymentServiceInterface, cfg *config.Config) WebhookInboxServiceInterface {
+	return &webhookInboxService{
+		repo:           repo,
+		paymentService: paymentService,
+		cfg:            cfg,
+	}
+}
@@ -10,6 +10,9 @@ type App struct {
 	JwtIssuer    string `json:"jwt_issuer"`
 
 	UrlForgotPassword string `json:"url_forgot_password"`
+
+	ServerTimeOut   int    `json:"server_timeout"`
+	OrderServiceUrl string `json:"order_service_url"`
 }
 
 type PsqlDB struct {
@@ -35,6 +38,21 @@ type Supabase struct {
 	Bucket string `json:"bucket"`
 }
 
+type LocalStorage struct {
+	Path       string `json:"path"`
+	BaseURL    string `json:"base_url"`
+	SigningKey string `json:"signing_key"`
+}
+
+type S3Storage struct {
+	Endpoint  string `json:"endpoint"`
+	Region    string `json:"region"`
+	AccessKey string `json:"access_key"`
+	SecretKey string `json:"secret_key"`
+	Bucket    string `json:"bucket"`
+	PublicURL string `json:"public_url"`
+}
+
 type Redis struct {
 	Host string `json:"host"`
 	Port string `json:"port"`
@@ -49,13 +67,18 @@ type PublisherName struct {
 	ProductPublish     string `json:"product_publish"`
 	ProductDelete      string `json:"product_delete"`
 	ProductToOrder     string `json:"product_to_order"`
+	ProductStockFailed string `json:"product_stock_failed"`
+	ProductStockAlert  string `json:"product_stock_alert"`
 }
 
 type Config struct {
 	App           App           `json:"app"`
 	Psql          PsqlDB        `json:"psql"`
 	RabbitMQ      RabbitMQ      `json:"rabbitmq"`
+	StorageDriver string        `json:"storage_driver"`
 	Storage       Supabase      `json:"storage"`
+	LocalStorage  LocalStorage  `json:"local_storage"`
+	S3Storage     S3Storage     `json:"s3_storage"`
 	Redis         Redis         `json:"redis"`
 	ElasticSearch ElasticSearch `json:"elasticsearch"`
 	PublisherName PublisherName `json:"publisher_name"`
@@ -71,6 +94,9 @@ func NewConfig() *Config {
 			JwtIssuer:    viper.GetString("JWT_ISSUER"),
 
 			UrlForgotPassword: viper.GetString("URL_FORGOT_PASSWORD"),
+
+			ServerTimeOut:   viper.GetInt("SERVER_TIMEOUT"),
+			OrderServiceUrl: viper.GetString("ORDER_SERVICE_URL"),
 		},
 		Psql: PsqlDB{
 			Host:      viper.GetString("DATABASE_HOST"),
@@ -92,6 +118,20 @@ func NewConfig() *Config {
 			Key:    viper.GetString("SUPABASE_STORAGE_KEY"),
 			Bucket: viper.GetString("SUPABASE_STORAGE_BUCKET"),
 		},
+		StorageDriver: viper.GetString("STORAGE_DRIVER"),
+		LocalStorage: LocalStorage{
+			Path:       viper.GetString("LOCAL_STORAGE_PATH"),
+			BaseURL:    viper.GetString("LOCAL_STORAGE_URL"),
+			SigningKey: viper.GetString("LOCAL_STORAGE_SIGNING_KEY"),
+		},
+		S3Storage: S3Storage{
+			Endpoint:  viper.GetString("S3_ENDPOINT"),
+			Region:    viper.GetString("S3_REGION"),
+			AccessKey: viper.GetString("S3_ACCESS_KEY"),
+			SecretKey: viper.GetString("S3_SECRET_KEY"),
+			Bucket:    viper.GetString("S3_BUCKET"),
+			PublicURL: viper.GetString("S3_PUBLIC_URL"),
+		},
 		Redis: Redis{
 			Host: viper.GetString("REDIS_HOST"),
 			Port: viper.GetString("REDIS_PORT"),
@@ -104,6 +144,8 @@ func NewConfig() *Config {
 			ProductPublish:     viper.GetString("PRODUCT_PUBLISH_NAME"),
 			ProductDelete:      viper.GetString("PRODUCT_DELETE"),
 			ProductToOrder:     viper.GetString("PRODUCT_TO_ORDER"),
+			ProductStockFailed: viper.GetString("PRODUCT_STOCK_FAILED"),
+			ProductStockAlert:  viper.GetString("PRODUCT_STOCK_ALERT"),
 		},
 	}
 }
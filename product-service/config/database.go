@@ -21,13 +21,13 @@ func (cfg Config) ConnectionPostgres() (*Postgres, error) {
 		cfg.Psql.Port,
 		cfg.Psql.DBName)
 
-	db, err := gorm.Open(postgres.Open(dbConnString), &gorm.Config{})
+	db, err := gorm.Open(postgres.Open(dbConnString), &gorm.Config{TranslateError: true})
 	if err != nil {
 		log.Error().Err(err).Msg("[ConnectionPostgres-1] Failed to connect to database " + cfg.Psql.Host)
 		return nil, err
 	}
 
-	db.AutoMigrate(&model.Category{}, &model.Product{})
+	db.AutoMigrate(&model.Category{}, &model.Product{}, &model.StockMovement{}, &model.ProductOption{}, &model.ProductOptionValue{}, &model.ProductVariantValue{}, &model.ProductImage{}, &model.ProductImportJob{}, &model.ProductReview{}, &model.Wishlist{})
 
 	sqlDB, err := db.DB()
 	if err != nil {
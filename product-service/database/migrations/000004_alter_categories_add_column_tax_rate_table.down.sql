@@ -0,0 +1 @@
+ALTER TABLE categories DROP COLUMN IF EXISTS tax_rate;
\ No newline at end of file
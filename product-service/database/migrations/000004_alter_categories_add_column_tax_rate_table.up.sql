@@ -0,0 +1 @@
+ALTER TABLE categories ADD COLUMN tax_rate DECIMAL(5, 2) DEFAULT 0;
\ No newline at end of file
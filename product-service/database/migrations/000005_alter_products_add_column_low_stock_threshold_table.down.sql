@@ -0,0 +1 @@
+ALTER TABLE products DROP COLUMN IF EXISTS low_stock_threshold;
\ No newline at end of file
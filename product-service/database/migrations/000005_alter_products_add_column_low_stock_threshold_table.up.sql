@@ -0,0 +1 @@
+ALTER TABLE products ADD COLUMN low_stock_threshold INT DEFAULT 5;
\ No newline at end of file
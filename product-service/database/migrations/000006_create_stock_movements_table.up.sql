@@ -0,0 +1,17 @@
+CREATE TABLE IF NOT EXISTS stock_movements (
+    id SERIAL PRIMARY KEY,
+    product_id BIGINT NOT NULL,
+    type VARCHAR(30) NOT NULL,
+    quantity INT NOT NULL,
+    stock_after INT NOT NULL,
+    actor_type VARCHAR(20) NOT NULL,
+    actor_id BIGINT NULL,
+    reference VARCHAR(100) NULL,
+    note TEXT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_stock_movements_product_id ON stock_movements(product_id);
+CREATE INDEX idx_stock_movements_reference ON stock_movements(reference);
+CREATE INDEX idx_stock_movements_created_at ON stock_movements(created_at);
+CREATE UNIQUE INDEX idx_stock_movements_system_reference ON stock_movements(product_id, type, reference) WHERE actor_type = 'system';
\ No newline at end of file
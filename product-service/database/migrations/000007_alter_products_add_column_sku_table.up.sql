@@ -0,0 +1,3 @@
+ALTER TABLE products ADD COLUMN sku VARCHAR(64) NULL;
+
+CREATE UNIQUE INDEX idx_products_sku ON products(sku) WHERE sku <> '' AND deleted_at IS NULL;
\ No newline at end of file
@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS "product_variant_values";
+DROP TABLE IF EXISTS "product_option_values";
+DROP TABLE IF EXISTS "product_options";
\ No newline at end of file
@@ -0,0 +1,25 @@
+CREATE TABLE IF NOT EXISTS product_options (
+    id SERIAL PRIMARY KEY,
+    product_id BIGINT NOT NULL REFERENCES products(id),
+    name VARCHAR(50) NOT NULL,
+    position INT NOT NULL DEFAULT 0
+);
+
+CREATE INDEX idx_product_options_product_id ON product_options(product_id);
+
+CREATE TABLE IF NOT EXISTS product_option_values (
+    id SERIAL PRIMARY KEY,
+    option_id BIGINT NOT NULL REFERENCES product_options(id) ON DELETE CASCADE,
+    value VARCHAR(50) NOT NULL,
+    position INT NOT NULL DEFAULT 0
+);
+
+CREATE INDEX idx_product_option_values_option_id ON product_option_values(option_id);
+
+CREATE TABLE IF NOT EXISTS product_variant_values (
+    id SERIAL PRIMARY KEY,
+    product_id BIGINT NOT NULL,
+    option_value_id BIGINT NOT NULL REFERENCES product_option_values(id) ON DELETE CASCADE
+);
+
+CREATE UNIQUE INDEX idx_product_variant_values_product_value ON product_variant_values(product_id, option_value_id);
\ No newline at end of file
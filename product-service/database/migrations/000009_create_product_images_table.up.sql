@@ -0,0 +1,13 @@
+CREATE TABLE IF NOT EXISTS product_images (
+    id SERIAL PRIMARY KEY,
+    product_id BIGINT NOT NULL REFERENCES products(id),
+    url TEXT NOT NULL,
+    thumbnail_url TEXT NULL,
+    medium_url TEXT NULL,
+    alt_text VARCHAR(255) NULL,
+    position INT NOT NULL DEFAULT 0,
+    is_primary BOOLEAN NOT NULL DEFAULT FALSE,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_product_images_product_id ON product_images(product_id);
\ No newline at end of file
@@ -0,0 +1,2 @@
+ALTER TABLE products DROP COLUMN IF EXISTS rating_count;
+ALTER TABLE products DROP COLUMN IF EXISTS rating_avg;
\ No newline at end of file
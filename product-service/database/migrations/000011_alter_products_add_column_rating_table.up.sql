@@ -0,0 +1,2 @@
+ALTER TABLE products ADD COLUMN rating_avg DECIMAL(3, 2) NOT NULL DEFAULT 0;
+ALTER TABLE products ADD COLUMN rating_count INT NOT NULL DEFAULT 0;
\ No newline at end of file
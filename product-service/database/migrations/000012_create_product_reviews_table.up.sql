@@ -0,0 +1,20 @@
+CREATE TABLE IF NOT EXISTS product_reviews (
+    id SERIAL PRIMARY KEY,
+    product_id BIGINT NOT NULL REFERENCES products(id),
+    user_id BIGINT NOT NULL,
+    user_name VARCHAR(255) NULL,
+    order_id BIGINT NOT NULL,
+    rating INT NOT NULL,
+    comment TEXT NULL,
+    photos JSONB NOT NULL DEFAULT '[]',
+    status VARCHAR(20) NOT NULL DEFAULT 'PENDING',
+    moderated_by BIGINT NULL,
+    moderated_at TIMESTAMP NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NULL,
+    deleted_at TIMESTAMP NULL
+);
+
+CREATE UNIQUE INDEX idx_product_reviews_product_user ON product_reviews(product_id, user_id) WHERE deleted_at IS NULL;
+CREATE INDEX idx_product_reviews_status ON product_reviews(status);
+CREATE INDEX idx_product_reviews_deleted_at ON product_reviews(deleted_at);
\ No newline at end of file
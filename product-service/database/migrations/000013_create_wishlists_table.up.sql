@@ -0,0 +1,10 @@
+CREATE TABLE IF NOT EXISTS wishlists (
+    id SERIAL PRIMARY KEY,
+    user_id BIGINT NOT NULL,
+    product_id BIGINT NOT NULL REFERENCES products(id),
+    variant_id BIGINT NOT NULL DEFAULT 0,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE UNIQUE INDEX idx_wishlists_user_product_variant ON wishlists(user_id, product_id, variant_id);
+CREATE INDEX idx_wishlists_product_id ON wishlists(product_id);
\ No newline at end of file
@@ -6,6 +6,7 @@ require (
 	github.com/go-playground/locales v0.14.1
 	github.com/go-playground/universal-translator v0.18.1
 	github.com/spf13/viper v1.19.0
+	shared v0.0.0
 )
 
 require (
@@ -53,7 +54,7 @@ require (
 	github.com/spf13/pflag v1.0.6 // indirect
 	github.com/streadway/amqp v1.1.0
 	github.com/subosito/gotenv v1.6.0 // indirect
-	github.com/supabase-community/storage-go v0.7.0
+	github.com/supabase-community/storage-go v0.7.0 // indirect
 	go.uber.org/atomic v1.9.0 // indirect
 	go.uber.org/multierr v1.9.0 // indirect
 	golang.org/x/exp v0.0.0-20230905200255-921286631fa9 // indirect
@@ -64,3 +65,6 @@ require (
 	gorm.io/driver/postgres v1.5.11
 	gorm.io/gorm v1.25.12
 )
+
+// Paket bersama antar service, lihat folder shared di root repository
+replace shared => ../shared
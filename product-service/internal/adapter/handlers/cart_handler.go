@@ -104,14 +104,26 @@ func (ch *CartHandler) AddToCart(c echo.Context) error {
 
 	userID := jwtUserData.UserID
 
+	// Varian adalah baris produk child, pastikan varian memang milik produk yang dipilih
+	if request.VariantID != 0 && request.VariantID != request.ProductID {
+		variant, err := ch.ProductService.GetByID(ctx, request.VariantID)
+		if err != nil || variant.ParentID == nil || *variant.ParentID != request.ProductID {
+			log.Errorf("[CartHandler-5] AddToCart: variant %d does not belong to product %d", request.VariantID, request.ProductID)
+			resp.Message = "Variant does not belong to product"
+			resp.Data = nil
+			return c.JSON(http.StatusBadRequest, resp)
+		}
+	}
+
 	reqEntity := entity.CartItem{
 		ProductID: request.ProductID,
+		VariantID: request.VariantID,
 		Quantity:  request.Quantity,
 	}
 
 	err = ch.CartService.AddToCart(ctx, userID, reqEntity)
 	if err != nil {
-		log.Errorf("[CartHandler-5] AddToCart: %v", err)
+		log.Errorf("[CartHandler-6] AddToCart: %v", err)
 		resp.Message = err.Error()
 		resp.Data = nil
 		return c.JSON(http.StatusInternalServerError, resp)
@@ -153,7 +165,12 @@ func (ch *CartHandler) GetCart(c echo.Context) error {
 		return c.JSON(http.StatusInternalServerError, resp)
 	}
 	for _, item := range items {
-		product, err := ch.ProductService.GetByID(ctx, item.ProductID)
+		rowID := item.ProductID
+		if item.VariantID != 0 {
+			rowID = item.VariantID
+		}
+
+		product, err := ch.ProductService.GetByID(ctx, rowID)
 		if err != nil {
 			log.Errorf("[CartHandler-4] GetCart: %v", err)
 			resp.Message = err.Error()
@@ -163,6 +180,9 @@ func (ch *CartHandler) GetCart(c echo.Context) error {
 
 		respList = append(respList, response.CartResponse{
 			ID:            item.ProductID,
+			VariantID:     item.VariantID,
+			SKU:           product.SKU,
+			OptionValues:  product.OptionValues,
 			ProductName:   product.Name,
 			ProductImage:  product.Image,
 			ProductStatus: product.Status,
@@ -211,7 +231,12 @@ func (ch *CartHandler) RemoveFromCart(c echo.Context) error {
 
 	prodID, err := conv.StringToInt64(productID)
 
-	err = ch.CartService.RemoveFromCart(ctx, userID, prodID)
+	var variantID int64
+	if c.QueryParam("variant_id") != "" {
+		variantID, _ = conv.StringToInt64(c.QueryParam("variant_id"))
+	}
+
+	err = ch.CartService.RemoveFromCart(ctx, userID, prodID, variantID)
 	if err != nil {
 		log.Errorf("[CartHandler-4] RemoveFromCart: %v", err)
 		resp.Message = err.Error()
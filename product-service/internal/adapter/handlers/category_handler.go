@@ -123,6 +123,7 @@ func (ch *categoryHandler) Create(c echo.Context) error {
 		Description: request.Description,
 		Status:      request.Status,
 		ParentID:    request.ParentID,
+		TaxRate:     request.TaxRate,
 	}
 
 	err := ch.categoryService.CreateCategory(ctx, reqEntity)
@@ -221,6 +222,7 @@ func (ch *categoryHandler) GetByIDAdmin(c echo.Context) error {
 		Icon:        result.Icon,
 		Status:      result.Status,
 		Description: result.Description,
+		TaxRate:     result.TaxRate,
 	}
 
 	resp.Message = "success"
@@ -264,6 +266,7 @@ func (ch *categoryHandler) GetBySlugAdmin(c echo.Context) error {
 		Icon:        result.Icon,
 		Status:      result.Status,
 		Description: result.Description,
+		TaxRate:     result.TaxRate,
 	}
 
 	resp.Message = "success"
@@ -316,6 +319,7 @@ func (ch *categoryHandler) Update(c echo.Context) error {
 		Description: request.Description,
 		Status:      request.Status,
 		ParentID:    request.ParentID,
+		TaxRate:     request.TaxRate,
 	}
 
 	err = ch.categoryService.EditCategory(ctx, reqEntity)
@@ -399,6 +403,7 @@ func (ch *categoryHandler) GetAllAdmin(c echo.Context) error {
 			Icon:         result.Icon,
 			Slug:         result.Slug,
 			Status:       result.Status,
+			TaxRate:      result.TaxRate,
 			TotalProduct: len(result.Products),
 		})
 	}
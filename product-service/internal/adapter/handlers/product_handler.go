@@ -1,6 +1,8 @@
 package handlers
 
 import (
+	"encoding/json"
+	"fmt"
 	"net/http"
 	"product-service/config"
 	"product-service/internal/adapter"
@@ -9,6 +11,8 @@ import (
 	"product-service/internal/core/domain/entity"
 	"product-service/internal/core/service"
 	"product-service/utils/conv"
+	"slices"
+	"strconv"
 	"strings"
 
 	"github.com/labstack/echo/v4"
@@ -22,6 +26,7 @@ type ProductHandlerInterface interface {
 	CreateAdmin(c echo.Context) error
 	EditAdmin(c echo.Context) error
 	DeleteAdmin(c echo.Context) error
+	GetLowStockAdmin(c echo.Context) error
 
 	GetAllHome(c echo.Context) error
 	GetAllShop(c echo.Context) error
@@ -71,6 +76,10 @@ func (p *productHandler) GetDetailHome(c echo.Context) error {
 	}
 
 	respDetail.ID = result.ID
+	respDetail.ParentID = conv.Int64PointerToInt64(result.ParentID)
+	respDetail.SKU = result.SKU
+	respDetail.Options = productOptionsToResponse(result.Options)
+	respDetail.OptionValues = result.OptionValues
 	respDetail.ProductName = result.Name
 	respDetail.CategoryName = result.CategoryName
 	respDetail.Description = result.Description
@@ -80,10 +89,16 @@ func (p *productHandler) GetDetailHome(c echo.Context) error {
 	respDetail.RegulerPrice = int64(result.RegulerPrice)
 	respDetail.SalePrice = int64(result.SalePrice)
 	respDetail.ProductImage = result.Image
+	respDetail.Images = productImagesToResponse(result.Images, result.Image)
+	respDetail.TaxRate = result.TaxRate
+	respDetail.RatingAvg = result.RatingAvg
+	respDetail.RatingCount = result.RatingCount
 
 	for _, child := range result.Child {
 		respDetail.Child = append(respDetail.Child, response.ProductChildHomeResponse{
 			ID:           child.ID,
+			SKU:          child.SKU,
+			OptionValues: child.OptionValues,
 			Weight:       child.Weight,
 			Stock:        child.Stock,
 			RegulerPrice: int64(child.RegulerPrice),
@@ -121,6 +136,11 @@ func (p *productHandler) GetAllShop(c echo.Context) error {
 			orderBy = "id"
 			orderType = "desc"
 		}
+
+		if c.QueryParam("orderBy") == "rating" {
+			orderBy = "rating_avg"
+			orderType = "desc"
+		}
 	}
 
 	var page int64 = 1
@@ -140,6 +160,12 @@ func (p *productHandler) GetAllShop(c echo.Context) error {
 		endPrice, _ = conv.StringToInt64(price[1])
 	}
 
+	// rating berisi rating minimal, misalnya rating=4 untuk produk bintang 4 ke atas
+	var minRating float64 = 0
+	if c.QueryParam("rating") != "" {
+		minRating, _ = strconv.ParseFloat(c.QueryParam("rating"), 64)
+	}
+
 	reqEntity := entity.QueryStringProduct{
 		CategorySlug: c.QueryParam("category"),
 		OrderBy:      orderBy,
@@ -148,6 +174,7 @@ func (p *productHandler) GetAllShop(c echo.Context) error {
 		Limit:        int(perPage),
 		StartPrice:   startPrice,
 		EndPrice:     endPrice,
+		MinRating:    minRating,
 	}
 
 	if c.QueryParam("search") != "" {
@@ -176,6 +203,8 @@ func (p *productHandler) GetAllShop(c echo.Context) error {
 			SalePrice:    int64(result.SalePrice),
 			RegulerPrice: int64(result.RegulerPrice),
 			CategoryName: result.CategoryName,
+			RatingAvg:    result.RatingAvg,
+			RatingCount:  result.RatingCount,
 		})
 	}
 
@@ -232,6 +261,8 @@ func (p *productHandler) GetAllHome(c echo.Context) error {
 			SalePrice:    int64(result.SalePrice),
 			RegulerPrice: int64(result.RegulerPrice),
 			CategoryName: result.CategoryName,
+			RatingAvg:    result.RatingAvg,
+			RatingCount:  result.RatingCount,
 		})
 	}
 
@@ -293,9 +324,10 @@ func (p *productHandler) DeleteAdmin(c echo.Context) error {
 // GetAllAdmin implements ProductHandlerInterface.
 func (p *productHandler) EditAdmin(c echo.Context) error {
 	var (
-		resp = response.DefaultResponse{}
-		ctx  = c.Request().Context()
-		req  = request.ProductRequest{}
+		resp        = response.DefaultResponse{}
+		ctx         = c.Request().Context()
+		req         = request.ProductRequest{}
+		jwtUserData = entity.JwtUserData{}
 	)
 
 	user := c.Get("user").(string)
@@ -306,6 +338,13 @@ func (p *productHandler) EditAdmin(c echo.Context) error {
 		return c.JSON(http.StatusNotFound, resp)
 	}
 
+	if err := json.Unmarshal([]byte(user), &jwtUserData); err != nil {
+		log.Errorf("[ProductHandler-1] EditAdmin: %v", err)
+		resp.Message = err.Error()
+		resp.Data = nil
+		return c.JSON(http.StatusBadRequest, resp)
+	}
+
 	idStr := c.Param("id")
 	if idStr == "" {
 		log.Errorf("[ProductHandler-2] EditAdmin: %v", "Invalid id")
@@ -336,31 +375,47 @@ func (p *productHandler) EditAdmin(c echo.Context) error {
 		return c.JSON(http.StatusBadRequest, resp)
 	}
 
+	if err := validateVariantOptions(req); err != nil {
+		log.Errorf("[ProductHandler-5] EditAdmin: %v", err)
+		resp.Message = err.Error()
+		resp.Data = nil
+		return c.JSON(http.StatusBadRequest, resp)
+	}
+
 	reqEntity := entity.ProductEntity{
-		ID:           id,
-		CategorySlug: req.CategorySlug,
-		ParentID:     nil,
-		Name:         req.ProductName,
-		Image:        req.VariantDetail[0].ProductImage,
-		Description:  req.ProductDescription,
-		RegulerPrice: float64(req.VariantDetail[0].RegulerPrice),
-		SalePrice:    float64(req.VariantDetail[0].SalePrice),
-		Unit:         req.Unit,
-		Weight:       req.VariantDetail[0].Weight,
-		Stock:        req.VariantDetail[0].Stock,
-		Variant:      req.Variant,
-		Status:       req.Status,
+		ID:                id,
+		CategorySlug:      req.CategorySlug,
+		ParentID:          nil,
+		Name:              req.ProductName,
+		Image:             req.VariantDetail[0].ProductImage,
+		Description:       req.ProductDescription,
+		RegulerPrice:      float64(req.VariantDetail[0].RegulerPrice),
+		SalePrice:         float64(req.VariantDetail[0].SalePrice),
+		Unit:              req.Unit,
+		Weight:            req.VariantDetail[0].Weight,
+		Stock:             req.VariantDetail[0].Stock,
+		LowStockThreshold: req.VariantDetail[0].LowStockThreshold,
+		SKU:               req.VariantDetail[0].SKU,
+		OptionValues:      req.VariantDetail[0].OptionValues,
+		Options:           productOptionsFromRequest(req.Options),
+		Variant:           req.Variant,
+		Status:            req.Status,
+		UpdatedBy:         jwtUserData.UserID,
 	}
 
 	productChilds := []entity.ProductEntity{}
 	if len(req.VariantDetail) > 1 {
 		for i := 1; i < len(req.VariantDetail); i++ {
 			productChilds = append(productChilds, entity.ProductEntity{
-				Image:        req.VariantDetail[i].ProductImage,
-				RegulerPrice: float64(req.VariantDetail[i].RegulerPrice),
-				SalePrice:    float64(req.VariantDetail[i].SalePrice),
-				Weight:       req.VariantDetail[i].Weight,
-				Stock:        req.VariantDetail[i].Stock,
+				ID:                req.VariantDetail[i].ID,
+				Image:             req.VariantDetail[i].ProductImage,
+				RegulerPrice:      float64(req.VariantDetail[i].RegulerPrice),
+				SalePrice:         float64(req.VariantDetail[i].SalePrice),
+				Weight:            req.VariantDetail[i].Weight,
+				Stock:             req.VariantDetail[i].Stock,
+				LowStockThreshold: req.VariantDetail[i].LowStockThreshold,
+				SKU:               req.VariantDetail[i].SKU,
+				OptionValues:      req.VariantDetail[i].OptionValues,
 			})
 		}
 
@@ -370,6 +425,11 @@ func (p *productHandler) EditAdmin(c echo.Context) error {
 	err = p.service.Update(ctx, reqEntity)
 	if err != nil {
 		log.Errorf("[ProductHandler-4] EditAdmin: %v", err)
+		if err.Error() == "409" {
+			resp.Message = "SKU already exists"
+			resp.Data = nil
+			return c.JSON(http.StatusConflict, resp)
+		}
 		resp.Message = err.Error()
 		resp.Data = nil
 		return c.JSON(http.StatusInternalServerError, resp)
@@ -383,9 +443,10 @@ func (p *productHandler) EditAdmin(c echo.Context) error {
 // GetAllAdmin implements ProductHandlerInterface.
 func (p *productHandler) CreateAdmin(c echo.Context) error {
 	var (
-		resp = response.DefaultResponse{}
-		ctx  = c.Request().Context()
-		req  = request.ProductRequest{}
+		resp        = response.DefaultResponse{}
+		ctx         = c.Request().Context()
+		req         = request.ProductRequest{}
+		jwtUserData = entity.JwtUserData{}
 	)
 
 	user := c.Get("user").(string)
@@ -396,6 +457,13 @@ func (p *productHandler) CreateAdmin(c echo.Context) error {
 		return c.JSON(http.StatusNotFound, resp)
 	}
 
+	if err := json.Unmarshal([]byte(user), &jwtUserData); err != nil {
+		log.Errorf("[ProductHandler-1] CreateAdmin: %v", err)
+		resp.Message = err.Error()
+		resp.Data = nil
+		return c.JSON(http.StatusBadRequest, resp)
+	}
+
 	if err := c.Bind(&req); err != nil {
 		log.Errorf("[ProductHandler-2] CreateAdmin: %v", err)
 		resp.Message = err.Error()
@@ -410,30 +478,45 @@ func (p *productHandler) CreateAdmin(c echo.Context) error {
 		return c.JSON(http.StatusBadRequest, resp)
 	}
 
+	if err := validateVariantOptions(req); err != nil {
+		log.Errorf("[ProductHandler-3] CreateAdmin: %v", err)
+		resp.Message = err.Error()
+		resp.Data = nil
+		return c.JSON(http.StatusBadRequest, resp)
+	}
+
 	reqEntity := entity.ProductEntity{
-		CategorySlug: req.CategorySlug,
-		ParentID:     nil,
-		Name:         req.ProductName,
-		Image:        req.VariantDetail[0].ProductImage,
-		Description:  req.ProductDescription,
-		RegulerPrice: float64(req.VariantDetail[0].RegulerPrice),
-		SalePrice:    float64(req.VariantDetail[0].SalePrice),
-		Unit:         req.Unit,
-		Weight:       req.VariantDetail[0].Weight,
-		Stock:        req.VariantDetail[0].Stock,
-		Variant:      req.Variant,
-		Status:       req.Status,
+		CategorySlug:      req.CategorySlug,
+		ParentID:          nil,
+		Name:              req.ProductName,
+		Image:             req.VariantDetail[0].ProductImage,
+		Description:       req.ProductDescription,
+		RegulerPrice:      float64(req.VariantDetail[0].RegulerPrice),
+		SalePrice:         float64(req.VariantDetail[0].SalePrice),
+		Unit:              req.Unit,
+		Weight:            req.VariantDetail[0].Weight,
+		Stock:             req.VariantDetail[0].Stock,
+		LowStockThreshold: req.VariantDetail[0].LowStockThreshold,
+		SKU:               req.VariantDetail[0].SKU,
+		OptionValues:      req.VariantDetail[0].OptionValues,
+		Options:           productOptionsFromRequest(req.Options),
+		Variant:           req.Variant,
+		Status:            req.Status,
+		UpdatedBy:         jwtUserData.UserID,
 	}
 
 	productChilds := []entity.ProductEntity{}
 	if len(req.VariantDetail) > 1 {
 		for i := 1; i < len(req.VariantDetail); i++ {
 			productChilds = append(productChilds, entity.ProductEntity{
-				Image:        req.VariantDetail[i].ProductImage,
-				RegulerPrice: float64(req.VariantDetail[i].RegulerPrice),
-				SalePrice:    float64(req.VariantDetail[i].SalePrice),
-				Weight:       req.VariantDetail[i].Weight,
-				Stock:        req.VariantDetail[i].Stock,
+				Image:             req.VariantDetail[i].ProductImage,
+				RegulerPrice:      float64(req.VariantDetail[i].RegulerPrice),
+				SalePrice:         float64(req.VariantDetail[i].SalePrice),
+				Weight:            req.VariantDetail[i].Weight,
+				Stock:             req.VariantDetail[i].Stock,
+				LowStockThreshold: req.VariantDetail[i].LowStockThreshold,
+				SKU:               req.VariantDetail[i].SKU,
+				OptionValues:      req.VariantDetail[i].OptionValues,
 			})
 		}
 
@@ -443,6 +526,11 @@ func (p *productHandler) CreateAdmin(c echo.Context) error {
 	err := p.service.Create(ctx, reqEntity)
 	if err != nil {
 		log.Errorf("[ProductHandler-4] CreateAdmin: %v", err)
+		if err.Error() == "409" {
+			resp.Message = "SKU already exists"
+			resp.Data = nil
+			return c.JSON(http.StatusConflict, resp)
+		}
 		resp.Message = err.Error()
 		resp.Data = nil
 		return c.JSON(http.StatusInternalServerError, resp)
@@ -502,11 +590,14 @@ func (p *productHandler) GetByIDAdmin(c echo.Context) error {
 	if len(result.Child) > 0 {
 		for _, child := range result.Child {
 			responseChilds = append(responseChilds, response.ProductChildResponse{
-				ID:           child.ID,
-				SalePrice:    int64(child.SalePrice),
-				RegulerPrice: int64(child.RegulerPrice),
-				Weight:       child.Weight,
-				Stock:        child.Stock,
+				ID:                child.ID,
+				SKU:               child.SKU,
+				OptionValues:      child.OptionValues,
+				SalePrice:         int64(child.SalePrice),
+				RegulerPrice:      int64(child.RegulerPrice),
+				Weight:            child.Weight,
+				Stock:             child.Stock,
+				LowStockThreshold: child.LowStockThreshold,
 			})
 		}
 	}
@@ -525,6 +616,14 @@ func (p *productHandler) GetByIDAdmin(c echo.Context) error {
 		Unit:               result.Unit,
 		Weight:             result.Weight,
 		Stock:              result.Stock,
+		LowStockThreshold:  result.LowStockThreshold,
+		TaxRate:            result.TaxRate,
+		RatingAvg:          result.RatingAvg,
+		RatingCount:        result.RatingCount,
+		SKU:                result.SKU,
+		Options:            productOptionsToResponse(result.Options),
+		OptionValues:       result.OptionValues,
+		Images:             productImagesToResponse(result.Images, result.Image),
 		CreatedAt:          result.CreatedAt,
 		Child:              responseChilds,
 	}
@@ -535,6 +634,75 @@ func (p *productHandler) GetByIDAdmin(c echo.Context) error {
 
 }
 
+// GetLowStockAdmin implements ProductHandlerInterface.
+func (p *productHandler) GetLowStockAdmin(c echo.Context) error {
+	var (
+		resp         = response.DefaultResponseWithPaginations{}
+		ctx          = c.Request().Context()
+		respProducts = []response.ProductLowStockResponse{}
+	)
+
+	var page int64 = 1
+	if pageStr := c.QueryParam("page"); pageStr != "" {
+		page, _ = conv.StringToInt64(pageStr)
+		if page <= 0 {
+			page = 1
+		}
+	}
+
+	var perPage int64 = 10
+	if perPageStr := c.QueryParam("limit"); perPageStr != "" {
+		perPage, _ = conv.StringToInt64(perPageStr)
+		if perPage <= 0 {
+			perPage = 10
+		}
+	}
+
+	reqEntity := entity.QueryStringProduct{
+		Page:         int(page),
+		Limit:        int(perPage),
+		CategorySlug: c.QueryParam("categorySlug"),
+	}
+
+	results, totalData, totalPage, err := p.service.GetLowStock(ctx, reqEntity)
+	if err != nil {
+		log.Errorf("[ProductHandler-1] GetLowStockAdmin: %v", err)
+		if err.Error() == "404" {
+			resp.Message = "Data not found"
+			resp.Data = nil
+			return c.JSON(http.StatusNotFound, resp)
+		}
+		resp.Message = err.Error()
+		resp.Data = nil
+		return c.JSON(http.StatusInternalServerError, resp)
+	}
+
+	for _, product := range results {
+		respProducts = append(respProducts, response.ProductLowStockResponse{
+			ID:                product.ID,
+			ProductName:       product.Name,
+			ParentID:          conv.Int64PointerToInt64(product.ParentID),
+			ProductImage:      product.Image,
+			CategoryName:      product.CategoryName,
+			Weight:            product.Weight,
+			Unit:              product.Unit,
+			Stock:             product.Stock,
+			LowStockThreshold: product.LowStockThreshold,
+		})
+	}
+
+	resp.Data = respProducts
+	resp.Message = "success"
+	resp.Pagination = &response.Pagination{
+		Page:       page,
+		TotalCount: totalData,
+		TotalPage:  totalPage,
+		PerPage:    perPage,
+	}
+
+	return c.JSON(http.StatusOK, resp)
+}
+
 // GetAllAdmin implements ProductHandlerInterface.
 func (p *productHandler) GetAllAdmin(c echo.Context) error {
 	var (
@@ -586,6 +754,11 @@ func (p *productHandler) GetAllAdmin(c echo.Context) error {
 		status = c.QueryParam("status")
 	}
 
+	minRating, err := strconv.ParseFloat(c.QueryParam("minRating"), 64)
+	if err != nil {
+		minRating = 0
+	}
+
 	reqEntity := entity.QueryStringProduct{
 		Search:       search,
 		OrderBy:      orderBy,
@@ -596,6 +769,7 @@ func (p *productHandler) GetAllAdmin(c echo.Context) error {
 		StartPrice:   startPrice,
 		EndPrice:     endPrice,
 		Status:       status,
+		MinRating:    minRating,
 	}
 
 	results, totalData, totalPage, err := p.service.GetAll(ctx, reqEntity)
@@ -620,6 +794,8 @@ func (p *productHandler) GetAllAdmin(c echo.Context) error {
 			CategoryName:  product.CategoryName,
 			ProductStatus: product.Status,
 			SalePrice:     int64(product.SalePrice),
+			RatingAvg:     product.RatingAvg,
+			RatingCount:   product.RatingCount,
 			CreatedAt:     product.CreatedAt,
 		})
 	}
@@ -636,6 +812,66 @@ func (p *productHandler) GetAllAdmin(c echo.Context) error {
 	return c.JSON(http.StatusOK, resp)
 }
 
+// validateVariantOptions memastikan setiap varian memilih satu nilai untuk setiap opsi, kombinasi nilai dan SKU tidak duplikat.
+func validateVariantOptions(req request.ProductRequest) error {
+	skus := map[string]bool{}
+	combinations := map[string]bool{}
+	for i, variant := range req.VariantDetail {
+		if variant.SKU != "" {
+			if skus[variant.SKU] {
+				return fmt.Errorf("variant_detail[%d]: duplicate sku %s", i, variant.SKU)
+			}
+			skus[variant.SKU] = true
+		}
+
+		if len(variant.OptionValues) != len(req.Options) {
+			return fmt.Errorf("variant_detail[%d]: option_values must have %d values", i, len(req.Options))
+		}
+
+		if len(req.Options) == 0 {
+			continue
+		}
+
+		for j, value := range variant.OptionValues {
+			if !slices.Contains(req.Options[j].Values, value) {
+				return fmt.Errorf("variant_detail[%d]: %s is not a value of option %s", i, value, req.Options[j].Name)
+			}
+		}
+
+		key := strings.Join(variant.OptionValues, "|")
+		if combinations[key] {
+			return fmt.Errorf("variant_detail[%d]: duplicate option combination %s", i, strings.Join(variant.OptionValues, " / "))
+		}
+		combinations[key] = true
+	}
+
+	return nil
+}
+
+func productOptionsFromRequest(options []request.ProductOptionRequest) []entity.ProductOptionEntity {
+	results := []entity.ProductOptionEntity{}
+	for _, option := range options {
+		results = append(results, entity.ProductOptionEntity{
+			Name:   option.Name,
+			Values: option.Values,
+		})
+	}
+
+	return results
+}
+
+func productOptionsToResponse(options []entity.ProductOptionEntity) []response.ProductOptionResponse {
+	results := []response.ProductOptionResponse{}
+	for _, option := range options {
+		results = append(results, response.ProductOptionResponse{
+			Name:   option.Name,
+			Values: option.Values,
+		})
+	}
+
+	return results
+}
+
 func NewProductHandler(e *echo.Echo, cfg *config.Config, productService service.ProductServiceInterface) ProductHandlerInterface {
 	product := &productHandler{service: productService}
 
@@ -649,6 +885,7 @@ func NewProductHandler(e *echo.Echo, cfg *config.Config, productService service.
 	mid := adapter.NewMiddlewareAdapter(cfg)
 	adminGroup := e.Group("/admin", mid.CheckToken())
 	adminGroup.GET("/products", product.GetAllAdmin)
+	adminGroup.GET("/products/low-stock", product.GetLowStockAdmin)
 	adminGroup.POST("/products", product.CreateAdmin)
 	adminGroup.GET("/products/:id", product.GetByIDAdmin)
 	adminGroup.PUT("/products/:id", product.EditAdmin)
@@ -0,0 +1,269 @@
+package handlers
+
+import (
+	"net/http"
+	"product-service/config"
+	"product-service/internal/adapter"
+	"product-service/internal/adapter/handlers/request"
+	"product-service/internal/adapter/handlers/response"
+	"product-service/internal/core/domain/entity"
+	"product-service/internal/core/service"
+	"product-service/utils/conv"
+
+	"github.com/labstack/echo/v4"
+	"github.com/labstack/gommon/log"
+)
+
+type ProductImageHandlerInterface interface {
+	GetByProductIDAdmin(c echo.Context) error
+	AttachAdmin(c echo.Context) error
+	ReorderAdmin(c echo.Context) error
+	SetPrimaryAdmin(c echo.Context) error
+	RemoveAdmin(c echo.Context) error
+}
+
+type productImageHandler struct {
+	service service.ProductImageServiceInterface
+}
+
+// GetByProductIDAdmin implements ProductImageHandlerInterface.
+func (p *productImageHandler) GetByProductIDAdmin(c echo.Context) error {
+	var (
+		resp = response.DefaultResponse{}
+		ctx  = c.Request().Context()
+	)
+
+	id, err := conv.StringToInt64(c.Param("id"))
+	if err != nil {
+		log.Errorf("[ProductImageHandler-1] GetByProductIDAdmin: %v", err)
+		resp.Message = "ID is required"
+		resp.Data = nil
+		return c.JSON(http.StatusBadRequest, resp)
+	}
+
+	results, err := p.service.GetByProductID(ctx, id)
+	if err != nil {
+		log.Errorf("[ProductImageHandler-2] GetByProductIDAdmin: %v", err)
+		return productImageErrorResponse(c, err)
+	}
+
+	resp.Message = "success"
+	resp.Data = productImagesToResponse(results, "")
+	return c.JSON(http.StatusOK, resp)
+}
+
+// AttachAdmin implements ProductImageHandlerInterface.
+// URL gambar didapat dari endpoint /admin/image-upload.
+func (p *productImageHandler) AttachAdmin(c echo.Context) error {
+	var (
+		resp = response.DefaultResponse{}
+		ctx  = c.Request().Context()
+		req  = request.ProductImageRequest{}
+	)
+
+	id, err := conv.StringToInt64(c.Param("id"))
+	if err != nil {
+		log.Errorf("[ProductImageHandler-1] AttachAdmin: %v", err)
+		resp.Message = "ID is required"
+		resp.Data = nil
+		return c.JSON(http.StatusBadRequest, resp)
+	}
+
+	if err := c.Bind(&req); err != nil {
+		log.Errorf("[ProductImageHandler-2] AttachAdmin: %v", err)
+		resp.Message = err.Error()
+		resp.Data = nil
+		return c.JSON(http.StatusBadRequest, resp)
+	}
+
+	if err := c.Validate(req); err != nil {
+		log.Errorf("[ProductImageHandler-3] AttachAdmin: %v", err)
+		resp.Message = err.Error()
+		resp.Data = nil
+		return c.JSON(http.StatusBadRequest, resp)
+	}
+
+	result, err := p.service.Attach(ctx, entity.ProductImageEntity{
+		ProductID:    id,
+		URL:          req.ImageURL,
+		ThumbnailURL: req.ThumbnailURL,
+		MediumURL:    req.MediumURL,
+		AltText:      req.AltText,
+		IsPrimary:    req.IsPrimary,
+	})
+	if err != nil {
+		log.Errorf("[ProductImageHandler-4] AttachAdmin: %v", err)
+		return productImageErrorResponse(c, err)
+	}
+
+	resp.Message = "success"
+	resp.Data = productImageToResponse(*result)
+	return c.JSON(http.StatusCreated, resp)
+}
+
+// ReorderAdmin implements ProductImageHandlerInterface.
+func (p *productImageHandler) ReorderAdmin(c echo.Context) error {
+	var (
+		resp = response.DefaultResponse{}
+		ctx  = c.Request().Context()
+		req  = request.ProductImageReorderRequest{}
+	)
+
+	id, err := conv.StringToInt64(c.Param("id"))
+	if err != nil {
+		log.Errorf("[ProductImageHandler-1] ReorderAdmin: %v", err)
+		resp.Message = "ID is required"
+		resp.Data = nil
+		return c.JSON(http.StatusBadRequest, resp)
+	}
+
+	if err := c.Bind(&req); err != nil {
+		log.Errorf("[ProductImageHandler-2] ReorderAdmin: %v", err)
+		resp.Message = err.Error()
+		resp.Data = nil
+		return c.JSON(http.StatusBadRequest, resp)
+	}
+
+	if err := c.Validate(req); err != nil {
+		log.Errorf("[ProductImageHandler-3] ReorderAdmin: %v", err)
+		resp.Message = err.Error()
+		resp.Data = nil
+		return c.JSON(http.StatusBadRequest, resp)
+	}
+
+	if err := p.service.Reorder(ctx, id, req.ImageIDs); err != nil {
+		log.Errorf("[ProductImageHandler-4] ReorderAdmin: %v", err)
+		return productImageErrorResponse(c, err)
+	}
+
+	resp.Message = "success"
+	resp.Data = nil
+	return c.JSON(http.StatusOK, resp)
+}
+
+// SetPrimaryAdmin implements ProductImageHandlerInterface.
+func (p *productImageHandler) SetPrimaryAdmin(c echo.Context) error {
+	var (
+		resp = response.DefaultResponse{}
+		ctx  = c.Request().Context()
+	)
+
+	id, err := conv.StringToInt64(c.Param("id"))
+	if err != nil {
+		log.Errorf("[ProductImageHandler-1] SetPrimaryAdmin: %v", err)
+		resp.Message = "ID is required"
+		resp.Data = nil
+		return c.JSON(http.StatusBadRequest, resp)
+	}
+
+	imageID, err := conv.StringToInt64(c.Param("imageId"))
+	if err != nil {
+		log.Errorf("[ProductImageHandler-2] SetPrimaryAdmin: %v", err)
+		resp.Message = "Image ID is required"
+		resp.Data = nil
+		return c.JSON(http.StatusBadRequest, resp)
+	}
+
+	if err := p.service.SetPrimary(ctx, id, imageID); err != nil {
+		log.Errorf("[ProductImageHandler-3] SetPrimaryAdmin: %v", err)
+		return productImageErrorResponse(c, err)
+	}
+
+	resp.Message = "success"
+	resp.Data = nil
+	return c.JSON(http.StatusOK, resp)
+}
+
+// RemoveAdmin implements ProductImageHandlerInterface.
+func (p *productImageHandler) RemoveAdmin(c echo.Context) error {
+	var (
+		resp = response.DefaultResponse{}
+		ctx  = c.Request().Context()
+	)
+
+	id, err := conv.StringToInt64(c.Param("id"))
+	if err != nil {
+		log.Errorf("[ProductImageHandler-1] RemoveAdmin: %v", err)
+		resp.Message = "ID is required"
+		resp.Data = nil
+		return c.JSON(http.StatusBadRequest, resp)
+	}
+
+	imageID, err := conv.StringToInt64(c.Param("imageId"))
+	if err != nil {
+		log.Errorf("[ProductImageHandler-2] RemoveAdmin: %v", err)
+		resp.Message = "Image ID is required"
+		resp.Data = nil
+		return c.JSON(http.StatusBadRequest, resp)
+	}
+
+	if err := p.service.Remove(ctx, id, imageID); err != nil {
+		log.Errorf("[ProductImageHandler-3] RemoveAdmin: %v", err)
+		return productImageErrorResponse(c, err)
+	}
+
+	resp.Message = "success"
+	resp.Data = nil
+	return c.JSON(http.StatusOK, resp)
+}
+
+func productImageErrorResponse(c echo.Context, err error) error {
+	resp := response.DefaultResponse{}
+	switch err.Error() {
+	case "404":
+		resp.Message = "Data not found"
+		return c.JSON(http.StatusNotFound, resp)
+	case "409":
+		resp.Message = "Product must have at least one image"
+		return c.JSON(http.StatusConflict, resp)
+	case "422":
+		resp.Message = "Image IDs must match the product images"
+		return c.JSON(http.StatusUnprocessableEntity, resp)
+	}
+
+	resp.Message = err.Error()
+	return c.JSON(http.StatusInternalServerError, resp)
+}
+
+func productImageToResponse(val entity.ProductImageEntity) response.ProductImageResponse {
+	return response.ProductImageResponse{
+		ID:           val.ID,
+		URL:          val.URL,
+		ThumbnailURL: val.ThumbnailURL,
+		MediumURL:    val.MediumURL,
+		AltText:      val.AltText,
+		Position:     val.Position,
+		IsPrimary:    val.IsPrimary,
+	}
+}
+
+// productImagesToResponse memakai fallbackImage sebagai gambar primary untuk produk lama yang belum punya galeri.
+func productImagesToResponse(images []entity.ProductImageEntity, fallbackImage string) []response.ProductImageResponse {
+	results := []response.ProductImageResponse{}
+	for _, val := range images {
+		results = append(results, productImageToResponse(val))
+	}
+
+	if len(results) == 0 && fallbackImage != "" {
+		results = append(results, response.ProductImageResponse{
+			URL:       fallbackImage,
+			IsPrimary: true,
+		})
+	}
+
+	return results
+}
+
+func NewProductImageHandler(e *echo.Echo, cfg *config.Config, productImageService service.ProductImageServiceInterface) ProductImageHandlerInterface {
+	productImage := &productImageHandler{service: productImageService}
+
+	mid := adapter.NewMiddlewareAdapter(cfg)
+	adminGroup := e.Group("/admin", mid.CheckToken())
+	adminGroup.GET("/products/:id/images", productImage.GetByProductIDAdmin)
+	adminGroup.POST("/products/:id/images", productImage.AttachAdmin)
+	adminGroup.PUT("/products/:id/images/reorder", productImage.ReorderAdmin)
+	adminGroup.PUT("/products/:id/images/:imageId/primary", productImage.SetPrimaryAdmin)
+	adminGroup.DELETE("/products/:id/images/:imageId", productImage.RemoveAdmin)
+
+	return productImage
+}
@@ -0,0 +1,265 @@
+package handlers
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"product-service/config"
+	"product-service/internal/adapter"
+	"product-service/internal/adapter/handlers/response"
+	"product-service/internal/core/domain/entity"
+	"product-service/internal/core/service"
+	"product-service/utils/conv"
+	"product-service/utils/spreadsheet"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/labstack/gommon/log"
+)
+
+const maxImportFileSize = 10 << 20
+
+// productImportColumns dipakai bersama oleh import dan export agar file hasil export bisa langsung di-import ulang.
+// Kolom options berformat "Size: S, M; Color: Red, Green", option_values berformat "S; Red".
+var productImportColumns = []string{
+	"sku", "parent_sku", "product_name", "category_slug", "unit", "product_description", "status", "variant",
+	"options", "option_values", "product_image", "stock", "low_stock_threshold", "weight", "reguler_price", "sale_price",
+}
+
+type ProductImportHandlerInterface interface {
+	ImportAdmin(c echo.Context) error
+	GetImportJobAdmin(c echo.Context) error
+	ExportAdmin(c echo.Context) error
+}
+
+type productImportHandler struct {
+	service service.ProductImportServiceInterface
+}
+
+// ImportAdmin implements ProductImportHandlerInterface.
+// Handler hanya membaca file, validasi baris dan penyimpanan produk dilakukan oleh service.
+func (p *productImportHandler) ImportAdmin(c echo.Context) error {
+	var (
+		resp        = response.DefaultResponse{}
+		ctx         = c.Request().Context()
+		jwtUserData = entity.JwtUserData{}
+	)
+
+	user := c.Get("user").(string)
+	if user == "" {
+		log.Errorf("[ProductImportHandler-1] ImportAdmin: %s", "data token not found")
+		resp.Message = "data token not found"
+		resp.Data = nil
+		return c.JSON(http.StatusNotFound, resp)
+	}
+
+	if err := json.Unmarshal([]byte(user), &jwtUserData); err != nil {
+		log.Errorf("[ProductImportHandler-2] ImportAdmin: %v", err)
+		resp.Message = err.Error()
+		resp.Data = nil
+		return c.JSON(http.StatusBadRequest, resp)
+	}
+
+	file, err := c.FormFile("file")
+	if err != nil {
+		log.Errorf("[ProductImportHandler-3] ImportAdmin: %v", err)
+		resp.Message = err.Error()
+		resp.Data = nil
+		return c.JSON(http.StatusUnprocessableEntity, resp)
+	}
+
+	if file.Size > maxImportFileSize {
+		log.Errorf("[ProductImportHandler-4] ImportAdmin: file size %d exceeds limit", file.Size)
+		resp.Message = "import file must not exceed 10MB"
+		resp.Data = nil
+		return c.JSON(http.StatusRequestEntityTooLarge, resp)
+	}
+
+	src, err := file.Open()
+	if err != nil {
+		log.Errorf("[ProductImportHandler-5] ImportAdmin: %v", err)
+		resp.Message = err.Error()
+		resp.Data = nil
+		return c.JSON(http.StatusBadRequest, resp)
+	}
+	defer src.Close()
+
+	fileBuffer := new(bytes.Buffer)
+	if _, err := io.Copy(fileBuffer, io.LimitReader(src, maxImportFileSize)); err != nil {
+		log.Errorf("[ProductImportHandler-6] ImportAdmin: %v", err)
+		resp.Message = err.Error()
+		resp.Data = nil
+		return c.JSON(http.StatusInternalServerError, resp)
+	}
+
+	rows, err := spreadsheet.Read(fileBuffer.Bytes())
+	if err != nil || len(rows) < 2 {
+		log.Errorf("[ProductImportHandler-7] ImportAdmin: %v", err)
+		resp.Message = "file must be a CSV or XLSX file with a header row and at least one product row"
+		resp.Data = nil
+		return c.JSON(http.StatusUnprocessableEntity, resp)
+	}
+
+	result, err := p.service.StartImport(ctx, entity.ProductImportJobEntity{
+		FileName:  file.Filename,
+		CreatedBy: jwtUserData.UserID,
+	}, rows)
+	if err != nil {
+		log.Errorf("[ProductImportHandler-8] ImportAdmin: %v", err)
+		if err.Error() == "422" {
+			resp.Message = "file must contain the columns sku, product_image, weight and reguler_price"
+			resp.Data = nil
+			return c.JSON(http.StatusUnprocessableEntity, resp)
+		}
+		resp.Message = err.Error()
+		resp.Data = nil
+		return c.JSON(http.StatusInternalServerError, resp)
+	}
+
+	resp.Message = "success"
+	resp.Data = productImportJobToResponse(*result)
+	return c.JSON(http.StatusAccepted, resp)
+}
+
+// GetImportJobAdmin implements ProductImportHandlerInterface.
+func (p *productImportHandler) GetImportJobAdmin(c echo.Context) error {
+	var (
+		resp = response.DefaultResponse{}
+		ctx  = c.Request().Context()
+	)
+
+	id, err := conv.StringToInt64(c.Param("id"))
+	if err != nil {
+		log.Errorf("[ProductImportHandler-1] GetImportJobAdmin: %v", err)
+		resp.Message = "ID is required"
+		resp.Data = nil
+		return c.JSON(http.StatusBadRequest, resp)
+	}
+
+	result, err := p.service.GetJobByID(ctx, id)
+	if err != nil {
+		log.Errorf("[ProductImportHandler-2] GetImportJobAdmin: %v", err)
+		if err.Error() == "404" {
+			resp.Message = "Data not found"
+			resp.Data = nil
+			return c.JSON(http.StatusNotFound, resp)
+		}
+		resp.Message = err.Error()
+		resp.Data = nil
+		return c.JSON(http.StatusInternalServerError, resp)
+	}
+
+	resp.Message = "success"
+	resp.Data = productImportJobToResponse(*result)
+	return c.JSON(http.StatusOK, resp)
+}
+
+// ExportAdmin implements ProductImportHandlerInterface.
+// Query format=xlsx menghasilkan file XLSX, selain itu CSV.
+func (p *productImportHandler) ExportAdmin(c echo.Context) error {
+	var (
+		resp = response.DefaultResponse{}
+		ctx  = c.Request().Context()
+	)
+
+	products, err := p.service.Export(ctx)
+	if err != nil {
+		log.Errorf("[ProductImportHandler-1] ExportAdmin: %v", err)
+		resp.Message = err.Error()
+		resp.Data = nil
+		return c.JSON(http.StatusInternalServerError, resp)
+	}
+
+	rows := [][]string{productImportColumns}
+	for _, product := range products {
+		rows = append(rows, productExportRow(product, ""))
+		for _, child := range product.Child {
+			rows = append(rows, productExportRow(child, product.SKU))
+		}
+	}
+
+	fileName := fmt.Sprintf("products-%s", time.Now().Format("20060102150405"))
+	contentType := "text/csv"
+	buf := new(bytes.Buffer)
+	if c.QueryParam("format") == "xlsx" {
+		fileName += ".xlsx"
+		contentType = "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+		err = spreadsheet.WriteXLSX(buf, "Products", rows)
+	} else {
+		fileName += ".csv"
+		err = spreadsheet.WriteCSV(buf, rows)
+	}
+	if err != nil {
+		log.Errorf("[ProductImportHandler-2] ExportAdmin: %v", err)
+		resp.Message = err.Error()
+		resp.Data = nil
+		return c.JSON(http.StatusInternalServerError, resp)
+	}
+
+	c.Response().Header().Set(echo.HeaderContentDisposition, fmt.Sprintf("attachment; filename=%q", fileName))
+	return c.Blob(http.StatusOK, contentType, buf.Bytes())
+}
+
+func productExportRow(product entity.ProductEntity, parentSKU string) []string {
+	row := []string{
+		product.SKU, parentSKU, "", "", "", "", "", "", "", strings.Join(product.OptionValues, "; "), product.Image,
+		strconv.Itoa(product.Stock), strconv.Itoa(product.LowStockThreshold), strconv.Itoa(product.Weight),
+		strconv.FormatInt(int64(product.RegulerPrice), 10), strconv.FormatInt(int64(product.SalePrice), 10),
+	}
+
+	if parentSKU == "" {
+		options := []string{}
+		for _, option := range product.Options {
+			options = append(options, option.Name+": "+strings.Join(option.Values, ", "))
+		}
+
+		row[2] = product.Name
+		row[3] = product.CategorySlug
+		row[4] = product.Unit
+		row[5] = product.Description
+		row[6] = product.Status
+		row[7] = strconv.Itoa(product.Variant)
+		row[8] = strings.Join(options, "; ")
+	}
+
+	return row
+}
+
+func productImportJobToResponse(val entity.ProductImportJobEntity) response.ProductImportJobResponse {
+	importErrors := []response.ProductImportErrorItem{}
+	for _, item := range val.Errors {
+		importErrors = append(importErrors, response.ProductImportErrorItem{
+			Row:     item.Row,
+			SKU:     item.SKU,
+			Message: item.Message,
+		})
+	}
+
+	return response.ProductImportJobResponse{
+		ID:          val.ID,
+		FileName:    val.FileName,
+		Status:      val.Status,
+		TotalRows:   val.TotalRows,
+		SuccessRows: val.SuccessRows,
+		FailedRows:  val.FailedRows,
+		Errors:      importErrors,
+		CreatedAt:   val.CreatedAt,
+		FinishedAt:  val.FinishedAt,
+	}
+}
+
+func NewProductImportHandler(e *echo.Echo, cfg *config.Config, productImportService service.ProductImportServiceInterface) ProductImportHandlerInterface {
+	productImport := &productImportHandler{service: productImportService}
+
+	mid := adapter.NewMiddlewareAdapter(cfg)
+	adminGroup := e.Group("/admin", mid.CheckToken())
+	adminGroup.POST("/products/import", productImport.ImportAdmin)
+	adminGroup.GET("/products/import/:id", productImport.GetImportJobAdmin)
+	adminGroup.GET("/products/export", productImport.ExportAdmin)
+
+	return productImport
+}
@@ -0,0 +1,345 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"product-service/config"
+	"product-service/internal/adapter"
+	"product-service/internal/adapter/handlers/request"
+	"product-service/internal/adapter/handlers/response"
+	"product-service/internal/core/domain/entity"
+	"product-service/internal/core/service"
+	"product-service/utils/conv"
+
+	"github.com/labstack/echo/v4"
+	"github.com/labstack/gommon/log"
+)
+
+type ProductReviewHandlerInterface interface {
+	GetByProductIDHome(c echo.Context) error
+	Create(c echo.Context) error
+
+	GetAllAdmin(c echo.Context) error
+	ModerateAdmin(c echo.Context) error
+	DeleteAdmin(c echo.Context) error
+}
+
+type productReviewHandler struct {
+	service service.ProductReviewServiceInterface
+}
+
+// GetByProductIDHome implements ProductReviewHandlerInterface.
+func (p *productReviewHandler) GetByProductIDHome(c echo.Context) error {
+	var (
+		resp      = response.DefaultResponseWithPaginations{}
+		ctx       = c.Request().Context()
+		respLists = []response.ProductReviewResponse{}
+	)
+
+	id, err := conv.StringToInt64(c.Param("id"))
+	if err != nil {
+		log.Errorf("[ProductReviewHandler-1] GetByProductIDHome: %v", err)
+		resp.Message = "ID is required"
+		resp.Data = nil
+		return c.JSON(http.StatusBadRequest, resp)
+	}
+
+	query := productReviewQueryFromRequest(c)
+	query.ProductID = id
+	results, totalData, totalPage, err := p.service.GetByProductID(ctx, query)
+	if err != nil {
+		log.Errorf("[ProductReviewHandler-2] GetByProductIDHome: %v", err)
+		return productReviewErrorResponse(c, err)
+	}
+
+	for _, val := range results {
+		respLists = append(respLists, productReviewToResponse(val))
+	}
+
+	resp.Message = "success"
+	resp.Data = respLists
+	resp.Pagination = &response.Pagination{
+		Page:       int64(query.Page),
+		TotalCount: totalData,
+		TotalPage:  totalPage,
+		PerPage:    int64(query.Limit),
+	}
+	return c.JSON(http.StatusOK, resp)
+}
+
+// Create implements ProductReviewHandlerInterface.
+// Foto ulasan diunggah lebih dulu melalui endpoint /auth/image-upload.
+func (p *productReviewHandler) Create(c echo.Context) error {
+	var (
+		resp        = response.DefaultResponse{}
+		ctx         = c.Request().Context()
+		req         = request.ProductReviewRequest{}
+		jwtUserData = entity.JwtUserData{}
+	)
+
+	user := c.Get("user").(string)
+	if user == "" {
+		log.Errorf("[ProductReviewHandler-1] Create: %s", "data token not found")
+		resp.Message = "data token not found"
+		resp.Data = nil
+		return c.JSON(http.StatusNotFound, resp)
+	}
+
+	if err := json.Unmarshal([]byte(user), &jwtUserData); err != nil {
+		log.Errorf("[ProductReviewHandler-2] Create: %v", err)
+		resp.Message = err.Error()
+		resp.Data = nil
+		return c.JSON(http.StatusBadRequest, resp)
+	}
+
+	id, err := conv.StringToInt64(c.Param("id"))
+	if err != nil {
+		log.Errorf("[ProductReviewHandler-3] Create: %v", err)
+		resp.Message = "ID is required"
+		resp.Data = nil
+		return c.JSON(http.StatusBadRequest, resp)
+	}
+
+	if err := c.Bind(&req); err != nil {
+		log.Errorf("[ProductReviewHandler-4] Create: %v", err)
+		resp.Message = err.Error()
+		resp.Data = nil
+		return c.JSON(http.StatusBadRequest, resp)
+	}
+
+	if err := c.Validate(req); err != nil {
+		log.Errorf("[ProductReviewHandler-5] Create: %v", err)
+		resp.Message = err.Error()
+		resp.Data = nil
+		return c.JSON(http.StatusBadRequest, resp)
+	}
+
+	result, err := p.service.Create(ctx, entity.ProductReviewEntity{
+		ProductID: id,
+		UserID:    jwtUserData.UserID,
+		UserName:  jwtUserData.Name,
+		Rating:    req.Rating,
+		Comment:   req.Comment,
+		Photos:    req.Photos,
+	}, jwtUserData.Token)
+	if err != nil {
+		log.Errorf("[ProductReviewHandler-6] Create: %v", err)
+		return productReviewErrorResponse(c, err)
+	}
+
+	resp.Message = "success"
+	resp.Data = productReviewToResponse(*result)
+	return c.JSON(http.StatusCreated, resp)
+}
+
+// GetAllAdmin implements ProductReviewHandlerInterface.
+func (p *productReviewHandler) GetAllAdmin(c echo.Context) error {
+	var (
+		resp      = response.DefaultResponseWithPaginations{}
+		ctx       = c.Request().Context()
+		respLists = []response.ProductReviewAdminResponse{}
+	)
+
+	query := productReviewQueryFromRequest(c)
+	query.Status = c.QueryParam("status")
+	if productIDStr := c.QueryParam("productId"); productIDStr != "" {
+		query.ProductID, _ = conv.StringToInt64(productIDStr)
+	}
+
+	results, totalData, totalPage, err := p.service.GetAllAdmin(ctx, query)
+	if err != nil {
+		log.Errorf("[ProductReviewHandler-1] GetAllAdmin: %v", err)
+		return productReviewErrorResponse(c, err)
+	}
+
+	for _, val := range results {
+		respLists = append(respLists, productReviewToAdminResponse(val))
+	}
+
+	resp.Message = "success"
+	resp.Data = respLists
+	resp.Pagination = &response.Pagination{
+		Page:       int64(query.Page),
+		TotalCount: totalData,
+		TotalPage:  totalPage,
+		PerPage:    int64(query.Limit),
+	}
+	return c.JSON(http.StatusOK, resp)
+}
+
+// ModerateAdmin implements ProductReviewHandlerInterface.
+func (p *productReviewHandler) ModerateAdmin(c echo.Context) error {
+	var (
+		resp        = response.DefaultResponse{}
+		ctx         = c.Request().Context()
+		req         = request.ProductReviewModerateRequest{}
+		jwtUserData = entity.JwtUserData{}
+	)
+
+	user := c.Get("user").(string)
+	if user == "" {
+		log.Errorf("[ProductReviewHandler-1] ModerateAdmin: %s", "data token not found")
+		resp.Message = "data token not found"
+		resp.Data = nil
+		return c.JSON(http.StatusNotFound, resp)
+	}
+
+	if err := json.Unmarshal([]byte(user), &jwtUserData); err != nil {
+		log.Errorf("[ProductReviewHandler-2] ModerateAdmin: %v", err)
+		resp.Message = err.Error()
+		resp.Data = nil
+		return c.JSON(http.StatusBadRequest, resp)
+	}
+
+	id, err := conv.StringToInt64(c.Param("id"))
+	if err != nil {
+		log.Errorf("[ProductReviewHandler-3] ModerateAdmin: %v", err)
+		resp.Message = "ID is required"
+		resp.Data = nil
+		return c.JSON(http.StatusBadRequest, resp)
+	}
+
+	if err := c.Bind(&req); err != nil {
+		log.Errorf("[ProductReviewHandler-4] ModerateAdmin: %v", err)
+		resp.Message = err.Error()
+		resp.Data = nil
+		return c.JSON(http.StatusBadRequest, resp)
+	}
+
+	if err := c.Validate(req); err != nil {
+		log.Errorf("[ProductReviewHandler-5] ModerateAdmin: %v", err)
+		resp.Message = err.Error()
+		resp.Data = nil
+		return c.JSON(http.StatusBadRequest, resp)
+	}
+
+	result, err := p.service.Moderate(ctx, id, req.Status, jwtUserData.UserID)
+	if err != nil {
+		log.Errorf("[ProductReviewHandler-6] ModerateAdmin: %v", err)
+		return productReviewErrorResponse(c, err)
+	}
+
+	resp.Message = "success"
+	resp.Data = productReviewToAdminResponse(*result)
+	return c.JSON(http.StatusOK, resp)
+}
+
+// DeleteAdmin implements ProductReviewHandlerInterface.
+func (p *productReviewHandler) DeleteAdmin(c echo.Context) error {
+	var (
+		resp = response.DefaultResponse{}
+		ctx  = c.Request().Context()
+	)
+
+	id, err := conv.StringToInt64(c.Param("id"))
+	if err != nil {
+		log.Errorf("[ProductReviewHandler-1] DeleteAdmin: %v", err)
+		resp.Message = "ID is required"
+		resp.Data = nil
+		return c.JSON(http.StatusBadRequest, resp)
+	}
+
+	if err := p.service.Delete(ctx, id); err != nil {
+		log.Errorf("[ProductReviewHandler-2] DeleteAdmin: %v", err)
+		return productReviewErrorResponse(c, err)
+	}
+
+	resp.Message = "success"
+	resp.Data = nil
+	return c.JSON(http.StatusOK, resp)
+}
+
+func productReviewQueryFromRequest(c echo.Context) entity.QueryStringProductReview {
+	var page int64 = 1
+	if pageStr := c.QueryParam("page"); pageStr != "" {
+		page, _ = conv.StringToInt64(pageStr)
+		if page <= 0 {
+			page = 1
+		}
+	}
+
+	var perPage int64 = 10
+	if perPageStr := c.QueryParam("limit"); perPageStr != "" {
+		perPage, _ = conv.StringToInt64(perPageStr)
+		if perPage <= 0 {
+			perPage = 10
+		}
+	}
+
+	var rating int64
+	if ratingStr := c.QueryParam("rating"); ratingStr != "" {
+		rating, _ = conv.StringToInt64(ratingStr)
+	}
+
+	return entity.QueryStringProductReview{
+		Rating: int(rating),
+		Page:   int(page),
+		Limit:  int(perPage),
+	}
+}
+
+func productReviewErrorResponse(c echo.Context, err error) error {
+	resp := response.DefaultResponse{}
+	switch err.Error() {
+	case "403":
+		resp.Message = "Only customers with a completed order for this product can review it"
+		return c.JSON(http.StatusForbidden, resp)
+	case "404":
+		resp.Message = "Data not found"
+		return c.JSON(http.StatusNotFound, resp)
+	case "409":
+		resp.Message = "You have already reviewed this product"
+		return c.JSON(http.StatusConflict, resp)
+	}
+
+	resp.Message = err.Error()
+	return c.JSON(http.StatusInternalServerError, resp)
+}
+
+func productReviewToResponse(val entity.ProductReviewEntity) response.ProductReviewResponse {
+	return response.ProductReviewResponse{
+		ID:        val.ID,
+		ProductID: val.ProductID,
+		UserName:  val.UserName,
+		Rating:    val.Rating,
+		Comment:   val.Comment,
+		Photos:    val.Photos,
+		Status:    val.Status,
+		CreatedAt: val.CreatedAt,
+	}
+}
+
+func productReviewToAdminResponse(val entity.ProductReviewEntity) response.ProductReviewAdminResponse {
+	return response.ProductReviewAdminResponse{
+		ID:          val.ID,
+		ProductID:   val.ProductID,
+		ProductName: val.ProductName,
+		UserID:      val.UserID,
+		UserName:    val.UserName,
+		OrderID:     val.OrderID,
+		Rating:      val.Rating,
+		Comment:     val.Comment,
+		Photos:      val.Photos,
+		Status:      val.Status,
+		ModeratedBy: val.ModeratedBy,
+		ModeratedAt: val.ModeratedAt,
+		CreatedAt:   val.CreatedAt,
+	}
+}
+
+func NewProductReviewHandler(e *echo.Echo, cfg *config.Config, productReviewService service.ProductReviewServiceInterface) ProductReviewHandlerInterface {
+	productReview := &productReviewHandler{service: productReviewService}
+
+	e.GET("/products/home/:id/reviews", productReview.GetByProductIDHome)
+
+	mid := adapter.NewMiddlewareAdapter(cfg)
+	authGroup := e.Group("/auth", mid.CheckToken())
+	authGroup.POST("/products/:id/reviews", productReview.Create)
+
+	adminGroup := e.Group("/admin", mid.CheckToken())
+	adminGroup.GET("/reviews", productReview.GetAllAdmin)
+	adminGroup.PUT("/reviews/:id/moderate", productReview.ModerateAdmin)
+	adminGroup.DELETE("/reviews/:id", productReview.DeleteAdmin)
+
+	return productReview
+}
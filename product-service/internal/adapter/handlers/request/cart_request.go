@@ -2,5 +2,6 @@ package request
 
 type CartRequest struct {
 	ProductID int64 `json:"product_id" binding:"required"`
+	VariantID int64 `json:"variant_id"`
 	Quantity  int64 `json:"quantity" binding:"required"`
 }
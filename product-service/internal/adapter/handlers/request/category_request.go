@@ -1,9 +1,10 @@
 package request
 
 type CreateCategoryRequest struct {
-	Name        string `json:"name" validate:"required"`
-	Icon        string `json:"icon" validate:"required"`
-	Description string `json:"description"`
-	Status      string `json:"status" validate:"required"`
-	ParentID    *int64 `json:"parent_id"`
+	Name        string  `json:"name" validate:"required"`
+	Icon        string  `json:"icon" validate:"required"`
+	Description string  `json:"description"`
+	Status      string  `json:"status" validate:"required"`
+	ParentID    *int64  `json:"parent_id"`
+	TaxRate     float64 `json:"tax_rate" validate:"gte=0,lte=100"`
 }
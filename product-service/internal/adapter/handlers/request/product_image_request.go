@@ -0,0 +1,13 @@
+package request
+
+type ProductImageRequest struct {
+	ImageURL     string `json:"image_url" validate:"required,url"`
+	ThumbnailURL string `json:"thumbnail_url" validate:"omitempty,url"`
+	MediumURL    string `json:"medium_url" validate:"omitempty,url"`
+	AltText      string `json:"alt_text" validate:"omitempty,max=255"`
+	IsPrimary    bool   `json:"is_primary"`
+}
+
+type ProductImageReorderRequest struct {
+	ImageIDs []int64 `json:"image_ids" validate:"required,min=1"`
+}
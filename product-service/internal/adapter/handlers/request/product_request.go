@@ -8,12 +8,22 @@ type ProductRequest struct {
 	ProductDescription string                 `json:"product_description" validate:"required"`
 	Status             string                 `json:"status" validate:"required"`
 	VariantDetail      []ProductDetailRequest `json:"variant_detail" validate:"required"`
+	Options            []ProductOptionRequest `json:"options" validate:"omitempty,max=3,dive"`
+}
+
+type ProductOptionRequest struct {
+	Name   string   `json:"name" validate:"required,max=50"`
+	Values []string `json:"values" validate:"required,min=1,dive,required,max=50"`
 }
 
 type ProductDetailRequest struct {
-	Stock        int    `json:"stock" validate:"required,number"`
-	ProductImage string `json:"product_image" validate:"required,url"`
-	Weight       int    `json:"weight" validate:"required,number"`
-	SalePrice    int64  `json:"sale_price" validate:"required,number"`
-	RegulerPrice int64  `json:"reguler_price" validate:"required,number"`
+	ID                int64    `json:"id" validate:"omitempty,min=1"`
+	Stock             int      `json:"stock" validate:"required,number"`
+	ProductImage      string   `json:"product_image" validate:"required,url"`
+	Weight            int      `json:"weight" validate:"required,number"`
+	SalePrice         int64    `json:"sale_price" validate:"required,number"`
+	RegulerPrice      int64    `json:"reguler_price" validate:"required,number"`
+	SKU               string   `json:"sku" validate:"omitempty,max=64"`
+	OptionValues      []string `json:"option_values"`
+	LowStockThreshold int      `json:"low_stock_threshold" validate:"omitempty,min=0"`
 }
@@ -0,0 +1,11 @@
+package request
+
+type ProductReviewRequest struct {
+	Rating  int      `json:"rating" validate:"required,min=1,max=5"`
+	Comment string   `json:"comment" validate:"required,max=2000"`
+	Photos  []string `json:"photos" validate:"omitempty,max=5,dive,url"`
+}
+
+type ProductReviewModerateRequest struct {
+	Status string `json:"status" validate:"required,oneof=APPROVED REJECTED"`
+}
@@ -0,0 +1,8 @@
+package request
+
+type StockMovementRequest struct {
+	Type      string `json:"type" validate:"required,oneof=adjustment restock spoilage return"`
+	Quantity  int    `json:"quantity" validate:"required,number"`
+	Reference string `json:"reference" validate:"omitempty,max=100"`
+	Note      string `json:"note"`
+}
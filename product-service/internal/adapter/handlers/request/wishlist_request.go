@@ -0,0 +1,10 @@
+package request
+
+type WishlistRequest struct {
+	ProductID int64 `json:"product_id" validate:"required"`
+	VariantID int64 `json:"variant_id"`
+}
+
+type WishlistMoveToCartRequest struct {
+	Quantity int64 `json:"quantity" validate:"omitempty,min=1"`
+}
@@ -1,21 +1,23 @@
 package response
 
 type CategoryListAdminResponse struct {
-	ID           int64  `json:"id"`
-	Name         string `json:"name"`
-	Icon         string `json:"icon"`
-	Slug         string `json:"slug"`
-	Status       string `json:"status"`
-	TotalProduct int    `json:"total_product"`
+	ID           int64   `json:"id"`
+	Name         string  `json:"name"`
+	Icon         string  `json:"icon"`
+	Slug         string  `json:"slug"`
+	Status       string  `json:"status"`
+	TaxRate      float64 `json:"tax_rate"`
+	TotalProduct int     `json:"total_product"`
 }
 
 type CategoryDetailResponse struct {
-	ID          int64  `json:"id"`
-	Name        string `json:"name"`
-	Icon        string `json:"icon"`
-	Slug        string `json:"slug"`
-	Status      string `json:"status"`
-	Description string `json:"description"`
+	ID          int64   `json:"id"`
+	Name        string  `json:"name"`
+	Icon        string  `json:"icon"`
+	Slug        string  `json:"slug"`
+	Status      string  `json:"status"`
+	Description string  `json:"description"`
+	TaxRate     float64 `json:"tax_rate"`
 }
 
 type CategoryListHomeResponse struct {
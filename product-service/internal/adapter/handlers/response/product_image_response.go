@@ -0,0 +1,11 @@
+package response
+
+type ProductImageResponse struct {
+	ID           int64  `json:"id"`
+	URL          string `json:"url"`
+	ThumbnailURL string `json:"thumbnail_url"`
+	MediumURL    string `json:"medium_url"`
+	AltText      string `json:"alt_text"`
+	Position     int    `json:"position"`
+	IsPrimary    bool   `json:"is_primary"`
+}
@@ -0,0 +1,21 @@
+package response
+
+import "time"
+
+type ProductImportJobResponse struct {
+	ID          int64                    `json:"id"`
+	FileName    string                   `json:"file_name"`
+	Status      string                   `json:"status"`
+	TotalRows   int                      `json:"total_rows"`
+	SuccessRows int                      `json:"success_rows"`
+	FailedRows  int                      `json:"failed_rows"`
+	Errors      []ProductImportErrorItem `json:"errors"`
+	CreatedAt   time.Time                `json:"created_at"`
+	FinishedAt  *time.Time               `json:"finished_at"`
+}
+
+type ProductImportErrorItem struct {
+	Row     int    `json:"row"`
+	SKU     string `json:"sku"`
+	Message string `json:"message"`
+}
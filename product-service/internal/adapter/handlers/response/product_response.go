@@ -10,46 +10,68 @@ type ProductListResponse struct {
 	CategoryName  string    `json:"category_name"`
 	ProductStatus string    `json:"product_status"`
 	SalePrice     int64     `json:"sale_price"`
+	RatingAvg     float64   `json:"rating_avg"`
+	RatingCount   int       `json:"rating_count"`
 	CreatedAt     time.Time `json:"created_at"`
 }
 
 type ProductDetailResponse struct {
-	ID                 int64                  `json:"id"`
-	ProductName        string                 `json:"product_name"`
-	ParentID           int64                  `json:"parent_id"`
-	ProductImage       string                 `json:"product_image"`
-	CategoryName       string                 `json:"category_name"`
-	CategorySlug       string                 `json:"category_slug"`
-	ProductStatus      string                 `json:"product_status"`
-	ProductDescription string                 `json:"product_description"`
-	SalePrice          int64                  `json:"sale_price"`
-	RegulerPrice       int64                  `json:"reguler_price"`
-	CreatedAt          time.Time              `json:"created_at"`
-	Unit               string                 `json:"unit"`
-	Weight             int                    `json:"weight"`
-	Stock              int                    `json:"stock"`
-	Child              []ProductChildResponse `json:"child"`
+	ID                 int64                   `json:"id"`
+	ProductName        string                  `json:"product_name"`
+	ParentID           int64                   `json:"parent_id"`
+	ProductImage       string                  `json:"product_image"`
+	CategoryName       string                  `json:"category_name"`
+	CategorySlug       string                  `json:"category_slug"`
+	ProductStatus      string                  `json:"product_status"`
+	ProductDescription string                  `json:"product_description"`
+	SalePrice          int64                   `json:"sale_price"`
+	RegulerPrice       int64                   `json:"reguler_price"`
+	CreatedAt          time.Time               `json:"created_at"`
+	Unit               string                  `json:"unit"`
+	Weight             int                     `json:"weight"`
+	Stock              int                     `json:"stock"`
+	LowStockThreshold  int                     `json:"low_stock_threshold"`
+	TaxRate            float64                 `json:"tax_rate"`
+	RatingAvg          float64                 `json:"rating_avg"`
+	RatingCount        int                     `json:"rating_count"`
+	SKU                string                  `json:"sku"`
+	Options            []ProductOptionResponse `json:"options"`
+	OptionValues       []string                `json:"option_values"`
+	Images             []ProductImageResponse  `json:"images"`
+	Child              []ProductChildResponse  `json:"child"`
+}
+
+type ProductOptionResponse struct {
+	Name   string   `json:"name"`
+	Values []string `json:"values"`
 }
 
 type ProductChildResponse struct {
-	ID           int64 `json:"id"`
-	Weight       int   `json:"weight"`
-	Stock        int   `json:"stock"`
-	RegulerPrice int64 `json:"reguler_price"`
-	SalePrice    int64 `json:"sale_price"`
+	ID                int64    `json:"id"`
+	SKU               string   `json:"sku"`
+	OptionValues      []string `json:"option_values"`
+	Weight            int      `json:"weight"`
+	Stock             int      `json:"stock"`
+	LowStockThreshold int      `json:"low_stock_threshold"`
+	RegulerPrice      int64    `json:"reguler_price"`
+	SalePrice         int64    `json:"sale_price"`
 }
 
 type ProductHomeListResponse struct {
-	ID           int64  `json:"id"`
-	ProductName  string `json:"product_name"`
-	ProductImage string `json:"product_image"`
-	CategoryName string `json:"category_name"`
-	SalePrice    int64  `json:"sale_price"`
-	RegulerPrice int64  `json:"reguler_price"`
+	ID           int64   `json:"id"`
+	ProductName  string  `json:"product_name"`
+	ProductImage string  `json:"product_image"`
+	CategoryName string  `json:"category_name"`
+	SalePrice    int64   `json:"sale_price"`
+	RegulerPrice int64   `json:"reguler_price"`
+	RatingAvg    float64 `json:"rating_avg"`
+	RatingCount  int     `json:"rating_count"`
 }
 
 type ProductHomeDetailResponse struct {
 	ID           int64                      `json:"id"`
+	ParentID     int64                      `json:"parent_id"`
+	SKU          string                     `json:"sku"`
 	ProductName  string                     `json:"product_name"`
 	CategoryName string                     `json:"category_name"`
 	Description  string                     `json:"description"`
@@ -59,14 +81,34 @@ type ProductHomeDetailResponse struct {
 	RegulerPrice int64                      `json:"reguler_price"`
 	Stock        int                        `json:"stock"`
 	Weight       int                        `json:"weight"`
+	TaxRate      float64                    `json:"tax_rate"`
+	RatingAvg    float64                    `json:"rating_avg"`
+	RatingCount  int                        `json:"rating_count"`
+	Options      []ProductOptionResponse    `json:"options"`
+	OptionValues []string                   `json:"option_values"`
+	Images       []ProductImageResponse     `json:"images"`
 	Child        []ProductChildHomeResponse `json:"child"`
 }
 
 type ProductChildHomeResponse struct {
-	ID           int64  `json:"id"`
-	Weight       int    `json:"weight"`
-	Stock        int    `json:"stock"`
-	RegulerPrice int64  `json:"reguler_price"`
-	SalePrice    int64  `json:"sale_price"`
-	Image        string `json:"image"`
+	ID           int64    `json:"id"`
+	SKU          string   `json:"sku"`
+	OptionValues []string `json:"option_values"`
+	Weight       int      `json:"weight"`
+	Stock        int      `json:"stock"`
+	RegulerPrice int64    `json:"reguler_price"`
+	SalePrice    int64    `json:"sale_price"`
+	Image        string   `json:"image"`
+}
+
+type ProductLowStockResponse struct {
+	ID                int64  `json:"id"`
+	ProductName       string `json:"product_name"`
+	ParentID          int64  `json:"parent_id"`
+	ProductImage      string `json:"product_image"`
+	CategoryName      string `json:"category_name"`
+	Weight            int    `json:"weight"`
+	Unit              string `json:"unit"`
+	Stock             int    `json:"stock"`
+	LowStockThreshold int    `json:"low_stock_threshold"`
 }
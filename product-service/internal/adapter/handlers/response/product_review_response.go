@@ -0,0 +1,30 @@
+package response
+
+import "time"
+
+type ProductReviewResponse struct {
+	ID        int64     `json:"id"`
+	ProductID int64     `json:"product_id"`
+	UserName  string    `json:"user_name"`
+	Rating    int       `json:"rating"`
+	Comment   string    `json:"comment"`
+	Photos    []string  `json:"photos"`
+	Status    string    `json:"status"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type ProductReviewAdminResponse struct {
+	ID          int64      `json:"id"`
+	ProductID   int64      `json:"product_id"`
+	ProductName string     `json:"product_name"`
+	UserID      int64      `json:"user_id"`
+	UserName    string     `json:"user_name"`
+	OrderID     int64      `json:"order_id"`
+	Rating      int        `json:"rating"`
+	Comment     string     `json:"comment"`
+	Photos      []string   `json:"photos"`
+	Status      string     `json:"status"`
+	ModeratedBy int64      `json:"moderated_by"`
+	ModeratedAt *time.Time `json:"moderated_at"`
+	CreatedAt   time.Time  `json:"created_at"`
+}
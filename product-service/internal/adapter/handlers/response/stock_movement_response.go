@@ -0,0 +1,23 @@
+package response
+
+import "time"
+
+type StockMovementResponse struct {
+	ID         int64     `json:"id"`
+	ProductID  int64     `json:"product_id"`
+	Type       string    `json:"type"`
+	Quantity   int       `json:"quantity"`
+	StockAfter int       `json:"stock_after"`
+	ActorType  string    `json:"actor_type"`
+	ActorID    int64     `json:"actor_id"`
+	Reference  string    `json:"reference"`
+	Note       string    `json:"note"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+type StockReconciliationResponse struct {
+	ProductID   int64 `json:"product_id"`
+	Stock       int   `json:"stock"`
+	LedgerStock int   `json:"ledger_stock"`
+	Difference  int   `json:"difference"`
+}
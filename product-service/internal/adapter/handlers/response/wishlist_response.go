@@ -0,0 +1,28 @@
+package response
+
+import "time"
+
+type WishlistResponse struct {
+	ID            int64     `json:"id"`
+	ProductID     int64     `json:"product_id"`
+	VariantID     int64     `json:"variant_id"`
+	SKU           string    `json:"sku"`
+	ProductName   string    `json:"product_name"`
+	ProductImage  string    `json:"product_image"`
+	ProductStatus string    `json:"product_status"`
+	CategoryName  string    `json:"category_name"`
+	RegulerPrice  int64     `json:"reguler_price"`
+	SalePrice     int64     `json:"sale_price"`
+	Stock         int       `json:"stock"`
+	InStock       bool      `json:"in_stock"`
+	HasVariant    bool      `json:"has_variant"`
+	CreatedAt     time.Time `json:"created_at"`
+}
+
+type WishlistCountResponse struct {
+	ProductID     int64  `json:"product_id"`
+	ProductName   string `json:"product_name"`
+	ProductImage  string `json:"product_image"`
+	ProductStatus string `json:"product_status"`
+	WishlistCount int64  `json:"wishlist_count"`
+}
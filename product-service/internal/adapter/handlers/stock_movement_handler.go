@@ -0,0 +1,292 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"product-service/config"
+	"product-service/internal/adapter"
+	"product-service/internal/adapter/handlers/request"
+	"product-service/internal/adapter/handlers/response"
+	"product-service/internal/core/domain/entity"
+	"product-service/internal/core/service"
+	"product-service/utils/conv"
+
+	"github.com/labstack/echo/v4"
+	"github.com/labstack/gommon/log"
+)
+
+type StockMovementHandlerInterface interface {
+	GetByProductIDAdmin(c echo.Context) error
+	CreateAdmin(c echo.Context) error
+	GetReconciliationAdmin(c echo.Context) error
+	ReconcileAdmin(c echo.Context) error
+}
+
+type stockMovementHandler struct {
+	service service.StockMovementServiceInterface
+}
+
+// GetByProductIDAdmin implements StockMovementHandlerInterface.
+func (s *stockMovementHandler) GetByProductIDAdmin(c echo.Context) error {
+	var (
+		resp          = response.DefaultResponseWithPaginations{}
+		ctx           = c.Request().Context()
+		respMovements = []response.StockMovementResponse{}
+	)
+
+	id, err := conv.StringToInt64(c.Param("id"))
+	if err != nil {
+		log.Errorf("[StockMovementHandler-1] GetByProductIDAdmin: %v", err)
+		resp.Message = "ID is required"
+		resp.Data = nil
+		return c.JSON(http.StatusBadRequest, resp)
+	}
+
+	var page int64 = 1
+	if pageStr := c.QueryParam("page"); pageStr != "" {
+		page, _ = conv.StringToInt64(pageStr)
+		if page <= 0 {
+			page = 1
+		}
+	}
+
+	var perPage int64 = 10
+	if perPageStr := c.QueryParam("limit"); perPageStr != "" {
+		perPage, _ = conv.StringToInt64(perPageStr)
+		if perPage <= 0 {
+			perPage = 10
+		}
+	}
+
+	reqEntity := entity.QueryStringStockMovement{
+		Page:  int(page),
+		Limit: int(perPage),
+		Type:  c.QueryParam("type"),
+	}
+
+	results, totalData, totalPage, err := s.service.GetByProductID(ctx, id, reqEntity)
+	if err != nil {
+		log.Errorf("[StockMovementHandler-2] GetByProductIDAdmin: %v", err)
+		if err.Error() == "404" {
+			resp.Message = "Data not found"
+			resp.Data = nil
+			return c.JSON(http.StatusNotFound, resp)
+		}
+		resp.Message = err.Error()
+		resp.Data = nil
+		return c.JSON(http.StatusInternalServerError, resp)
+	}
+
+	for _, val := range results {
+		respMovements = append(respMovements, stockMovementToResponse(val))
+	}
+
+	resp.Message = "success"
+	resp.Data = respMovements
+	resp.Pagination = &response.Pagination{
+		Page:       page,
+		TotalCount: totalData,
+		TotalPage:  totalPage,
+		PerPage:    perPage,
+	}
+	return c.JSON(http.StatusOK, resp)
+}
+
+// CreateAdmin implements StockMovementHandlerInterface.
+func (s *stockMovementHandler) CreateAdmin(c echo.Context) error {
+	var (
+		resp        = response.DefaultResponse{}
+		ctx         = c.Request().Context()
+		req         = request.StockMovementRequest{}
+		jwtUserData = entity.JwtUserData{}
+	)
+
+	user := c.Get("user").(string)
+	if user == "" {
+		log.Errorf("[StockMovementHandler-1] CreateAdmin: %s", "data token not found")
+		resp.Message = "data token not found"
+		resp.Data = nil
+		return c.JSON(http.StatusNotFound, resp)
+	}
+
+	if err := json.Unmarshal([]byte(user), &jwtUserData); err != nil {
+		log.Errorf("[StockMovementHandler-2] CreateAdmin: %v", err)
+		resp.Message = err.Error()
+		resp.Data = nil
+		return c.JSON(http.StatusBadRequest, resp)
+	}
+
+	id, err := conv.StringToInt64(c.Param("id"))
+	if err != nil {
+		log.Errorf("[StockMovementHandler-3] CreateAdmin: %v", err)
+		resp.Message = "ID is required"
+		resp.Data = nil
+		return c.JSON(http.StatusBadRequest, resp)
+	}
+
+	if err := c.Bind(&req); err != nil {
+		log.Errorf("[StockMovementHandler-4] CreateAdmin: %v", err)
+		resp.Message = err.Error()
+		resp.Data = nil
+		return c.JSON(http.StatusBadRequest, resp)
+	}
+
+	if err := c.Validate(req); err != nil {
+		log.Errorf("[StockMovementHandler-5] CreateAdmin: %v", err)
+		resp.Message = err.Error()
+		resp.Data = nil
+		return c.JSON(http.StatusBadRequest, resp)
+	}
+
+	result, err := s.service.PostMovement(ctx, entity.StockMovementEntity{
+		ProductID: id,
+		Type:      req.Type,
+		Quantity:  req.Quantity,
+		ActorID:   jwtUserData.UserID,
+		Reference: req.Reference,
+		Note:      req.Note,
+	})
+	if err != nil {
+		log.Errorf("[StockMovementHandler-6] CreateAdmin: %v", err)
+		switch err.Error() {
+		case "404":
+			resp.Message = "Data not found"
+			resp.Data = nil
+			return c.JSON(http.StatusNotFound, resp)
+		case "409":
+			resp.Message = "Stock cannot be negative"
+			resp.Data = nil
+			return c.JSON(http.StatusConflict, resp)
+		case "422":
+			resp.Message = "Invalid stock movement"
+			resp.Data = nil
+			return c.JSON(http.StatusUnprocessableEntity, resp)
+		}
+		resp.Message = err.Error()
+		resp.Data = nil
+		return c.JSON(http.StatusInternalServerError, resp)
+	}
+
+	resp.Message = "success"
+	resp.Data = stockMovementToResponse(*result)
+	return c.JSON(http.StatusCreated, resp)
+}
+
+// GetReconciliationAdmin implements StockMovementHandlerInterface.
+func (s *stockMovementHandler) GetReconciliationAdmin(c echo.Context) error {
+	var (
+		resp = response.DefaultResponse{}
+		ctx  = c.Request().Context()
+	)
+
+	id, err := conv.StringToInt64(c.Param("id"))
+	if err != nil {
+		log.Errorf("[StockMovementHandler-1] GetReconciliationAdmin: %v", err)
+		resp.Message = "ID is required"
+		resp.Data = nil
+		return c.JSON(http.StatusBadRequest, resp)
+	}
+
+	result, err := s.service.GetReconciliation(ctx, id)
+	if err != nil {
+		log.Errorf("[StockMovementHandler-2] GetReconciliationAdmin: %v", err)
+		if err.Error() == "404" {
+			resp.Message = "Data not found"
+			resp.Data = nil
+			return c.JSON(http.StatusNotFound, resp)
+		}
+		resp.Message = err.Error()
+		resp.Data = nil
+		return c.JSON(http.StatusInternalServerError, resp)
+	}
+
+	resp.Message = "success"
+	resp.Data = stockReconciliationToResponse(*result)
+	return c.JSON(http.StatusOK, resp)
+}
+
+// ReconcileAdmin implements StockMovementHandlerInterface.
+func (s *stockMovementHandler) ReconcileAdmin(c echo.Context) error {
+	var (
+		resp        = response.DefaultResponse{}
+		ctx         = c.Request().Context()
+		jwtUserData = entity.JwtUserData{}
+	)
+
+	user := c.Get("user").(string)
+	if user == "" {
+		log.Errorf("[StockMovementHandler-1] ReconcileAdmin: %s", "data token not found")
+		resp.Message = "data token not found"
+		resp.Data = nil
+		return c.JSON(http.StatusNotFound, resp)
+	}
+
+	if err := json.Unmarshal([]byte(user), &jwtUserData); err != nil {
+		log.Errorf("[StockMovementHandler-2] ReconcileAdmin: %v", err)
+		resp.Message = err.Error()
+		resp.Data = nil
+		return c.JSON(http.StatusBadRequest, resp)
+	}
+
+	id, err := conv.StringToInt64(c.Param("id"))
+	if err != nil {
+		log.Errorf("[StockMovementHandler-3] ReconcileAdmin: %v", err)
+		resp.Message = "ID is required"
+		resp.Data = nil
+		return c.JSON(http.StatusBadRequest, resp)
+	}
+
+	result, err := s.service.Reconcile(ctx, id, jwtUserData.UserID)
+	if err != nil {
+		log.Errorf("[StockMovementHandler-4] ReconcileAdmin: %v", err)
+		if err.Error() == "404" {
+			resp.Message = "Data not found"
+			resp.Data = nil
+			return c.JSON(http.StatusNotFound, resp)
+		}
+		resp.Message = err.Error()
+		resp.Data = nil
+		return c.JSON(http.StatusInternalServerError, resp)
+	}
+
+	resp.Message = "success"
+	resp.Data = stockReconciliationToResponse(*result)
+	return c.JSON(http.StatusOK, resp)
+}
+
+func stockMovementToResponse(val entity.StockMovementEntity) response.StockMovementResponse {
+	return response.StockMovementResponse{
+		ID:         val.ID,
+		ProductID:  val.ProductID,
+		Type:       val.Type,
+		Quantity:   val.Quantity,
+		StockAfter: val.StockAfter,
+		ActorType:  val.ActorType,
+		ActorID:    val.ActorID,
+		Reference:  val.Reference,
+		Note:       val.Note,
+		CreatedAt:  val.CreatedAt,
+	}
+}
+
+func stockReconciliationToResponse(val entity.StockReconciliationEntity) response.StockReconciliationResponse {
+	return response.StockReconciliationResponse{
+		ProductID:   val.ProductID,
+		Stock:       val.Stock,
+		LedgerStock: val.LedgerStock,
+		Difference:  val.Difference,
+	}
+}
+
+func NewStockMovementHandler(e *echo.Echo, cfg *config.Config, stockMovementService service.StockMovementServiceInterface) StockMovementHandlerInterface {
+	stockMovement := &stockMovementHandler{service: stockMovementService}
+
+	mid := adapter.NewMiddlewareAdapter(cfg)
+	adminGroup := e.Group("/admin", mid.CheckToken())
+	adminGroup.GET("/products/:id/stock-movements", stockMovement.GetByProductIDAdmin)
+	adminGroup.POST("/products/:id/stock-movements", stockMovement.CreateAdmin)
+	adminGroup.GET("/products/:id/stock-reconciliation", stockMovement.GetReconciliationAdmin)
+	adminGroup.POST("/products/:id/stock-reconciliation", stockMovement.ReconcileAdmin)
+
+	return stockMovement
+}
@@ -0,0 +1,56 @@
+package handlers
+
+import (
+	"net/http"
+	"product-service/internal/adapter/handlers/response"
+	"product-service/internal/adapter/storage"
+	"product-service/utils/conv"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+	"github.com/labstack/gommon/log"
+)
+
+type StorageHandlerInterface interface {
+	ServeFile(c echo.Context) error
+}
+
+type storageHandler struct {
+	storage storage.LocalStorageInterface
+}
+
+// ServeFile implements StorageHandlerInterface.
+// File di bawah public/ bebas diakses, selain itu wajib memakai signed URL.
+func (s *storageHandler) ServeFile(c echo.Context) error {
+	var resp = response.DefaultResponse{}
+
+	path := c.Param("*")
+	if !strings.HasPrefix(path, "public/") {
+		expires, _ := conv.StringToInt64(c.QueryParam("expires"))
+		if !s.storage.VerifySignature(path, expires, c.QueryParam("signature")) {
+			log.Errorf("[StorageHandler-1] ServeFile: invalid signature for %s", path)
+			resp.Message = "Invalid or expired signature"
+			resp.Data = nil
+			return c.JSON(http.StatusForbidden, resp)
+		}
+	}
+
+	fullPath, err := s.storage.FilePath(path)
+	if err != nil {
+		log.Errorf("[StorageHandler-2] ServeFile: %v", err)
+		resp.Message = "Data not found"
+		resp.Data = nil
+		return c.JSON(http.StatusNotFound, resp)
+	}
+
+	return c.File(fullPath)
+}
+
+// NewStorageHandler hanya didaftarkan saat STORAGE_DRIVER=local, LOCAL_STORAGE_URL harus mengarah ke route /storage.
+func NewStorageHandler(e *echo.Echo, localStorage storage.LocalStorageInterface) StorageHandlerInterface {
+	res := &storageHandler{storage: localStorage}
+
+	e.GET("/storage/*", res.ServeFile)
+
+	return res
+}
@@ -0,0 +1,307 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"product-service/config"
+	"product-service/internal/adapter"
+	"product-service/internal/adapter/handlers/request"
+	"product-service/internal/adapter/handlers/response"
+	"product-service/internal/core/domain/entity"
+	"product-service/internal/core/service"
+	"product-service/utils/conv"
+
+	"github.com/labstack/echo/v4"
+	"github.com/labstack/gommon/log"
+)
+
+type WishlistHandlerInterface interface {
+	GetWishlist(c echo.Context) error
+	AddToWishlist(c echo.Context) error
+	RemoveFromWishlist(c echo.Context) error
+	MoveToCart(c echo.Context) error
+
+	GetCountsAdmin(c echo.Context) error
+}
+
+type wishlistHandler struct {
+	service service.WishlistServiceInterface
+}
+
+// GetWishlist implements WishlistHandlerInterface.
+func (w *wishlistHandler) GetWishlist(c echo.Context) error {
+	var (
+		resp     = response.DefaultResponse{}
+		ctx      = c.Request().Context()
+		respList = []response.WishlistResponse{}
+	)
+
+	jwtUserData, err := wishlistUserFromContext(c)
+	if err != nil {
+		log.Errorf("[WishlistHandler-1] GetWishlist: %v", err)
+		resp.Message = err.Error()
+		resp.Data = nil
+		return c.JSON(http.StatusBadRequest, resp)
+	}
+
+	results, err := w.service.GetByUserID(ctx, jwtUserData.UserID)
+	if err != nil {
+		log.Errorf("[WishlistHandler-2] GetWishlist: %v", err)
+		return wishlistErrorResponse(c, err)
+	}
+
+	for _, val := range results {
+		respList = append(respList, wishlistToResponse(val))
+	}
+
+	resp.Message = "success"
+	resp.Data = respList
+	return c.JSON(http.StatusOK, resp)
+}
+
+// AddToWishlist implements WishlistHandlerInterface.
+func (w *wishlistHandler) AddToWishlist(c echo.Context) error {
+	var (
+		resp = response.DefaultResponse{}
+		ctx  = c.Request().Context()
+		req  = request.WishlistRequest{}
+	)
+
+	if err := c.Bind(&req); err != nil {
+		log.Errorf("[WishlistHandler-1] AddToWishlist: %v", err)
+		resp.Message = err.Error()
+		resp.Data = nil
+		return c.JSON(http.StatusBadRequest, resp)
+	}
+
+	if err := c.Validate(req); err != nil {
+		log.Errorf("[WishlistHandler-2] AddToWishlist: %v", err)
+		resp.Message = err.Error()
+		resp.Data = nil
+		return c.JSON(http.StatusBadRequest, resp)
+	}
+
+	jwtUserData, err := wishlistUserFromContext(c)
+	if err != nil {
+		log.Errorf("[WishlistHandler-3] AddToWishlist: %v", err)
+		resp.Message = err.Error()
+		resp.Data = nil
+		return c.JSON(http.StatusBadRequest, resp)
+	}
+
+	result, err := w.service.Add(ctx, jwtUserData.UserID, req.ProductID, req.VariantID)
+	if err != nil {
+		log.Errorf("[WishlistHandler-4] AddToWishlist: %v", err)
+		return wishlistErrorResponse(c, err)
+	}
+
+	resp.Message = "success"
+	resp.Data = wishlistToResponse(*result)
+	return c.JSON(http.StatusCreated, resp)
+}
+
+// RemoveFromWishlist implements WishlistHandlerInterface.
+func (w *wishlistHandler) RemoveFromWishlist(c echo.Context) error {
+	var (
+		resp = response.DefaultResponse{}
+		ctx  = c.Request().Context()
+	)
+
+	jwtUserData, err := wishlistUserFromContext(c)
+	if err != nil {
+		log.Errorf("[WishlistHandler-1] RemoveFromWishlist: %v", err)
+		resp.Message = err.Error()
+		resp.Data = nil
+		return c.JSON(http.StatusBadRequest, resp)
+	}
+
+	id, err := conv.StringToInt64(c.Param("id"))
+	if err != nil {
+		log.Errorf("[WishlistHandler-2] RemoveFromWishlist: %v", err)
+		resp.Message = "ID is required"
+		resp.Data = nil
+		return c.JSON(http.StatusBadRequest, resp)
+	}
+
+	if err := w.service.Remove(ctx, jwtUserData.UserID, id); err != nil {
+		log.Errorf("[WishlistHandler-3] RemoveFromWishlist: %v", err)
+		return wishlistErrorResponse(c, err)
+	}
+
+	resp.Message = "success"
+	resp.Data = nil
+	return c.JSON(http.StatusOK, resp)
+}
+
+// MoveToCart implements WishlistHandlerInterface.
+// Quantity bawaan 1 jika tidak dikirim.
+func (w *wishlistHandler) MoveToCart(c echo.Context) error {
+	var (
+		resp = response.DefaultResponse{}
+		ctx  = c.Request().Context()
+		req  = request.WishlistMoveToCartRequest{}
+	)
+
+	jwtUserData, err := wishlistUserFromContext(c)
+	if err != nil {
+		log.Errorf("[WishlistHandler-1] MoveToCart: %v", err)
+		resp.Message = err.Error()
+		resp.Data = nil
+		return c.JSON(http.StatusBadRequest, resp)
+	}
+
+	id, err := conv.StringToInt64(c.Param("id"))
+	if err != nil {
+		log.Errorf("[WishlistHandler-2] MoveToCart: %v", err)
+		resp.Message = "ID is required"
+		resp.Data = nil
+		return c.JSON(http.StatusBadRequest, resp)
+	}
+
+	if err := c.Bind(&req); err != nil {
+		log.Errorf("[WishlistHandler-3] MoveToCart: %v", err)
+		resp.Message = err.Error()
+		resp.Data = nil
+		return c.JSON(http.StatusBadRequest, resp)
+	}
+
+	if err := c.Validate(req); err != nil {
+		log.Errorf("[WishlistHandler-4] MoveToCart: %v", err)
+		resp.Message = err.Error()
+		resp.Data = nil
+		return c.JSON(http.StatusBadRequest, resp)
+	}
+
+	if req.Quantity == 0 {
+		req.Quantity = 1
+	}
+
+	if err := w.service.MoveToCart(ctx, jwtUserData.UserID, id, req.Quantity); err != nil {
+		log.Errorf("[WishlistHandler-5] MoveToCart: %v", err)
+		return wishlistErrorResponse(c, err)
+	}
+
+	resp.Message = "success"
+	resp.Data = nil
+	return c.JSON(http.StatusOK, resp)
+}
+
+// GetCountsAdmin implements WishlistHandlerInterface.
+func (w *wishlistHandler) GetCountsAdmin(c echo.Context) error {
+	var (
+		resp     = response.DefaultResponseWithPaginations{}
+		ctx      = c.Request().Context()
+		respList = []response.WishlistCountResponse{}
+	)
+
+	var page int64 = 1
+	if pageStr := c.QueryParam("page"); pageStr != "" {
+		page, _ = conv.StringToInt64(pageStr)
+		if page <= 0 {
+			page = 1
+		}
+	}
+
+	var perPage int64 = 10
+	if perPageStr := c.QueryParam("limit"); perPageStr != "" {
+		perPage, _ = conv.StringToInt64(perPageStr)
+		if perPage <= 0 {
+			perPage = 10
+		}
+	}
+
+	results, totalData, totalPage, err := w.service.GetCountsAdmin(ctx, entity.QueryStringWishlist{
+		Search: c.QueryParam("search"),
+		Page:   int(page),
+		Limit:  int(perPage),
+	})
+	if err != nil {
+		log.Errorf("[WishlistHandler-1] GetCountsAdmin: %v", err)
+		return wishlistErrorResponse(c, err)
+	}
+
+	for _, val := range results {
+		respList = append(respList, response.WishlistCountResponse{
+			ProductID:     val.ProductID,
+			ProductName:   val.ProductName,
+			ProductImage:  val.ProductImage,
+			ProductStatus: val.ProductStatus,
+			WishlistCount: val.WishlistCount,
+		})
+	}
+
+	resp.Message = "success"
+	resp.Data = respList
+	resp.Pagination = &response.Pagination{
+		Page:       page,
+		TotalCount: totalData,
+		TotalPage:  totalPage,
+		PerPage:    perPage,
+	}
+	return c.JSON(http.StatusOK, resp)
+}
+
+func wishlistUserFromContext(c echo.Context) (entity.JwtUserData, error) {
+	jwtUserData := entity.JwtUserData{}
+	user, _ := c.Get("user").(string)
+	if user == "" {
+		return jwtUserData, errors.New("data token not found")
+	}
+
+	err := json.Unmarshal([]byte(user), &jwtUserData)
+	return jwtUserData, err
+}
+
+func wishlistErrorResponse(c echo.Context, err error) error {
+	resp := response.DefaultResponse{}
+	switch err.Error() {
+	case "404":
+		resp.Message = "Data not found"
+		return c.JSON(http.StatusNotFound, resp)
+	case "409":
+		resp.Message = "Product is already in wishlist or out of stock"
+		return c.JSON(http.StatusConflict, resp)
+	case "422":
+		resp.Message = "Please choose a valid variant for this product"
+		return c.JSON(http.StatusUnprocessableEntity, resp)
+	}
+
+	resp.Message = err.Error()
+	return c.JSON(http.StatusInternalServerError, resp)
+}
+
+func wishlistToResponse(val entity.WishlistEntity) response.WishlistResponse {
+	return response.WishlistResponse{
+		ID:            val.ID,
+		ProductID:     val.ProductID,
+		VariantID:     val.VariantID,
+		SKU:           val.SKU,
+		ProductName:   val.ProductName,
+		ProductImage:  val.ProductImage,
+		ProductStatus: val.ProductStatus,
+		CategoryName:  val.CategoryName,
+		RegulerPrice:  int64(val.RegulerPrice),
+		SalePrice:     int64(val.SalePrice),
+		Stock:         val.Stock,
+		InStock:       val.Stock > 0,
+		HasVariant:    val.HasVariant,
+		CreatedAt:     val.CreatedAt,
+	}
+}
+
+func NewWishlistHandler(e *echo.Echo, cfg *config.Config, wishlistService service.WishlistServiceInterface) WishlistHandlerInterface {
+	wishlist := &wishlistHandler{service: wishlistService}
+
+	mid := adapter.NewMiddlewareAdapter(cfg)
+	authGroup := e.Group("/auth", mid.CheckToken())
+	authGroup.GET("/wishlist", wishlist.GetWishlist)
+	authGroup.POST("/wishlist", wishlist.AddToWishlist)
+	authGroup.DELETE("/wishlist/:id", wishlist.RemoveFromWishlist)
+	authGroup.POST("/wishlist/:id/move-to-cart", wishlist.MoveToCart)
+
+	adminGroup := e.Group("/admin", mid.CheckToken())
+	adminGroup.GET("/wishlists", wishlist.GetCountsAdmin)
+
+	return wishlist
+}
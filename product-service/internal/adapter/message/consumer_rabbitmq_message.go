@@ -8,10 +8,52 @@ import (
 	"io"
 	"product-service/config"
 	"product-service/internal/core/domain/entity"
+	"strings"
 
+	"github.com/elastic/go-elasticsearch/v7"
 	"github.com/labstack/gommon/log"
 )
 
+// productRatingMapping memastikan rating_avg dipetakan sebagai float,
+// dynamic mapping akan memetakan nilai awal 0 sebagai long sehingga rata-rata terpotong.
+const productRatingMapping = `{ "properties": { "rating_avg": { "type": "float" }, "rating_count": { "type": "integer" } } }`
+
+// ensureProductRatingMapping menambahkan mapping rating ke index products, index dibuat jika belum ada.
+func ensureProductRatingMapping(esClient *elasticsearch.Client) {
+	if esClient == nil {
+		return
+	}
+
+	res, err := esClient.Indices.PutMapping(
+		strings.NewReader(productRatingMapping),
+		esClient.Indices.PutMapping.WithIndex("products"),
+		esClient.Indices.PutMapping.WithContext(context.Background()),
+	)
+	if err != nil {
+		log.Errorf("[EnsureProductRatingMapping-1] Failed to put mapping: %v", err)
+		return
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode == 404 {
+		res, err = esClient.Indices.Create(
+			"products",
+			esClient.Indices.Create.WithBody(strings.NewReader(fmt.Sprintf(`{ "mappings": %s }`, productRatingMapping))),
+			esClient.Indices.Create.WithContext(context.Background()),
+		)
+		if err != nil {
+			log.Errorf("[EnsureProductRatingMapping-2] Failed to create index: %v", err)
+			return
+		}
+		defer res.Body.Close()
+	}
+
+	if res.IsError() {
+		body, _ := io.ReadAll(res.Body)
+		log.Errorf("[EnsureProductRatingMapping-3] %s", string(body))
+	}
+}
+
 func StartDeleteOrderConsumer() {
 	conn, err := config.NewConfig().NewRabbitMQ()
 	if err != nil {
@@ -131,6 +173,8 @@ func StartConsumer() {
 		log.Errorf("[StartConsumer-5] Failed initialize Elasticsearch client: %v", err)
 	}
 
+	ensureProductRatingMapping(esClient)
+
 	forever := make(chan bool)
 	go func() {
 		for d := range msgs {
@@ -1,23 +1,29 @@
 package message
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
 	"product-service/config"
+	"product-service/internal/adapter/repository"
 	"product-service/internal/core/domain/entity"
-	"product-service/internal/core/domain/model"
+	"time"
 
 	"github.com/labstack/gommon/log"
+	"gorm.io/gorm"
 )
 
 // StartUpdateStockConsumer implements consumerUpdateStockInterface.
+// Pesan di-ack setelah perubahan stok tersimpan, pesan yang gagal karena error sementara dikembalikan ke antrean.
 func StartUpdateStockConsumer() {
-	db, err := config.NewConfig().ConnectionPostgres()
+	cfg := config.NewConfig()
+	db, err := cfg.ConnectionPostgres()
 	if err != nil {
 		log.Errorf("[StartUpdateStockConsumer-1] Failed to connect to PostgreSQL: %v", err)
 		return
 	}
 
-	conn, err := config.NewConfig().NewRabbitMQ()
+	conn, err := cfg.NewRabbitMQ()
 	if err != nil {
 		log.Errorf("[StartUpdateStockConsumer-1] Failed to connect to RabbitMQ: %v", err)
 		return
@@ -34,7 +40,7 @@ func StartUpdateStockConsumer() {
 	defer ch.Close()
 
 	q, err := ch.QueueDeclare(
-		config.NewConfig().PublisherName.ProductUpdateStock,
+		cfg.PublisherName.ProductUpdateStock,
 		true,
 		false,
 		false,
@@ -46,46 +52,111 @@ func StartUpdateStockConsumer() {
 		return
 	}
 
+	if err := ch.Qos(1, 0, false); err != nil {
+		log.Fatalf("[StartConsumer-4] Failed to set QoS: %v", err)
+		return
+	}
+
 	msgs, err := ch.Consume(
 		q.Name,
 		"",
-		true,
+		false,
 		false,
 		false,
 		false,
 		nil,
 	)
 	if err != nil {
-		log.Fatalf("[StartConsumer-4] Failed to register consumer: %v", err)
+		log.Fatalf("[StartConsumer-5] Failed to register consumer: %v", err)
 		return
 	}
 
 	log.Info("RabbitMQ Consumer started...")
 
+	stockMovementRepo := repository.NewStockMovementRepository(db.DB)
+	publisher := NewPublishRabbitMQ(cfg)
+
 	for msg := range msgs {
 		var orderItem entity.PublishOrderItemEntity
 		if err := json.Unmarshal(msg.Body, &orderItem); err != nil {
 			log.Errorf("[StartUpdateStockConsumer-5] Failed to decode message: %v", err)
+			msg.Nack(false, false)
 			continue
 		}
 
-		// Simulasi update stok
-		var product model.Product
-		if err := db.DB.First(&product, orderItem.ProductID).Error; err != nil {
-			log.Errorf("[StartUpdateStockConsumer-6] Failed to find product: %v", err)
+		if err := updateStock(context.Background(), stockMovementRepo, publisher, orderItem); err != nil {
+			log.Errorf("[StartUpdateStockConsumer-6] Requeue product %d reference %s: %v", orderItem.ProductID, orderItem.Reference, err)
+			time.Sleep(2 * time.Second)
+			msg.Nack(false, true)
 			continue
 		}
 
-		if product.Stock < int(orderItem.Quantity) {
-			log.Errorf("[StartUpdateStockConsumer-7] Stock not enough")
-			continue
+		msg.Ack(false)
+	}
+}
+
+// updateStock mencatat movement sale atau cancellation untuk satu produk dalam order.
+// Order-service menjumlahkan kuantitas item dengan produk yang sama, sehingga dedupe per produk, tipe dan kode order tetap tepat.
+// Error yang dikembalikan hanya error sementara sehingga pesan perlu diproses ulang.
+func updateStock(ctx context.Context, stockMovementRepo repository.StockMovementRepositoryInterface, publisher PublishRabbitMQInterface, orderItem entity.PublishOrderItemEntity) error {
+	movementType := orderItem.MovementType
+	if movementType == "" {
+		movementType = "sale"
+	}
+
+	quantity := -int(orderItem.Quantity)
+	if movementType == "cancellation" {
+		// Pembatalan hanya mengembalikan stok dari penjualan yang tercatat
+		sold, err := stockMovementRepo.HasMovement(ctx, orderItem.ProductID, "sale", orderItem.Reference)
+		if err != nil {
+			return err
 		}
+		if !sold {
+			log.Infof("[updateStock-1] Skip cancellation product %d reference %s: no sale recorded", orderItem.ProductID, orderItem.Reference)
+			return nil
+		}
+		quantity = int(orderItem.Quantity)
+	}
 
-		product.Stock -= int(orderItem.Quantity)
-		if err := db.DB.Save(&product).Error; err != nil {
-			log.Errorf("[StartUpdateStockConsumer-8] Failed to update stock: %v", err)
-			continue
+	movement, err := stockMovementRepo.RecordMovement(ctx, entity.StockMovementEntity{
+		ProductID: orderItem.ProductID,
+		Type:      movementType,
+		Quantity:  quantity,
+		ActorType: "system",
+		Reference: orderItem.Reference,
+	})
+	if err == nil {
+		log.Infof("[updateStock-2] Mencatat %s produk %d sebanyak %d", movementType, orderItem.ProductID, orderItem.Quantity)
+		if err := publisher.PublishStockAlert(*movement); err != nil {
+			log.Errorf("[updateStock-6] Failed to publish stock alert product %d: %v", orderItem.ProductID, err)
+		}
+		return nil
+	}
+
+	if errors.Is(err, gorm.ErrDuplicatedKey) {
+		log.Infof("[updateStock-3] Duplicate %s product %d reference %s", movementType, orderItem.ProductID, orderItem.Reference)
+		return nil
+	}
+
+	if movementType == "sale" && (err.Error() == "409" || err.Error() == "404") {
+		reason := "Stock not enough"
+		if err.Error() == "404" {
+			reason = "Product not found"
 		}
-		log.Printf("Mengurangi stok produk %s sebanyak %d", orderItem.ProductID, orderItem.Quantity)
+		log.Infof("[updateStock-4] %s product %d reference %s", reason, orderItem.ProductID, orderItem.Reference)
+
+		return publisher.PublishStockFailed(entity.PublishStockFailedEntity{
+			ProductID: orderItem.ProductID,
+			Quantity:  orderItem.Quantity,
+			Reference: orderItem.Reference,
+			Reason:    reason,
+		})
 	}
+
+	if err.Error() == "404" {
+		log.Infof("[updateStock-5] Skip %s product %d reference %s: product not found", movementType, orderItem.ProductID, orderItem.Reference)
+		return nil
+	}
+
+	return err
 }
@@ -13,6 +13,8 @@ import (
 type PublishRabbitMQInterface interface {
 	PublishProductToQueue(product entity.ProductEntity) error
 	DeleteProductFromQueue(productID int64) error
+	PublishStockFailed(req entity.PublishStockFailedEntity) error
+	PublishStockAlert(movement entity.StockMovementEntity) error
 }
 
 type PublishRabbitMQ struct {
@@ -23,6 +25,135 @@ func NewPublishRabbitMQ(cfg *config.Config) PublishRabbitMQInterface {
 	return &PublishRabbitMQ{cfg: cfg}
 }
 
+// PublishStockAlert implements PublishRabbitMQInterface.
+// Alert hanya dikirim saat stok melewati batas low stock atau habis, bukan pada setiap pengurangan.
+func (p *PublishRabbitMQ) PublishStockAlert(movement entity.StockMovementEntity) error {
+	alertType := StockAlertType(movement)
+	if alertType == "" {
+		return nil
+	}
+
+	conn, err := p.cfg.NewRabbitMQ()
+	if err != nil {
+		log.Errorf("[PublishStockAlert-1] Failed to connect to RabbitMQ: %v", err)
+		return err
+	}
+
+	defer conn.Close()
+
+	ch, err := conn.Channel()
+	if err != nil {
+		log.Errorf("[PublishStockAlert-2] Failed to open a channel: %v", err)
+		return err
+	}
+
+	defer ch.Close()
+	q, err := ch.QueueDeclare(
+		p.cfg.PublisherName.ProductStockAlert,
+		true,
+		false,
+		false,
+		false,
+		nil,
+	)
+	if err != nil {
+		log.Errorf("[PublishStockAlert-3] Failed to declare queue: %v", err)
+		return err
+	}
+
+	data, _ := json.Marshal(entity.PublishStockAlertEntity{
+		ProductID:         movement.ProductID,
+		ProductName:       movement.ProductName,
+		Stock:             movement.StockAfter,
+		LowStockThreshold: movement.LowStockThreshold,
+		AlertType:         alertType,
+	})
+	err = ch.Publish(
+		"",
+		q.Name,
+		false,
+		false,
+		amqp.Publishing{
+			ContentType: "application/json",
+			Body:        data,
+		},
+	)
+	if err != nil {
+		log.Errorf("[PublishStockAlert-4] Failed to publish message: %v", err)
+		return err
+	}
+
+	return nil
+}
+
+// StockAlertType mengembalikan out_of_stock atau low_stock bila movement membuat stok melewati batas, selain itu string kosong.
+func StockAlertType(movement entity.StockMovementEntity) string {
+	if movement.Quantity >= 0 {
+		return ""
+	}
+
+	stockBefore := movement.StockAfter - movement.Quantity
+	if movement.StockAfter == 0 {
+		return "out_of_stock"
+	}
+
+	if movement.StockAfter <= movement.LowStockThreshold && stockBefore > movement.LowStockThreshold {
+		return "low_stock"
+	}
+
+	return ""
+}
+
+// PublishStockFailed implements PublishRabbitMQInterface.
+func (p *PublishRabbitMQ) PublishStockFailed(req entity.PublishStockFailedEntity) error {
+	conn, err := p.cfg.NewRabbitMQ()
+	if err != nil {
+		log.Errorf("[PublishStockFailed-1] Failed to connect to RabbitMQ: %v", err)
+		return err
+	}
+
+	defer conn.Close()
+
+	ch, err := conn.Channel()
+	if err != nil {
+		log.Errorf("[PublishStockFailed-2] Failed to open a channel: %v", err)
+		return err
+	}
+
+	defer ch.Close()
+	q, err := ch.QueueDeclare(
+		p.cfg.PublisherName.ProductStockFailed,
+		true,
+		false,
+		false,
+		false,
+		nil,
+	)
+	if err != nil {
+		log.Errorf("[PublishStockFailed-3] Failed to declare queue: %v", err)
+		return err
+	}
+
+	data, _ := json.Marshal(req)
+	err = ch.Publish(
+		"",
+		q.Name,
+		false,
+		false,
+		amqp.Publishing{
+			ContentType:  "application/json",
+			DeliveryMode: amqp.Persistent,
+			Body:         data,
+		},
+	)
+	if err != nil {
+		log.Errorf("[PublishStockFailed-4] Failed to publish message: %v", err)
+		return err
+	}
+
+	return nil
+}
+
 // DeleteProductFromQueue implements PublishRabbitMQInterface.
 func (p *PublishRabbitMQ) DeleteProductFromQueue(productID int64) error {
 	conn, err := p.cfg.NewRabbitMQ()
@@ -21,6 +21,29 @@ type middlewareAdapter struct {
 	cfg *config.Config
 }
 
+// routeRoles memetakan segmen pertama path ke role yang boleh mengaksesnya,
+// grup route yang tidak terdaftar cukup memerlukan token yang valid.
+var routeRoles = map[string][]string{
+	"admin": {"Super Admin"},
+}
+
+// isRoleAllowed mengecek role user terhadap routeRoles untuk path yang diminta.
+func isRoleAllowed(path string, roleName string) bool {
+	segments := strings.Split(strings.Trim(path, "/"), "/")
+	roles, ok := routeRoles[segments[0]]
+	if !ok {
+		return true
+	}
+
+	for _, val := range roles {
+		if val == roleName {
+			return true
+		}
+	}
+
+	return false
+}
+
 // CheckToken implements MiddlewareAdapterInterface.
 func (m *middlewareAdapter) CheckToken() echo.MiddlewareFunc {
 	return func(next echo.HandlerFunc) echo.HandlerFunc {
@@ -69,10 +92,9 @@ func (m *middlewareAdapter) CheckToken() echo.MiddlewareFunc {
 			}
 
 			path := c.Request().URL.Path
-			segments := strings.Split(strings.Trim(path, "/"), "/")
-			if jwtUserData.RoleName == "Customer" && segments[0] == "admin" {
-				log.Infof("[MiddlewareAdapter-5] CheckToken: %s", "customer cannot access admin routes")
-				respErr.Message = "customer cannot access admin routes"
+			if !isRoleAllowed(path, jwtUserData.RoleName) {
+				log.Infof("[MiddlewareAdapter-5] CheckToken: role %s cannot access %s", jwtUserData.RoleName, path)
+				respErr.Message = "role is not allowed to access this route"
 				respErr.Data = nil
 				return c.JSON(http.StatusForbidden, respErr)
 			}
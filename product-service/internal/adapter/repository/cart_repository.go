@@ -13,7 +13,7 @@ import (
 type CartRedisRepositoryInterface interface {
 	AddToCart(ctx context.Context, userID string, items []entity.CartItem) error
 	GetCart(ctx context.Context, userID string) ([]entity.CartItem, error)
-	RemoveFromCart(ctx context.Context, userID int64, productID int64) error
+	RemoveFromCart(ctx context.Context, userID int64, productID int64, variantID int64) error
 	RemoveAllCart(ctx context.Context, userID int64) error
 }
 
@@ -57,7 +57,7 @@ func (c *CartRedisRepository) GetCart(ctx context.Context, userID string) ([]ent
 }
 
 // RemoveFromCart implements CartRedisRepositoryInterface.
-func (c *CartRedisRepository) RemoveFromCart(ctx context.Context, userID int64, productID int64) error {
+func (c *CartRedisRepository) RemoveFromCart(ctx context.Context, userID int64, productID int64, variantID int64) error {
 	cart, err := c.GetCart(ctx, fmt.Sprintf("cart:%d", userID))
 	if err != nil {
 		log.Errorf("[CartRedisRepository-1] RemoveFromCart: %v", err)
@@ -66,7 +66,7 @@ func (c *CartRedisRepository) RemoveFromCart(ctx context.Context, userID int64,
 
 	newCart := []entity.CartItem{}
 	for _, item := range cart {
-		if item.ProductID != productID {
+		if item.ProductID != productID || item.VariantID != variantID {
 			newCart = append(newCart, item)
 		}
 	}
@@ -110,6 +110,7 @@ func (c *categoryRepository) EditCategory(ctx context.Context, req entity.Catego
 	modelCategory.Status = status
 	modelCategory.Slug = req.Slug
 	modelCategory.Description = req.Description
+	modelCategory.TaxRate = req.TaxRate
 	if err := c.db.Save(&modelCategory).Error; err != nil {
 		log.Errorf("[CategoryRepository-3] EditCategory: %v", err)
 		return err
@@ -131,6 +132,7 @@ func (c *categoryRepository) CreateCategory(ctx context.Context, req entity.Cate
 		Status:      status,
 		Slug:        req.Slug,
 		Description: req.Description,
+		TaxRate:     req.TaxRate,
 	}
 
 	if err := c.db.Create(&modelCategory).Error; err != nil {
@@ -166,6 +168,7 @@ func (c *categoryRepository) GetBySlug(ctx context.Context, slug string) (*entit
 		Status:      status,
 		Slug:        modelCategory.Slug,
 		Description: modelCategory.Description,
+		TaxRate:     modelCategory.TaxRate,
 	}, nil
 }
 
@@ -195,6 +198,7 @@ func (c *categoryRepository) GetByID(ctx context.Context, categoryID int64) (*en
 		Status:      status,
 		Slug:        modelCategory.Slug,
 		Description: modelCategory.Description,
+		TaxRate:     modelCategory.TaxRate,
 	}, nil
 }
 
@@ -250,6 +254,7 @@ func (c *categoryRepository) GetAll(ctx context.Context, query entity.QueryStrin
 			Status:      status,
 			Slug:        val.Slug,
 			Description: val.Description,
+			TaxRate:     val.TaxRate,
 			Products:    productEntities,
 		})
 	}
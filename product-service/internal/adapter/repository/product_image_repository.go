@@ -0,0 +1,284 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"product-service/internal/core/domain/entity"
+	"product-service/internal/core/domain/model"
+
+	"github.com/labstack/gommon/log"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type ProductImageRepositoryInterface interface {
+	GetByProductID(ctx context.Context, productID int64) ([]entity.ProductImageEntity, error)
+	Attach(ctx context.Context, req entity.ProductImageEntity) (*entity.ProductImageEntity, error)
+	Reorder(ctx context.Context, productID int64, imageIDs []int64) error
+	SetPrimary(ctx context.Context, productID int64, imageID int64) error
+	Remove(ctx context.Context, productID int64, imageID int64) (*entity.ProductImageEntity, error)
+}
+
+type productImageRepository struct {
+	db *gorm.DB
+}
+
+// GetByProductID implements ProductImageRepositoryInterface.
+func (p *productImageRepository) GetByProductID(ctx context.Context, productID int64) ([]entity.ProductImageEntity, error) {
+	modelImages := []model.ProductImage{}
+	if err := p.db.WithContext(ctx).Where("product_id = ?", productID).Order("position ASC, id ASC").Find(&modelImages).Error; err != nil {
+		log.Errorf("[ProductImageRepository-1] GetByProductID: %v", err)
+		return nil, err
+	}
+
+	results := []entity.ProductImageEntity{}
+	for _, val := range modelImages {
+		results = append(results, toProductImageEntity(val))
+	}
+
+	return results, nil
+}
+
+// Attach implements ProductImageRepositoryInterface.
+// Gambar pertama pada galeri otomatis menjadi primary.
+func (p *productImageRepository) Attach(ctx context.Context, req entity.ProductImageEntity) (*entity.ProductImageEntity, error) {
+	modelImage := model.ProductImage{
+		ProductID:    req.ProductID,
+		URL:          req.URL,
+		ThumbnailURL: req.ThumbnailURL,
+		MediumURL:    req.MediumURL,
+		AltText:      req.AltText,
+		IsPrimary:    req.IsPrimary,
+	}
+
+	err := p.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := lockProduct(tx, req.ProductID); err != nil {
+			return err
+		}
+
+		var count int64
+		if err := tx.Model(&model.ProductImage{}).Where("product_id = ?", req.ProductID).Count(&count).Error; err != nil {
+			return err
+		}
+
+		var maxPosition int
+		if err := tx.Model(&model.ProductImage{}).Select("COALESCE(MAX(position), -1)").Where("product_id = ?", req.ProductID).Scan(&maxPosition).Error; err != nil {
+			return err
+		}
+
+		modelImage.Position = maxPosition + 1
+		if count == 0 {
+			modelImage.IsPrimary = true
+		}
+
+		if modelImage.IsPrimary {
+			if err := tx.Model(&model.ProductImage{}).Where("product_id = ?", req.ProductID).Update("is_primary", false).Error; err != nil {
+				return err
+			}
+		}
+
+		if err := tx.Create(&modelImage).Error; err != nil {
+			return err
+		}
+
+		if modelImage.IsPrimary {
+			return tx.Model(&model.Product{}).Where("id = ?", req.ProductID).Update("image", modelImage.URL).Error
+		}
+
+		return nil
+	})
+	if err != nil {
+		log.Errorf("[ProductImageRepository-1] Attach: %v", err)
+		return nil, err
+	}
+
+	result := toProductImageEntity(modelImage)
+	return &result, nil
+}
+
+// Reorder implements ProductImageRepositoryInterface.
+// imageIDs harus berisi seluruh gambar produk, posisi mengikuti urutan array.
+func (p *productImageRepository) Reorder(ctx context.Context, productID int64, imageIDs []int64) error {
+	err := p.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := lockProduct(tx, productID); err != nil {
+			return err
+		}
+
+		currentIDs := []int64{}
+		if err := tx.Model(&model.ProductImage{}).Where("product_id = ?", productID).Pluck("id", &currentIDs).Error; err != nil {
+			return err
+		}
+
+		if len(currentIDs) != len(imageIDs) {
+			return errors.New("422")
+		}
+
+		exists := map[int64]bool{}
+		for _, id := range currentIDs {
+			exists[id] = true
+		}
+
+		for position, id := range imageIDs {
+			if !exists[id] {
+				return errors.New("422")
+			}
+			delete(exists, id)
+
+			if err := tx.Model(&model.ProductImage{}).Where("id = ?", id).Update("position", position).Error; err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		log.Errorf("[ProductImageRepository-1] Reorder: %v", err)
+		return err
+	}
+
+	return nil
+}
+
+// SetPrimary implements ProductImageRepositoryInterface.
+func (p *productImageRepository) SetPrimary(ctx context.Context, productID int64, imageID int64) error {
+	err := p.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := lockProduct(tx, productID); err != nil {
+			return err
+		}
+
+		modelImage := model.ProductImage{}
+		if err := tx.Where("id = ? AND product_id = ?", imageID, productID).First(&modelImage).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return errors.New("404")
+			}
+			return err
+		}
+
+		return setPrimaryImage(tx, modelImage)
+	})
+	if err != nil {
+		log.Errorf("[ProductImageRepository-1] SetPrimary: %v", err)
+		return err
+	}
+
+	return nil
+}
+
+// Remove implements ProductImageRepositoryInterface.
+// Bila gambar primary dihapus, gambar dengan posisi paling awal menjadi primary.
+func (p *productImageRepository) Remove(ctx context.Context, productID int64, imageID int64) (*entity.ProductImageEntity, error) {
+	modelImage := model.ProductImage{}
+	err := p.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := lockProduct(tx, productID); err != nil {
+			return err
+		}
+
+		if err := tx.Where("id = ? AND product_id = ?", imageID, productID).First(&modelImage).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return errors.New("404")
+			}
+			return err
+		}
+
+		var count int64
+		if err := tx.Model(&model.ProductImage{}).Where("product_id = ?", productID).Count(&count).Error; err != nil {
+			return err
+		}
+
+		// Product.Image wajib terisi sehingga gambar terakhir tidak boleh dihapus
+		if count <= 1 {
+			return errors.New("409")
+		}
+
+		if err := tx.Delete(&modelImage).Error; err != nil {
+			return err
+		}
+
+		if !modelImage.IsPrimary {
+			return nil
+		}
+
+		nextImage := model.ProductImage{}
+		if err := tx.Where("product_id = ?", productID).Order("position ASC, id ASC").First(&nextImage).Error; err != nil {
+			return err
+		}
+
+		return setPrimaryImage(tx, nextImage)
+	})
+	if err != nil {
+		log.Errorf("[ProductImageRepository-1] Remove: %v", err)
+		return nil, err
+	}
+
+	result := toProductImageEntity(modelImage)
+	return &result, nil
+}
+
+func lockProduct(tx *gorm.DB, productID int64) error {
+	product := model.Product{}
+	if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Select("id").First(&product, "id = ?", productID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return errors.New("404")
+		}
+		return err
+	}
+
+	return nil
+}
+
+func setPrimaryImage(tx *gorm.DB, modelImage model.ProductImage) error {
+	if err := tx.Model(&model.ProductImage{}).Where("product_id = ? AND id <> ?", modelImage.ProductID, modelImage.ID).Update("is_primary", false).Error; err != nil {
+		return err
+	}
+
+	if err := tx.Model(&model.ProductImage{}).Where("id = ?", modelImage.ID).Update("is_primary", true).Error; err != nil {
+		return err
+	}
+
+	return tx.Model(&model.Product{}).Where("id = ?", modelImage.ProductID).Update("image", modelImage.URL).Error
+}
+
+// syncPrimaryImage menyamakan galeri dengan Product.Image yang diubah dari form produk.
+// Produk tanpa galeri mendapat gambar tersebut sebagai gambar primary pertama.
+func syncPrimaryImage(tx *gorm.DB, productID int64, url string) error {
+	if url == "" {
+		return nil
+	}
+
+	modelImage := model.ProductImage{}
+	err := tx.Where("product_id = ? AND is_primary = ?", productID, true).First(&modelImage).Error
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return err
+	}
+
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return tx.Create(&model.ProductImage{
+			ProductID: productID,
+			URL:       url,
+			IsPrimary: true,
+		}).Error
+	}
+
+	if modelImage.URL == url {
+		return nil
+	}
+
+	return tx.Model(&modelImage).Update("url", url).Error
+}
+
+func toProductImageEntity(val model.ProductImage) entity.ProductImageEntity {
+	return entity.ProductImageEntity{
+		ID:           val.ID,
+		ProductID:    val.ProductID,
+		URL:          val.URL,
+		ThumbnailURL: val.ThumbnailURL,
+		MediumURL:    val.MediumURL,
+		AltText:      val.AltText,
+		Position:     val.Position,
+		IsPrimary:    val.IsPrimary,
+	}
+}
+
+func NewProductImageRepository(db *gorm.DB) ProductImageRepositoryInterface {
+	return &productImageRepository{db: db}
+}
@@ -0,0 +1,145 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"product-service/internal/core/domain/entity"
+	"product-service/internal/core/domain/model"
+	"time"
+
+	"github.com/labstack/gommon/log"
+	"gorm.io/gorm"
+)
+
+type ProductImportRepositoryInterface interface {
+	CreateJob(ctx context.Context, req entity.ProductImportJobEntity) (int64, error)
+	UpdateJob(ctx context.Context, req entity.ProductImportJobEntity) error
+	GetJobByID(ctx context.Context, jobID int64) (*entity.ProductImportJobEntity, error)
+	FailUnfinishedJobs(ctx context.Context, message string) (int64, error)
+}
+
+type productImportRepository struct {
+	db *gorm.DB
+}
+
+// CreateJob implements ProductImportRepositoryInterface.
+func (p *productImportRepository) CreateJob(ctx context.Context, req entity.ProductImportJobEntity) (int64, error) {
+	modelJob, err := toProductImportJobModel(req)
+	if err != nil {
+		log.Errorf("[ProductImportRepository-1] CreateJob: %v", err)
+		return 0, err
+	}
+
+	if err := p.db.WithContext(ctx).Create(&modelJob).Error; err != nil {
+		log.Errorf("[ProductImportRepository-2] CreateJob: %v", err)
+		return 0, err
+	}
+
+	return modelJob.ID, nil
+}
+
+// UpdateJob implements ProductImportRepositoryInterface.
+func (p *productImportRepository) UpdateJob(ctx context.Context, req entity.ProductImportJobEntity) error {
+	modelJob, err := toProductImportJobModel(req)
+	if err != nil {
+		log.Errorf("[ProductImportRepository-1] UpdateJob: %v", err)
+		return err
+	}
+
+	if err := p.db.WithContext(ctx).Model(&model.ProductImportJob{}).Where("id = ?", req.ID).Updates(map[string]interface{}{
+		"status":       modelJob.Status,
+		"total_rows":   modelJob.TotalRows,
+		"success_rows": modelJob.SuccessRows,
+		"failed_rows":  modelJob.FailedRows,
+		"errors":       modelJob.Errors,
+		"finished_at":  modelJob.FinishedAt,
+	}).Error; err != nil {
+		log.Errorf("[ProductImportRepository-2] UpdateJob: %v", err)
+		return err
+	}
+
+	return nil
+}
+
+// GetJobByID implements ProductImportRepositoryInterface.
+func (p *productImportRepository) GetJobByID(ctx context.Context, jobID int64) (*entity.ProductImportJobEntity, error) {
+	modelJob := model.ProductImportJob{}
+	if err := p.db.WithContext(ctx).First(&modelJob, "id = ?", jobID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			err = errors.New("404")
+		}
+		log.Errorf("[ProductImportRepository-1] GetJobByID: %v", err)
+		return nil, err
+	}
+
+	importErrors := []entity.ProductImportErrorEntity{}
+	if err := json.Unmarshal([]byte(modelJob.Errors), &importErrors); err != nil {
+		log.Errorf("[ProductImportRepository-2] GetJobByID: %v", err)
+		return nil, err
+	}
+
+	return &entity.ProductImportJobEntity{
+		ID:          modelJob.ID,
+		FileName:    modelJob.FileName,
+		Status:      modelJob.Status,
+		TotalRows:   modelJob.TotalRows,
+		SuccessRows: modelJob.SuccessRows,
+		FailedRows:  modelJob.FailedRows,
+		Errors:      importErrors,
+		CreatedBy:   modelJob.CreatedBy,
+		CreatedAt:   modelJob.CreatedAt,
+		FinishedAt:  modelJob.FinishedAt,
+	}, nil
+}
+
+// FailUnfinishedJobs implements ProductImportRepositoryInterface.
+// Baris yang belum diproses dihitung gagal dan message ditambahkan ke laporan error job.
+func (p *productImportRepository) FailUnfinishedJobs(ctx context.Context, message string) (int64, error) {
+	importErrors, err := json.Marshal([]entity.ProductImportErrorEntity{{Message: message}})
+	if err != nil {
+		log.Errorf("[ProductImportRepository-1] FailUnfinishedJobs: %v", err)
+		return 0, err
+	}
+
+	result := p.db.WithContext(ctx).Model(&model.ProductImportJob{}).Where("status IN ?", []string{"pending", "processing"}).Updates(map[string]interface{}{
+		"status":      "failed",
+		"failed_rows": gorm.Expr("total_rows - success_rows"),
+		"errors":      gorm.Expr("errors || ?::jsonb", string(importErrors)),
+		"finished_at": time.Now(),
+	})
+	if result.Error != nil {
+		log.Errorf("[ProductImportRepository-2] FailUnfinishedJobs: %v", result.Error)
+		return 0, result.Error
+	}
+
+	return result.RowsAffected, nil
+}
+
+func toProductImportJobModel(req entity.ProductImportJobEntity) (model.ProductImportJob, error) {
+	if req.Errors == nil {
+		req.Errors = []entity.ProductImportErrorEntity{}
+	}
+
+	importErrors, err := json.Marshal(req.Errors)
+	if err != nil {
+		return model.ProductImportJob{}, err
+	}
+
+	return model.ProductImportJob{
+		ID:          req.ID,
+		FileName:    req.FileName,
+		Status:      req.Status,
+		TotalRows:   req.TotalRows,
+		SuccessRows: req.SuccessRows,
+		FailedRows:  req.FailedRows,
+		Errors:      string(importErrors),
+		CreatedBy:   req.CreatedBy,
+		CreatedAt:   req.CreatedAt,
+		FinishedAt:  req.FinishedAt,
+	}, nil
+}
+
+func NewProductImportRepository(db *gorm.DB) ProductImportRepositoryInterface {
+	return &productImportRepository{db: db}
+}
@@ -20,9 +20,12 @@ type ProductRepositoryInterface interface {
 	GetAll(ctx context.Context, query entity.QueryStringProduct) ([]entity.ProductEntity, int64, int64, error)
 	GetByID(ctx context.Context, productID int64) (*entity.ProductEntity, error)
 	Create(ctx context.Context, req entity.ProductEntity) (int64, error)
-	Update(ctx context.Context, req entity.ProductEntity) error
+	Update(ctx context.Context, req entity.ProductEntity) ([]entity.StockMovementEntity, error)
 	Delete(ctx context.Context, productID int64) error
 	SearchProducts(ctx context.Context, query entity.QueryStringProduct) ([]entity.ProductEntity, int64, int64, error)
+	GetLowStock(ctx context.Context, query entity.QueryStringProduct) ([]entity.ProductEntity, int64, int64, error)
+	GetBySKU(ctx context.Context, sku string) (*entity.ProductEntity, error)
+	GetAllForExport(ctx context.Context) ([]entity.ProductEntity, error)
 }
 
 type productRepository struct {
@@ -30,6 +33,97 @@ type productRepository struct {
 	esClient *elasticsearch.Client
 }
 
+// GetBySKU implements ProductRepositoryInterface.
+func (p *productRepository) GetBySKU(ctx context.Context, sku string) (*entity.ProductEntity, error) {
+	modelProduct := model.Product{}
+	if err := p.db.WithContext(ctx).Where("sku = ?", sku).First(&modelProduct).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			err = errors.New("404")
+		}
+		log.Errorf("[ProductRepository-1] GetBySKU: %v", err)
+		return nil, err
+	}
+
+	return &entity.ProductEntity{
+		ID:           modelProduct.ID,
+		ParentID:     modelProduct.ParentID,
+		SKU:          modelProduct.SKU,
+		Name:         modelProduct.Name,
+		CategorySlug: modelProduct.CategorySlug,
+	}, nil
+}
+
+// GetAllForExport implements ProductRepositoryInterface.
+// Detail tiap produk parent dimuat lewat GetByID agar varian dan opsinya sama dengan detail admin.
+func (p *productRepository) GetAllForExport(ctx context.Context) ([]entity.ProductEntity, error) {
+	productIDs := []int64{}
+	if err := p.db.WithContext(ctx).Model(&model.Product{}).Where("parent_id IS NULL").Order("id ASC").Pluck("id", &productIDs).Error; err != nil {
+		log.Errorf("[ProductRepository-1] GetAllForExport: %v", err)
+		return nil, err
+	}
+
+	results := []entity.ProductEntity{}
+	for _, productID := range productIDs {
+		product, err := p.GetByID(ctx, productID)
+		if err != nil {
+			log.Errorf("[ProductRepository-2] GetAllForExport: %v", err)
+			return nil, err
+		}
+		results = append(results, *product)
+	}
+
+	return results, nil
+}
+
+// GetLowStock implements ProductRepositoryInterface.
+// Setiap varian adalah baris produk sendiri sehingga parent dan child dicek terhadap batasnya masing-masing.
+func (p *productRepository) GetLowStock(ctx context.Context, query entity.QueryStringProduct) ([]entity.ProductEntity, int64, int64, error) {
+	modelProducts := []model.Product{}
+	var countData int64
+
+	offset := (query.Page - 1) * query.Limit
+	sqlMain := p.db.WithContext(ctx).Preload("Category").Where("stock <= low_stock_threshold")
+	if query.CategorySlug != "" {
+		sqlMain = sqlMain.Where("category_slug = ?", query.CategorySlug)
+	}
+
+	if err := sqlMain.Model(&modelProducts).Count(&countData).Error; err != nil {
+		log.Errorf("[ProductRepository-1] GetLowStock: %v", err)
+		return nil, 0, 0, err
+	}
+
+	totalPage := int(math.Ceil(float64(countData) / float64(query.Limit)))
+	if err := sqlMain.Order("stock ASC, id ASC").Limit(query.Limit).Offset(offset).Find(&modelProducts).Error; err != nil {
+		log.Errorf("[ProductRepository-2] GetLowStock: %v", err)
+		return nil, 0, 0, err
+	}
+
+	if len(modelProducts) == 0 {
+		log.Errorf("[ProductRepository-3] GetLowStock: %v", "Data not found")
+		return nil, 0, 0, errors.New("404")
+	}
+
+	respProducts := []entity.ProductEntity{}
+	for _, val := range modelProducts {
+		respProducts = append(respProducts, entity.ProductEntity{
+			ID:                val.ID,
+			CategorySlug:      val.CategorySlug,
+			ParentID:          val.ParentID,
+			Name:              val.Name,
+			Image:             val.Image,
+			Unit:              val.Unit,
+			Weight:            val.Weight,
+			Stock:             val.Stock,
+			LowStockThreshold: val.LowStockThreshold,
+			Status:            val.Status,
+			CategoryName:      val.Category.Name,
+			CreatedAt:         val.CreatedAt,
+		})
+	}
+
+	return respProducts, countData, int64(totalPage), nil
+}
+
 // Delete implements ProductRepositoryInterface.
 func (p *productRepository) Delete(ctx context.Context, productID int64) error {
 	modelProduct := model.Product{}
@@ -64,7 +158,8 @@ func (p *productRepository) Delete(ctx context.Context, productID int64) error {
 }
 
 // Update implements ProductRepositoryInterface.
-func (p *productRepository) Update(ctx context.Context, req entity.ProductEntity) error {
+// Mengembalikan perubahan stok dari form agar pemanggil bisa mengirim alert stok.
+func (p *productRepository) Update(ctx context.Context, req entity.ProductEntity) ([]entity.StockMovementEntity, error) {
 	modelProduct := model.Product{}
 
 	if err := p.db.Where("id = ?", req.ID).First(&modelProduct).Error; err != nil {
@@ -72,105 +167,160 @@ func (p *productRepository) Update(ctx context.Context, req entity.ProductEntity
 			err = errors.New("404")
 		}
 		log.Errorf("[ProductRepository-1] Update: %v", err)
-		return err
+		return nil, err
 	}
 
 	modelProduct.CategorySlug = req.CategorySlug
 	modelProduct.ParentID = req.ParentID
 	modelProduct.Name = req.Name
+	modelProduct.SKU = req.SKU
 	modelProduct.Image = req.Image
 	modelProduct.Description = req.Description
 	modelProduct.RegulerPrice = req.RegulerPrice
 	modelProduct.SalePrice = req.SalePrice
 	modelProduct.Unit = req.Unit
 	modelProduct.Weight = req.Weight
-	modelProduct.Stock = req.Stock
 	modelProduct.Variant = req.Variant
 	modelProduct.Status = req.Status
+	if req.LowStockThreshold > 0 {
+		modelProduct.LowStockThreshold = req.LowStockThreshold
+	}
+
+	// Stok tidak disimpan langsung, perubahan stok dicatat sebagai adjustment di ledger.
+	// Rating hanya dihitung ulang dari ulasan yang disetujui.
+	stockDiff := req.Stock - modelProduct.Stock
+	if err := p.db.Omit("stock", "rating_avg", "rating_count").Save(&modelProduct).Error; err != nil {
+		if errors.Is(err, gorm.ErrDuplicatedKey) {
+			err = errors.New("409")
+		}
+		log.Errorf("[ProductRepository-2] Update: %v", err)
+		return nil, err
+	}
 
-	if err := p.db.Save(&modelProduct).Error; err != nil {
+	if err := syncPrimaryImage(p.db, modelProduct.ID, modelProduct.Image); err != nil {
 		log.Errorf("[ProductRepository-2] Update: %v", err)
-		return err
+		return nil, err
 	}
 
-	if len(req.Child) > 0 {
-		if err := p.db.Where("parent_id = ?", modelProduct.ID).Delete(&model.Product{}).Error; err != nil {
-			log.Errorf("[ProductRepository-3] Update: %v", err)
-			return err
-		}
+	valueIDs, err := saveProductOptions(p.db, modelProduct.ID, req.Options)
+	if err != nil {
+		log.Errorf("[ProductRepository-3] Update: %v", err)
+		return nil, err
+	}
 
-		modelProductChild := []model.Product{}
-		for _, val := range req.Child {
-			modelProductChild = append(modelProductChild, model.Product{
-				CategorySlug: req.CategorySlug,
-				ParentID:     &modelProduct.ID,
-				Name:         req.Name,
-				Image:        val.Image,
-				Description:  req.Description,
-				RegulerPrice: val.RegulerPrice,
-				SalePrice:    val.SalePrice,
-				Unit:         req.Unit,
-				Weight:       val.Weight,
-				Stock:        val.Stock,
-				Variant:      req.Variant,
-				Status:       req.Status,
-			})
-		}
+	if err := saveVariantValues(p.db, modelProduct.ID, req.OptionValues, valueIDs); err != nil {
+		log.Errorf("[ProductRepository-4] Update: %v", err)
+		return nil, err
+	}
 
-		if err := p.db.Create(&modelProductChild).Error; err != nil {
-			log.Errorf("[ProductRepository-3] Update: %v", err)
-			return err
-		}
+	movements := []entity.StockMovementEntity{}
+	movement, err := adjustStockFromForm(p.db, modelProduct.ID, stockDiff, req.UpdatedBy)
+	if err != nil {
+		log.Errorf("[ProductRepository-5] Update: %v", err)
+		return nil, err
+	}
+	if movement != nil {
+		movements = append(movements, *movement)
 	}
 
-	return nil
+	childMovements, err := p.saveVariants(modelProduct, req, valueIDs)
+	if err != nil {
+		log.Errorf("[ProductRepository-6] Update: %v", err)
+		return nil, err
+	}
+
+	return append(movements, childMovements...), nil
 }
 
 // Create implements ProductRepositoryInterface.
 func (p *productRepository) Create(ctx context.Context, req entity.ProductEntity) (int64, error) {
 	modelProduct := model.Product{
-		CategorySlug: req.CategorySlug,
-		ParentID:     req.ParentID,
-		Name:         req.Name,
-		Image:        req.Image,
-		Description:  req.Description,
-		RegulerPrice: req.RegulerPrice,
-		SalePrice:    req.SalePrice,
-		Unit:         req.Unit,
-		Weight:       req.Weight,
-		Stock:        req.Stock,
-		Variant:      req.Variant,
-		Status:       req.Status,
+		CategorySlug:      req.CategorySlug,
+		ParentID:          req.ParentID,
+		Name:              req.Name,
+		SKU:               req.SKU,
+		Image:             req.Image,
+		Description:       req.Description,
+		RegulerPrice:      req.RegulerPrice,
+		SalePrice:         req.SalePrice,
+		Unit:              req.Unit,
+		Weight:            req.Weight,
+		Stock:             req.Stock,
+		LowStockThreshold: req.LowStockThreshold,
+		Variant:           req.Variant,
+		Status:            req.Status,
 	}
 
 	if err := p.db.Create(&modelProduct).Error; err != nil {
+		if errors.Is(err, gorm.ErrDuplicatedKey) {
+			err = errors.New("409")
+		}
 		log.Errorf("[ProductRepository-1] Create: %v", err)
 		return 0, err
 	}
 
+	if err := openingStockMovement(p.db, modelProduct, req.UpdatedBy, "product-create"); err != nil {
+		log.Errorf("[ProductRepository-2] Create: %v", err)
+		return 0, err
+	}
+
+	// Gambar utama dari form produk menjadi gambar primary pertama di galeri
+	if err := syncPrimaryImage(p.db, modelProduct.ID, modelProduct.Image); err != nil {
+		log.Errorf("[ProductRepository-2] Create: %v", err)
+		return 0, err
+	}
+
+	valueIDs, err := saveProductOptions(p.db, modelProduct.ID, req.Options)
+	if err != nil {
+		log.Errorf("[ProductRepository-3] Create: %v", err)
+		return 0, err
+	}
+
+	if err := saveVariantValues(p.db, modelProduct.ID, req.OptionValues, valueIDs); err != nil {
+		log.Errorf("[ProductRepository-4] Create: %v", err)
+		return 0, err
+	}
+
 	if len(req.Child) > 0 {
 		modelProductChild := []model.Product{}
 		for _, val := range req.Child {
 			modelProductChild = append(modelProductChild, model.Product{
-				CategorySlug: req.CategorySlug,
-				ParentID:     &modelProduct.ID,
-				Name:         req.Name,
-				Image:        val.Image,
-				Description:  req.Description,
-				RegulerPrice: val.RegulerPrice,
-				SalePrice:    val.SalePrice,
-				Unit:         req.Unit,
-				Weight:       val.Weight,
-				Stock:        val.Stock,
-				Variant:      req.Variant,
-				Status:       req.Status,
+				CategorySlug:      req.CategorySlug,
+				ParentID:          &modelProduct.ID,
+				Name:              req.Name,
+				SKU:               val.SKU,
+				Image:             val.Image,
+				Description:       req.Description,
+				RegulerPrice:      val.RegulerPrice,
+				SalePrice:         val.SalePrice,
+				Unit:              req.Unit,
+				Weight:            val.Weight,
+				Stock:             val.Stock,
+				LowStockThreshold: val.LowStockThreshold,
+				Variant:           req.Variant,
+				Status:            req.Status,
 			})
 		}
 
 		if err := p.db.Create(&modelProductChild).Error; err != nil {
-			log.Errorf("[ProductRepository-2] Create: %v", err)
+			if errors.Is(err, gorm.ErrDuplicatedKey) {
+				err = errors.New("409")
+			}
+			log.Errorf("[ProductRepository-5] Create: %v", err)
 			return 0, err
 		}
+
+		for i, val := range modelProductChild {
+			if err := openingStockMovement(p.db, val, req.UpdatedBy, "product-create"); err != nil {
+				log.Errorf("[ProductRepository-6] Create: %v", err)
+				return 0, err
+			}
+
+			if err := saveVariantValues(p.db, val.ID, req.Child[i].OptionValues, valueIDs); err != nil {
+				log.Errorf("[ProductRepository-7] Create: %v", err)
+				return 0, err
+			}
+		}
 	}
 
 	return modelProduct.ID, nil
@@ -180,7 +330,10 @@ func (p *productRepository) Create(ctx context.Context, req entity.ProductEntity
 func (p *productRepository) GetByID(ctx context.Context, productID int64) (*entity.ProductEntity, error) {
 	modelProduct := model.Product{}
 
-	if err := p.db.WithContext(ctx).Preload("Category").First(&modelProduct, "id = ?", productID).Error; err != nil {
+	err := p.db.WithContext(ctx).Preload("Category").Preload("Images", func(db *gorm.DB) *gorm.DB {
+		return db.Order("position ASC, id ASC")
+	}).First(&modelProduct, "id = ?", productID).Error
+	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			err = errors.New("404")
 		}
@@ -189,51 +342,83 @@ func (p *productRepository) GetByID(ctx context.Context, productID int64) (*enti
 	}
 
 	modelParent := []model.Product{}
-	err := p.db.WithContext(ctx).Preload("Category").Where("parent_id = ?", modelProduct.ID).Find(&modelParent).Error
+	err = p.db.WithContext(ctx).Preload("Category").Where("parent_id = ?", modelProduct.ID).Find(&modelParent).Error
 	if err != nil {
 		log.Errorf("[ProductRepository-2] GetByID: %v", err)
 		return nil, err
 	}
 
+	// Opsi varian disimpan pada parent, detail child memakai opsi milik parent-nya
+	optionOwnerID := modelProduct.ID
+	if modelProduct.ParentID != nil {
+		optionOwnerID = *modelProduct.ParentID
+	}
+
+	productIDs := []int64{modelProduct.ID}
+	for _, val := range modelParent {
+		productIDs = append(productIDs, val.ID)
+	}
+
+	options, optionValues, err := p.loadVariantOptions(ctx, optionOwnerID, productIDs)
+	if err != nil {
+		log.Errorf("[ProductRepository-3] GetByID: %v", err)
+		return nil, err
+	}
+
 	childEntities := []entity.ProductEntity{}
 	for _, val := range modelParent {
 		childEntities = append(childEntities, entity.ProductEntity{
-			ID:           val.ID,
-			CategorySlug: val.CategorySlug,
-			ParentID:     val.ParentID,
-			Name:         val.Name,
-			Image:        val.Image,
-			Description:  val.Description,
-			RegulerPrice: val.RegulerPrice,
-			SalePrice:    val.SalePrice,
-			Unit:         val.Unit,
-			Weight:       val.Weight,
-			Stock:        val.Stock,
-			Variant:      val.Variant,
-			Status:       val.Status,
-			CategoryName: val.Category.Name,
-			Child:        childEntities,
-			CreatedAt:    val.CreatedAt,
+			ID:                val.ID,
+			CategorySlug:      val.CategorySlug,
+			ParentID:          val.ParentID,
+			Name:              val.Name,
+			SKU:               val.SKU,
+			Image:             val.Image,
+			Description:       val.Description,
+			RegulerPrice:      val.RegulerPrice,
+			SalePrice:         val.SalePrice,
+			Unit:              val.Unit,
+			Weight:            val.Weight,
+			Stock:             val.Stock,
+			LowStockThreshold: val.LowStockThreshold,
+			Variant:           val.Variant,
+			Status:            val.Status,
+			CategoryName:      val.Category.Name,
+			Child:             childEntities,
+			OptionValues:      optionValues[val.ID],
+			CreatedAt:         val.CreatedAt,
 		})
 	}
 
+	images := []entity.ProductImageEntity{}
+	for _, val := range modelProduct.Images {
+		images = append(images, toProductImageEntity(val))
+	}
+
 	return &entity.ProductEntity{
-		ID:           modelProduct.ID,
-		CategorySlug: modelProduct.CategorySlug,
-		ParentID:     modelProduct.ParentID,
-		Name:         modelProduct.Name,
-		Image:        modelProduct.Image,
-		Description:  modelProduct.Description,
-		RegulerPrice: modelProduct.RegulerPrice,
-		SalePrice:    modelProduct.SalePrice,
-		Unit:         modelProduct.Unit,
-		Weight:       modelProduct.Weight,
-		Stock:        modelProduct.Stock,
-		Variant:      modelProduct.Variant,
-		Status:       modelProduct.Status,
-		CategoryName: modelProduct.Category.Name,
-		Child:        childEntities,
-		CreatedAt:    modelProduct.CreatedAt,
+		ID:                modelProduct.ID,
+		CategorySlug:      modelProduct.CategorySlug,
+		ParentID:          modelProduct.ParentID,
+		Name:              modelProduct.Name,
+		SKU:               modelProduct.SKU,
+		Image:             modelProduct.Image,
+		Description:       modelProduct.Description,
+		RegulerPrice:      modelProduct.RegulerPrice,
+		SalePrice:         modelProduct.SalePrice,
+		Unit:              modelProduct.Unit,
+		Weight:            modelProduct.Weight,
+		Stock:             modelProduct.Stock,
+		LowStockThreshold: modelProduct.LowStockThreshold,
+		Variant:           modelProduct.Variant,
+		Status:            modelProduct.Status,
+		CategoryName:      modelProduct.Category.Name,
+		RatingAvg:         modelProduct.RatingAvg,
+		RatingCount:       modelProduct.RatingCount,
+		Child:             childEntities,
+		Options:           options,
+		OptionValues:      optionValues[modelProduct.ID],
+		Images:            images,
+		CreatedAt:         modelProduct.CreatedAt,
 	}, nil
 }
 
@@ -256,6 +441,10 @@ func (p *productRepository) SearchProducts(ctx context.Context, query entity.Que
 
 	// Menyusun bagian sort query
 	sortQuery := fmt.Sprintf(`{ "%s": "%s" }`, sortField, sortOrder)
+	if sortField == "rating_avg" {
+		// Dokumen lama belum memiliki field rating, unmapped_type mencegah error saat sorting
+		sortQuery = fmt.Sprintf(`{ "rating_avg": { "order": "%s", "unmapped_type": "float" } }, { "rating_count": { "order": "%s", "unmapped_type": "integer" } }`, sortOrder, sortOrder)
+	}
 
 	if query.CategorySlug != "" {
 		filterQueries = append(filterQueries, fmt.Sprintf(`{ "term": { "category_slug.keyword": "%s" } }`, query.CategorySlug))
@@ -265,6 +454,10 @@ func (p *productRepository) SearchProducts(ctx context.Context, query entity.Que
 		filterQueries = append(filterQueries, fmt.Sprintf(`{ "range": { "reguler_price": { "gte": %d, "lte": %d } } }`, query.StartPrice, query.EndPrice))
 	}
 
+	if query.MinRating > 0 {
+		filterQueries = append(filterQueries, fmt.Sprintf(`{ "range": { "rating_avg": { "gte": %g } } }`, query.MinRating))
+	}
+
 	if query.Search != "" {
 		mainQueries = append(mainQueries, fmt.Sprintf(`{ "multi_match": { "query": "%s", "fields": ["name", "description", "category_name"] } }`, query.Search))
 	}
@@ -364,6 +557,10 @@ func (p *productRepository) GetAll(ctx context.Context, query entity.QueryString
 		sqlMain = sqlMain.Where("sale_price <= ?", query.EndPrice)
 	}
 
+	if query.MinRating > 0 {
+		sqlMain = sqlMain.Where("rating_avg >= ?", query.MinRating)
+	}
+
 	if err := sqlMain.Model(&modelProducts).Count(&countData).Error; err != nil {
 		log.Errorf("[ProductRepository-1] GetAll: %v", err)
 		return nil, 0, 0, err
@@ -383,27 +580,304 @@ func (p *productRepository) GetAll(ctx context.Context, query entity.QueryString
 	respProducts := []entity.ProductEntity{}
 	for _, val := range modelProducts {
 		respProducts = append(respProducts, entity.ProductEntity{
-			ID:           val.ID,
-			CategorySlug: val.CategorySlug,
-			ParentID:     val.ParentID,
-			Name:         val.Name,
-			Image:        val.Image,
-			Description:  val.Description,
-			RegulerPrice: val.RegulerPrice,
-			SalePrice:    val.SalePrice,
-			Unit:         val.Unit,
-			Weight:       val.Weight,
-			Stock:        val.Stock,
-			Variant:      val.Variant,
-			Status:       val.Status,
-			CategoryName: val.Category.Name,
-			CreatedAt:    val.CreatedAt,
+			ID:                val.ID,
+			CategorySlug:      val.CategorySlug,
+			ParentID:          val.ParentID,
+			Name:              val.Name,
+			Image:             val.Image,
+			Description:       val.Description,
+			RegulerPrice:      val.RegulerPrice,
+			SalePrice:         val.SalePrice,
+			Unit:              val.Unit,
+			Weight:            val.Weight,
+			Stock:             val.Stock,
+			LowStockThreshold: val.LowStockThreshold,
+			Variant:           val.Variant,
+			Status:            val.Status,
+			CategoryName:      val.Category.Name,
+			RatingAvg:         val.RatingAvg,
+			RatingCount:       val.RatingCount,
+			CreatedAt:         val.CreatedAt,
 		})
 	}
 
 	return respProducts, countData, int64(totalPage), nil
 }
 
+// saveVariants menyimpan child produk parent dari form atau import. Varian dicocokkan lewat id lalu SKU
+// sehingga id yang dipakai keranjang, wishlist, order, ulasan dan riwayat stok tetap sama.
+// Varian yang tidak ada lagi di request dihapus, varian baru dibuat dengan stok awal.
+// Mengembalikan adjustment stok varian yang sudah ada.
+func (p *productRepository) saveVariants(parent model.Product, req entity.ProductEntity, valueIDs []map[string]int64) ([]entity.StockMovementEntity, error) {
+	movements := []entity.StockMovementEntity{}
+	existingChild := []model.Product{}
+	if err := p.db.Where("parent_id = ?", parent.ID).Find(&existingChild).Error; err != nil {
+		return nil, err
+	}
+
+	childByID := map[int64]model.Product{}
+	childBySKU := map[string]model.Product{}
+	for _, val := range existingChild {
+		childByID[val.ID] = val
+		if val.SKU != "" {
+			childBySKU[val.SKU] = val
+		}
+	}
+
+	matched := make([]*model.Product, len(req.Child))
+	keepIDs := map[int64]bool{}
+	for i, val := range req.Child {
+		child, ok := childByID[val.ID]
+		if !ok && val.SKU != "" {
+			child, ok = childBySKU[val.SKU]
+		}
+		if ok && !keepIDs[child.ID] {
+			keepIDs[child.ID] = true
+			matched[i] = &child
+		}
+	}
+
+	removedIDs := []int64{}
+	for _, val := range existingChild {
+		if !keepIDs[val.ID] {
+			removedIDs = append(removedIDs, val.ID)
+		}
+	}
+
+	if len(removedIDs) > 0 {
+		if err := p.db.Where("id IN ?", removedIDs).Delete(&model.Product{}).Error; err != nil {
+			return nil, err
+		}
+	}
+
+	for i, val := range req.Child {
+		modelChild := model.Product{}
+		if matched[i] != nil {
+			modelChild = *matched[i]
+		}
+
+		currentStock := modelChild.Stock
+		modelChild.CategorySlug = req.CategorySlug
+		modelChild.ParentID = &parent.ID
+		modelChild.Name = req.Name
+		modelChild.SKU = val.SKU
+		modelChild.Image = val.Image
+		modelChild.Description = req.Description
+		modelChild.RegulerPrice = val.RegulerPrice
+		modelChild.SalePrice = val.SalePrice
+		modelChild.Unit = req.Unit
+		modelChild.Weight = val.Weight
+		modelChild.LowStockThreshold = val.LowStockThreshold
+		modelChild.Variant = req.Variant
+		modelChild.Status = req.Status
+
+		if matched[i] == nil {
+			modelChild.Stock = val.Stock
+			if err := p.db.Create(&modelChild).Error; err != nil {
+				if errors.Is(err, gorm.ErrDuplicatedKey) {
+					err = errors.New("409")
+				}
+				return nil, err
+			}
+
+			if err := openingStockMovement(p.db, modelChild, req.UpdatedBy, "product-update"); err != nil {
+				return nil, err
+			}
+		} else {
+			if err := p.db.Omit("stock", "rating_avg", "rating_count").Save(&modelChild).Error; err != nil {
+				if errors.Is(err, gorm.ErrDuplicatedKey) {
+					err = errors.New("409")
+				}
+				return nil, err
+			}
+
+			movement, err := adjustStockFromForm(p.db, modelChild.ID, val.Stock-currentStock, req.UpdatedBy)
+			if err != nil {
+				return nil, err
+			}
+			if movement != nil {
+				movements = append(movements, *movement)
+			}
+		}
+
+		if err := saveVariantValues(p.db, modelChild.ID, val.OptionValues, valueIDs); err != nil {
+			return nil, err
+		}
+	}
+
+	return movements, nil
+}
+
+// adjustStockFromForm mencatat selisih stok dari form produk sebagai adjustment di ledger,
+// nil tanpa error jika stok tidak berubah.
+func adjustStockFromForm(db *gorm.DB, productID int64, quantity int, actorID int64) (*entity.StockMovementEntity, error) {
+	if quantity == 0 {
+		return nil, nil
+	}
+
+	movement := model.StockMovement{
+		ProductID: productID,
+		Type:      "adjustment",
+		Quantity:  quantity,
+		ActorType: "admin",
+		Reference: "product-update",
+		Note:      "stock edited from product form",
+	}
+	if actorID != 0 {
+		movement.ActorID = &actorID
+	}
+
+	modelProduct := model.Product{}
+	if err := db.Transaction(func(tx *gorm.DB) error {
+		var err error
+		modelProduct, err = applyStockMovement(tx, &movement)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+
+	result := toStockMovementEntity(movement)
+	result.ProductName = modelProduct.Name
+	result.LowStockThreshold = modelProduct.LowStockThreshold
+	return &result, nil
+}
+
+// loadVariantOptions mengambil opsi varian milik produk parent dan nilai opsi setiap baris produk, diurutkan sesuai posisi opsi.
+func (p *productRepository) loadVariantOptions(ctx context.Context, parentID int64, productIDs []int64) ([]entity.ProductOptionEntity, map[int64][]string, error) {
+	modelOptions := []model.ProductOption{}
+	optionValues := map[int64][]string{}
+
+	err := p.db.WithContext(ctx).
+		Preload("Values", func(db *gorm.DB) *gorm.DB { return db.Order("position ASC") }).
+		Where("product_id = ?", parentID).
+		Order("position ASC").
+		Find(&modelOptions).Error
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if len(modelOptions) == 0 {
+		return nil, optionValues, nil
+	}
+
+	optionIndexes := map[int64]int{}
+	valueNames := map[int64]string{}
+	options := []entity.ProductOptionEntity{}
+	for i, option := range modelOptions {
+		values := []string{}
+		for _, value := range option.Values {
+			values = append(values, value.Value)
+			optionIndexes[value.ID] = i
+			valueNames[value.ID] = value.Value
+		}
+
+		options = append(options, entity.ProductOptionEntity{
+			ID:     option.ID,
+			Name:   option.Name,
+			Values: values,
+		})
+	}
+
+	modelVariantValues := []model.ProductVariantValue{}
+	if err := p.db.WithContext(ctx).Where("product_id IN ?", productIDs).Find(&modelVariantValues).Error; err != nil {
+		return nil, nil, err
+	}
+
+	for _, val := range modelVariantValues {
+		index, ok := optionIndexes[val.OptionValueID]
+		if !ok {
+			continue
+		}
+
+		if optionValues[val.ProductID] == nil {
+			optionValues[val.ProductID] = make([]string, len(modelOptions))
+		}
+		optionValues[val.ProductID][index] = valueNames[val.OptionValueID]
+	}
+
+	return options, optionValues, nil
+}
+
+// saveProductOptions mengganti seluruh opsi varian produk parent dan mengembalikan id nilai opsi per posisi opsi.
+func saveProductOptions(db *gorm.DB, productID int64, options []entity.ProductOptionEntity) ([]map[string]int64, error) {
+	oldOptionIDs := []int64{}
+	if err := db.Model(&model.ProductOption{}).Where("product_id = ?", productID).Pluck("id", &oldOptionIDs).Error; err != nil {
+		return nil, err
+	}
+
+	if len(oldOptionIDs) > 0 {
+		oldValueIDs := db.Model(&model.ProductOptionValue{}).Select("id").Where("option_id IN ?", oldOptionIDs)
+		if err := db.Where("option_value_id IN (?)", oldValueIDs).Delete(&model.ProductVariantValue{}).Error; err != nil {
+			return nil, err
+		}
+
+		if err := db.Where("option_id IN ?", oldOptionIDs).Delete(&model.ProductOptionValue{}).Error; err != nil {
+			return nil, err
+		}
+
+		if err := db.Where("id IN ?", oldOptionIDs).Delete(&model.ProductOption{}).Error; err != nil {
+			return nil, err
+		}
+	}
+
+	valueIDs := []map[string]int64{}
+	for i, option := range options {
+		modelOption := model.ProductOption{
+			ProductID: productID,
+			Name:      option.Name,
+			Position:  i,
+		}
+		for j, value := range option.Values {
+			modelOption.Values = append(modelOption.Values, model.ProductOptionValue{
+				Value:    value,
+				Position: j,
+			})
+		}
+
+		if err := db.Create(&modelOption).Error; err != nil {
+			return nil, err
+		}
+
+		ids := map[string]int64{}
+		for _, value := range modelOption.Values {
+			ids[value.Value] = value.ID
+		}
+		valueIDs = append(valueIDs, ids)
+	}
+
+	return valueIDs, nil
+}
+
+// saveVariantValues mengganti nilai opsi satu baris produk, optionValues mengikuti urutan opsi pada parent.
+func saveVariantValues(db *gorm.DB, productID int64, optionValues []string, valueIDs []map[string]int64) error {
+	if err := db.Where("product_id = ?", productID).Delete(&model.ProductVariantValue{}).Error; err != nil {
+		return err
+	}
+
+	modelVariantValues := []model.ProductVariantValue{}
+	for i, value := range optionValues {
+		if i >= len(valueIDs) {
+			break
+		}
+
+		valueID, ok := valueIDs[i][value]
+		if !ok {
+			return errors.New("422")
+		}
+
+		modelVariantValues = append(modelVariantValues, model.ProductVariantValue{
+			ProductID:     productID,
+			OptionValueID: valueID,
+		})
+	}
+
+	if len(modelVariantValues) == 0 {
+		return nil
+	}
+
+	return db.Create(&modelVariantValues).Error
+}
+
 func NewProductRepository(db *gorm.DB, es *elasticsearch.Client) ProductRepositoryInterface {
 	return &productRepository{db: db, esClient: es}
 }
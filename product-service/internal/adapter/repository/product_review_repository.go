@@ -0,0 +1,251 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"math"
+	"product-service/internal/core/domain/entity"
+	"product-service/internal/core/domain/model"
+	"time"
+
+	"github.com/labstack/gommon/log"
+	"gorm.io/gorm"
+)
+
+type ProductReviewRepositoryInterface interface {
+	Create(ctx context.Context, req entity.ProductReviewEntity) (*entity.ProductReviewEntity, error)
+	GetAll(ctx context.Context, query entity.QueryStringProductReview) ([]entity.ProductReviewEntity, int64, int64, error)
+	GetByID(ctx context.Context, reviewID int64) (*entity.ProductReviewEntity, error)
+	Moderate(ctx context.Context, reviewID int64, status string, moderatedBy int64) (*entity.ProductReviewEntity, error)
+	Delete(ctx context.Context, reviewID int64) (*entity.ProductReviewEntity, error)
+}
+
+type productReviewRepository struct {
+	db *gorm.DB
+}
+
+// Create implements ProductReviewRepositoryInterface.
+// Ulasan baru berstatus PENDING dan belum dihitung ke rating produk sampai disetujui admin.
+func (p *productReviewRepository) Create(ctx context.Context, req entity.ProductReviewEntity) (*entity.ProductReviewEntity, error) {
+	if req.Photos == nil {
+		req.Photos = []string{}
+	}
+
+	photos, err := json.Marshal(req.Photos)
+	if err != nil {
+		log.Errorf("[ProductReviewRepository-1] Create: %v", err)
+		return nil, err
+	}
+
+	modelReview := model.ProductReview{
+		ProductID: req.ProductID,
+		UserID:    req.UserID,
+		UserName:  req.UserName,
+		OrderID:   req.OrderID,
+		Rating:    req.Rating,
+		Comment:   req.Comment,
+		Photos:    string(photos),
+		Status:    "PENDING",
+	}
+
+	var count int64
+	if err := p.db.WithContext(ctx).Model(&model.ProductReview{}).Where("product_id = ? AND user_id = ?", req.ProductID, req.UserID).Count(&count).Error; err != nil {
+		log.Errorf("[ProductReviewRepository-2] Create: %v", err)
+		return nil, err
+	}
+
+	if count > 0 {
+		err = errors.New("409")
+		log.Errorf("[ProductReviewRepository-3] Create: %v", err)
+		return nil, err
+	}
+
+	if err := p.db.WithContext(ctx).Create(&modelReview).Error; err != nil {
+		if errors.Is(err, gorm.ErrDuplicatedKey) {
+			err = errors.New("409")
+		}
+		log.Errorf("[ProductReviewRepository-4] Create: %v", err)
+		return nil, err
+	}
+
+	result := toProductReviewEntity(modelReview)
+	return &result, nil
+}
+
+// GetAll implements ProductReviewRepositoryInterface.
+func (p *productReviewRepository) GetAll(ctx context.Context, query entity.QueryStringProductReview) ([]entity.ProductReviewEntity, int64, int64, error) {
+	modelReviews := []model.ProductReview{}
+	var countData int64
+
+	offset := (query.Page - 1) * query.Limit
+	sqlMain := p.db.WithContext(ctx).Preload("Product")
+	if query.ProductID > 0 {
+		sqlMain = sqlMain.Where("product_id = ?", query.ProductID)
+	}
+
+	if query.Status != "" {
+		sqlMain = sqlMain.Where("status = ?", query.Status)
+	}
+
+	if query.Rating > 0 {
+		sqlMain = sqlMain.Where("rating = ?", query.Rating)
+	}
+
+	if err := sqlMain.Model(&modelReviews).Count(&countData).Error; err != nil {
+		log.Errorf("[ProductReviewRepository-1] GetAll: %v", err)
+		return nil, 0, 0, err
+	}
+
+	totalPage := int(math.Ceil(float64(countData) / float64(query.Limit)))
+	if err := sqlMain.Order("created_at DESC, id DESC").Limit(query.Limit).Offset(offset).Find(&modelReviews).Error; err != nil {
+		log.Errorf("[ProductReviewRepository-2] GetAll: %v", err)
+		return nil, 0, 0, err
+	}
+
+	results := []entity.ProductReviewEntity{}
+	for _, val := range modelReviews {
+		results = append(results, toProductReviewEntity(val))
+	}
+
+	return results, countData, int64(totalPage), nil
+}
+
+// GetByID implements ProductReviewRepositoryInterface.
+func (p *productReviewRepository) GetByID(ctx context.Context, reviewID int64) (*entity.ProductReviewEntity, error) {
+	modelReview := model.ProductReview{}
+	if err := p.db.WithContext(ctx).Preload("Product").First(&modelReview, "id = ?", reviewID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			err = errors.New("404")
+		}
+		log.Errorf("[ProductReviewRepository-1] GetByID: %v", err)
+		return nil, err
+	}
+
+	result := toProductReviewEntity(modelReview)
+	return &result, nil
+}
+
+// Moderate implements ProductReviewRepositoryInterface.
+// Rating produk dihitung ulang dalam transaksi yang sama dengan perubahan status.
+func (p *productReviewRepository) Moderate(ctx context.Context, reviewID int64, status string, moderatedBy int64) (*entity.ProductReviewEntity, error) {
+	modelReview := model.ProductReview{}
+	err := p.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Preload("Product").First(&modelReview, "id = ?", reviewID).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return errors.New("404")
+			}
+			return err
+		}
+
+		if err := lockProduct(tx, modelReview.ProductID); err != nil {
+			return err
+		}
+
+		now := time.Now()
+		modelReview.Status = status
+		modelReview.ModeratedBy = &moderatedBy
+		modelReview.ModeratedAt = &now
+		if err := tx.Model(&model.ProductReview{}).Where("id = ?", modelReview.ID).Updates(map[string]interface{}{
+			"status":       modelReview.Status,
+			"moderated_by": modelReview.ModeratedBy,
+			"moderated_at": modelReview.ModeratedAt,
+			"updated_at":   now,
+		}).Error; err != nil {
+			return err
+		}
+
+		return recalculateProductRating(tx, modelReview.ProductID)
+	})
+	if err != nil {
+		log.Errorf("[ProductReviewRepository-1] Moderate: %v", err)
+		return nil, err
+	}
+
+	result := toProductReviewEntity(modelReview)
+	return &result, nil
+}
+
+// Delete implements ProductReviewRepositoryInterface.
+func (p *productReviewRepository) Delete(ctx context.Context, reviewID int64) (*entity.ProductReviewEntity, error) {
+	modelReview := model.ProductReview{}
+	err := p.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.First(&modelReview, "id = ?", reviewID).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return errors.New("404")
+			}
+			return err
+		}
+
+		if err := lockProduct(tx, modelReview.ProductID); err != nil {
+			return err
+		}
+
+		if err := tx.Delete(&modelReview).Error; err != nil {
+			return err
+		}
+
+		return recalculateProductRating(tx, modelReview.ProductID)
+	})
+	if err != nil {
+		log.Errorf("[ProductReviewRepository-1] Delete: %v", err)
+		return nil, err
+	}
+
+	result := toProductReviewEntity(modelReview)
+	return &result, nil
+}
+
+// recalculateProductRating menghitung ulang rata-rata dan jumlah ulasan yang disetujui pada produk.
+func recalculateProductRating(tx *gorm.DB, productID int64) error {
+	var aggregate struct {
+		RatingAvg   float64
+		RatingCount int
+	}
+
+	if err := tx.Model(&model.ProductReview{}).
+		Select("COALESCE(AVG(rating), 0) AS rating_avg, COUNT(*) AS rating_count").
+		Where("product_id = ? AND status = ?", productID, "APPROVED").
+		Scan(&aggregate).Error; err != nil {
+		return err
+	}
+
+	return tx.Model(&model.Product{}).Where("id = ?", productID).Updates(map[string]interface{}{
+		"rating_avg":   math.Round(aggregate.RatingAvg*100) / 100,
+		"rating_count": aggregate.RatingCount,
+	}).Error
+}
+
+func toProductReviewEntity(val model.ProductReview) entity.ProductReviewEntity {
+	photos := []string{}
+	if val.Photos != "" {
+		if err := json.Unmarshal([]byte(val.Photos), &photos); err != nil {
+			log.Errorf("[ProductReviewRepository-1] toProductReviewEntity: %v", err)
+		}
+	}
+
+	var moderatedBy int64
+	if val.ModeratedBy != nil {
+		moderatedBy = *val.ModeratedBy
+	}
+
+	return entity.ProductReviewEntity{
+		ID:          val.ID,
+		ProductID:   val.ProductID,
+		ProductName: val.Product.Name,
+		UserID:      val.UserID,
+		UserName:    val.UserName,
+		OrderID:     val.OrderID,
+		Rating:      val.Rating,
+		Comment:     val.Comment,
+		Photos:      photos,
+		Status:      val.Status,
+		ModeratedBy: moderatedBy,
+		ModeratedAt: val.ModeratedAt,
+		CreatedAt:   val.CreatedAt,
+	}
+}
+
+func NewProductReviewRepository(db *gorm.DB) ProductReviewRepositoryInterface {
+	return &productReviewRepository{db: db}
+}
@@ -0,0 +1,263 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"math"
+	"product-service/internal/core/domain/entity"
+	"product-service/internal/core/domain/model"
+
+	"github.com/labstack/gommon/log"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type StockMovementRepositoryInterface interface {
+	RecordMovement(ctx context.Context, req entity.StockMovementEntity) (*entity.StockMovementEntity, error)
+	HasMovement(ctx context.Context, productID int64, movementType, reference string) (bool, error)
+	GetByProductID(ctx context.Context, productID int64, query entity.QueryStringStockMovement) ([]entity.StockMovementEntity, int64, int64, error)
+	GetReconciliation(ctx context.Context, productID int64) (*entity.StockReconciliationEntity, error)
+	Reconcile(ctx context.Context, productID int64, actorID int64) (*entity.StockReconciliationEntity, error)
+}
+
+type stockMovementRepository struct {
+	db *gorm.DB
+}
+
+// RecordMovement implements StockMovementRepositoryInterface.
+func (s *stockMovementRepository) RecordMovement(ctx context.Context, req entity.StockMovementEntity) (*entity.StockMovementEntity, error) {
+	modelMovement := toStockMovementModel(req)
+	modelProduct := model.Product{}
+
+	err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var err error
+		modelProduct, err = applyStockMovement(tx, &modelMovement)
+		return err
+	})
+	if err != nil {
+		log.Errorf("[StockMovementRepository-1] RecordMovement: %v", err)
+		return nil, err
+	}
+
+	result := toStockMovementEntity(modelMovement)
+	result.ProductName = modelProduct.Name
+	result.LowStockThreshold = modelProduct.LowStockThreshold
+	return &result, nil
+}
+
+// HasMovement implements StockMovementRepositoryInterface.
+func (s *stockMovementRepository) HasMovement(ctx context.Context, productID int64, movementType string, reference string) (bool, error) {
+	var count int64
+
+	if err := s.db.WithContext(ctx).Model(&model.StockMovement{}).
+		Where("product_id = ? AND type = ? AND reference = ?", productID, movementType, reference).
+		Count(&count).Error; err != nil {
+		log.Errorf("[StockMovementRepository-1] HasMovement: %v", err)
+		return false, err
+	}
+
+	return count > 0, nil
+}
+
+// GetByProductID implements StockMovementRepositoryInterface.
+func (s *stockMovementRepository) GetByProductID(ctx context.Context, productID int64, query entity.QueryStringStockMovement) ([]entity.StockMovementEntity, int64, int64, error) {
+	modelMovements := []model.StockMovement{}
+	var countData int64
+	offset := (query.Page - 1) * query.Limit
+
+	sqlMain := s.db.WithContext(ctx).Model(&model.StockMovement{}).Where("product_id = ?", productID)
+	if query.Type != "" {
+		sqlMain = sqlMain.Where("type = ?", query.Type)
+	}
+
+	if err := sqlMain.Count(&countData).Error; err != nil {
+		log.Errorf("[StockMovementRepository-1] GetByProductID: %v", err)
+		return nil, 0, 0, err
+	}
+
+	totalPage := int(math.Ceil(float64(countData) / float64(query.Limit)))
+	if err := sqlMain.Order("created_at DESC, id DESC").Limit(query.Limit).Offset(offset).Find(&modelMovements).Error; err != nil {
+		log.Errorf("[StockMovementRepository-2] GetByProductID: %v", err)
+		return nil, 0, 0, err
+	}
+
+	if len(modelMovements) == 0 {
+		log.Errorf("[StockMovementRepository-3] GetByProductID: %v", "Data not found")
+		return nil, 0, 0, errors.New("404")
+	}
+
+	results := []entity.StockMovementEntity{}
+	for _, val := range modelMovements {
+		results = append(results, toStockMovementEntity(val))
+	}
+
+	return results, countData, int64(totalPage), nil
+}
+
+// GetReconciliation implements StockMovementRepositoryInterface.
+func (s *stockMovementRepository) GetReconciliation(ctx context.Context, productID int64) (*entity.StockReconciliationEntity, error) {
+	return stockReconciliation(s.db.WithContext(ctx), productID)
+}
+
+// Reconcile implements StockMovementRepositoryInterface.
+// Stok produk dianggap hasil hitung fisik, selisih dengan ledger dicatat sebagai adjustment tanpa mengubah stok
+// sehingga jumlah ledger kembali sama dengan stok. Produk lama yang belum punya ledger mendapat saldo awal dengan cara ini.
+func (s *stockMovementRepository) Reconcile(ctx context.Context, productID int64, actorID int64) (*entity.StockReconciliationEntity, error) {
+	var result *entity.StockReconciliationEntity
+
+	err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		product := model.Product{}
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Select("id", "stock").First(&product, "id = ?", productID).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return errors.New("404")
+			}
+			return err
+		}
+
+		reconciliation, err := stockReconciliation(tx, productID)
+		if err != nil {
+			return err
+		}
+
+		if reconciliation.Difference != 0 {
+			if err := tx.Create(&model.StockMovement{
+				ProductID:  productID,
+				Type:       "adjustment",
+				Quantity:   reconciliation.Difference,
+				StockAfter: product.Stock,
+				ActorType:  "admin",
+				ActorID:    &actorID,
+				Reference:  "reconciliation",
+				Note:       "ledger reconciled to product stock",
+			}).Error; err != nil {
+				return err
+			}
+		}
+
+		result = &entity.StockReconciliationEntity{
+			ProductID:   productID,
+			Stock:       product.Stock,
+			LedgerStock: product.Stock,
+		}
+		return nil
+	})
+	if err != nil {
+		log.Errorf("[StockMovementRepository-1] Reconcile: %v", err)
+		return nil, err
+	}
+
+	return result, nil
+}
+
+func stockReconciliation(db *gorm.DB, productID int64) (*entity.StockReconciliationEntity, error) {
+	product := model.Product{}
+	if err := db.Select("id", "stock").First(&product, "id = ?", productID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("404")
+		}
+		return nil, err
+	}
+
+	var ledgerStock int
+	if err := db.Model(&model.StockMovement{}).
+		Select("COALESCE(SUM(quantity), 0)").
+		Where("product_id = ?", productID).
+		Scan(&ledgerStock).Error; err != nil {
+		return nil, err
+	}
+
+	return &entity.StockReconciliationEntity{
+		ProductID:   productID,
+		Stock:       product.Stock,
+		LedgerStock: ledgerStock,
+		Difference:  product.Stock - ledgerStock,
+	}, nil
+}
+
+// applyStockMovement menerapkan perubahan stok secara atomik dan mencatat movement dalam transaksi tx.
+// Update bersyarat memastikan stok tidak pernah negatif walaupun ada pesanan bersamaan.
+func applyStockMovement(tx *gorm.DB, movement *model.StockMovement) (model.Product, error) {
+	product := model.Product{ID: movement.ProductID}
+	result := tx.Model(&product).
+		Clauses(clause.Returning{Columns: []clause.Column{{Name: "name"}, {Name: "stock"}, {Name: "low_stock_threshold"}}}).
+		Where("stock + ? >= 0", movement.Quantity).
+		Update("stock", gorm.Expr("stock + ?", movement.Quantity))
+	if result.Error != nil {
+		return product, result.Error
+	}
+
+	if result.RowsAffected == 0 {
+		var count int64
+		if err := tx.Model(&model.Product{}).Where("id = ?", movement.ProductID).Count(&count).Error; err != nil {
+			return product, err
+		}
+		if count == 0 {
+			return product, errors.New("404")
+		}
+		return product, errors.New("409")
+	}
+
+	movement.StockAfter = product.Stock
+	return product, tx.Create(movement).Error
+}
+
+// openingStockMovement mencatat stok awal produk baru, stok produk sudah tersimpan sehingga tidak diterapkan ulang.
+func openingStockMovement(tx *gorm.DB, product model.Product, actorID int64, reference string) error {
+	if product.Stock == 0 {
+		return nil
+	}
+
+	movement := model.StockMovement{
+		ProductID:  product.ID,
+		Type:       "restock",
+		Quantity:   product.Stock,
+		StockAfter: product.Stock,
+		ActorType:  "admin",
+		Reference:  reference,
+		Note:       "initial stock",
+	}
+	if actorID != 0 {
+		movement.ActorID = &actorID
+	}
+
+	return tx.Create(&movement).Error
+}
+
+func toStockMovementModel(req entity.StockMovementEntity) model.StockMovement {
+	result := model.StockMovement{
+		ProductID: req.ProductID,
+		Type:      req.Type,
+		Quantity:  req.Quantity,
+		ActorType: req.ActorType,
+		Reference: req.Reference,
+		Note:      req.Note,
+	}
+	if req.ActorID != 0 {
+		result.ActorID = &req.ActorID
+	}
+
+	return result
+}
+
+func toStockMovementEntity(val model.StockMovement) entity.StockMovementEntity {
+	result := entity.StockMovementEntity{
+		ID:         val.ID,
+		ProductID:  val.ProductID,
+		Type:       val.Type,
+		Quantity:   val.Quantity,
+		StockAfter: val.StockAfter,
+		ActorType:  val.ActorType,
+		Reference:  val.Reference,
+		Note:       val.Note,
+		CreatedAt:  val.CreatedAt,
+	}
+	if val.ActorID != nil {
+		result.ActorID = *val.ActorID
+	}
+
+	return result
+}
+
+func NewStockMovementRepository(db *gorm.DB) StockMovementRepositoryInterface {
+	return &stockMovementRepository{db: db}
+}
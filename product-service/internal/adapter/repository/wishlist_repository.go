@@ -0,0 +1,210 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"math"
+	"product-service/internal/core/domain/entity"
+	"product-service/internal/core/domain/model"
+
+	"github.com/labstack/gommon/log"
+	"gorm.io/gorm"
+)
+
+type WishlistRepositoryInterface interface {
+	GetByUserID(ctx context.Context, userID int64) ([]entity.WishlistEntity, error)
+	GetByID(ctx context.Context, userID int64, wishlistID int64) (*entity.WishlistEntity, error)
+	Add(ctx context.Context, req entity.WishlistEntity) (*entity.WishlistEntity, error)
+	Remove(ctx context.Context, userID int64, wishlistID int64) error
+	GetCounts(ctx context.Context, query entity.QueryStringWishlist) ([]entity.WishlistCountEntity, int64, int64, error)
+}
+
+type wishlistRepository struct {
+	db *gorm.DB
+}
+
+// GetByUserID implements WishlistRepositoryInterface.
+// Produk yang sudah dihapus tidak ditampilkan.
+func (w *wishlistRepository) GetByUserID(ctx context.Context, userID int64) ([]entity.WishlistEntity, error) {
+	modelWishlists := []model.Wishlist{}
+	if err := w.db.WithContext(ctx).Preload("Product.Category").
+		Joins("JOIN products ON products.id = wishlists.product_id AND products.deleted_at IS NULL").
+		Where("wishlists.user_id = ?", userID).
+		Order("wishlists.created_at DESC, wishlists.id DESC").
+		Find(&modelWishlists).Error; err != nil {
+		log.Errorf("[WishlistRepository-1] GetByUserID: %v", err)
+		return nil, err
+	}
+
+	results, err := w.toWishlistEntities(ctx, modelWishlists)
+	if err != nil {
+		log.Errorf("[WishlistRepository-2] GetByUserID: %v", err)
+		return nil, err
+	}
+
+	return results, nil
+}
+
+// GetByID implements WishlistRepositoryInterface.
+func (w *wishlistRepository) GetByID(ctx context.Context, userID int64, wishlistID int64) (*entity.WishlistEntity, error) {
+	modelWishlist := model.Wishlist{}
+	if err := w.db.WithContext(ctx).Preload("Product.Category").
+		Joins("JOIN products ON products.id = wishlists.product_id AND products.deleted_at IS NULL").
+		Where("wishlists.id = ? AND wishlists.user_id = ?", wishlistID, userID).
+		First(&modelWishlist).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			err = errors.New("404")
+		}
+		log.Errorf("[WishlistRepository-1] GetByID: %v", err)
+		return nil, err
+	}
+
+	results, err := w.toWishlistEntities(ctx, []model.Wishlist{modelWishlist})
+	if err != nil {
+		log.Errorf("[WishlistRepository-2] GetByID: %v", err)
+		return nil, err
+	}
+
+	return &results[0], nil
+}
+
+// Add implements WishlistRepositoryInterface.
+func (w *wishlistRepository) Add(ctx context.Context, req entity.WishlistEntity) (*entity.WishlistEntity, error) {
+	modelWishlist := model.Wishlist{
+		UserID:    req.UserID,
+		ProductID: req.ProductID,
+		VariantID: req.VariantID,
+	}
+
+	if err := w.db.WithContext(ctx).Create(&modelWishlist).Error; err != nil {
+		if errors.Is(err, gorm.ErrDuplicatedKey) {
+			err = errors.New("409")
+		}
+		log.Errorf("[WishlistRepository-1] Add: %v", err)
+		return nil, err
+	}
+
+	return w.GetByID(ctx, req.UserID, modelWishlist.ID)
+}
+
+// Remove implements WishlistRepositoryInterface.
+func (w *wishlistRepository) Remove(ctx context.Context, userID int64, wishlistID int64) error {
+	result := w.db.WithContext(ctx).Where("id = ? AND user_id = ?", wishlistID, userID).Delete(&model.Wishlist{})
+	if result.Error != nil {
+		log.Errorf("[WishlistRepository-1] Remove: %v", result.Error)
+		return result.Error
+	}
+
+	if result.RowsAffected == 0 {
+		err := errors.New("404")
+		log.Errorf("[WishlistRepository-2] Remove: %v", err)
+		return err
+	}
+
+	return nil
+}
+
+// GetCounts implements WishlistRepositoryInterface.
+// Menghitung jumlah customer yang menyimpan setiap produk, diurutkan dari yang terbanyak.
+func (w *wishlistRepository) GetCounts(ctx context.Context, query entity.QueryStringWishlist) ([]entity.WishlistCountEntity, int64, int64, error) {
+	results := []entity.WishlistCountEntity{}
+	var countData int64
+
+	offset := (query.Page - 1) * query.Limit
+	sqlMain := w.db.WithContext(ctx).Model(&model.Wishlist{}).
+		Joins("JOIN products ON products.id = wishlists.product_id AND products.deleted_at IS NULL")
+	if query.Search != "" {
+		sqlMain = sqlMain.Where("products.name ILIKE ?", "%"+query.Search+"%")
+	}
+
+	// Query hitung dan query data memakai kondisi yang sama tanpa saling mengubah statement
+	sqlMain = sqlMain.Session(&gorm.Session{})
+
+	if err := sqlMain.Distinct("wishlists.product_id").Count(&countData).Error; err != nil {
+		log.Errorf("[WishlistRepository-1] GetCounts: %v", err)
+		return nil, 0, 0, err
+	}
+
+	totalPage := int(math.Ceil(float64(countData) / float64(query.Limit)))
+	if err := sqlMain.
+		Select("wishlists.product_id, products.name AS product_name, products.image AS product_image, products.status AS product_status, COUNT(DISTINCT wishlists.user_id) AS wishlist_count").
+		Group("wishlists.product_id, products.name, products.image, products.status").
+		Order("wishlist_count DESC, wishlists.product_id ASC").
+		Limit(query.Limit).Offset(offset).
+		Scan(&results).Error; err != nil {
+		log.Errorf("[WishlistRepository-2] GetCounts: %v", err)
+		return nil, 0, 0, err
+	}
+
+	return results, countData, int64(totalPage), nil
+}
+
+// toWishlistEntities mengisi harga dan stok dari baris varian jika varian dipilih, selain itu dari produk parent.
+func (w *wishlistRepository) toWishlistEntities(ctx context.Context, modelWishlists []model.Wishlist) ([]entity.WishlistEntity, error) {
+	productIDs := []int64{}
+	variantIDs := []int64{}
+	for _, val := range modelWishlists {
+		productIDs = append(productIDs, val.ProductID)
+		if val.VariantID != 0 {
+			variantIDs = append(variantIDs, val.VariantID)
+		}
+	}
+
+	variants := map[int64]model.Product{}
+	if len(variantIDs) > 0 {
+		modelVariants := []model.Product{}
+		if err := w.db.WithContext(ctx).Where("id IN ?", variantIDs).Find(&modelVariants).Error; err != nil {
+			return nil, err
+		}
+		for _, val := range modelVariants {
+			variants[val.ID] = val
+		}
+	}
+
+	hasVariants := map[int64]bool{}
+	if len(productIDs) > 0 {
+		parentIDs := []int64{}
+		if err := w.db.WithContext(ctx).Model(&model.Product{}).Where("parent_id IN ?", productIDs).Distinct().Pluck("parent_id", &parentIDs).Error; err != nil {
+			return nil, err
+		}
+		for _, val := range parentIDs {
+			hasVariants[val] = true
+		}
+	}
+
+	results := []entity.WishlistEntity{}
+	for _, val := range modelWishlists {
+		source := val.Product
+		if variant, ok := variants[val.VariantID]; ok {
+			source = variant
+		}
+
+		image := source.Image
+		if image == "" {
+			image = val.Product.Image
+		}
+
+		results = append(results, entity.WishlistEntity{
+			ID:            val.ID,
+			UserID:        val.UserID,
+			ProductID:     val.ProductID,
+			VariantID:     val.VariantID,
+			SKU:           source.SKU,
+			ProductName:   val.Product.Name,
+			ProductImage:  image,
+			ProductStatus: val.Product.Status,
+			CategoryName:  val.Product.Category.Name,
+			RegulerPrice:  source.RegulerPrice,
+			SalePrice:     source.SalePrice,
+			Stock:         source.Stock,
+			HasVariant:    hasVariants[val.ProductID],
+			CreatedAt:     val.CreatedAt,
+		})
+	}
+
+	return results, nil
+}
+
+func NewWishlistRepository(db *gorm.DB) WishlistRepositoryInterface {
+	return &wishlistRepository{db: db}
+}
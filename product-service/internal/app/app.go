@@ -6,6 +6,7 @@ import (
 	"os/signal"
 	"product-service/config"
 	"product-service/internal/adapter/handlers"
+	httpclient "product-service/internal/adapter/http_client"
 	"product-service/internal/adapter/message"
 	"product-service/internal/adapter/repository"
 	"product-service/internal/adapter/storage"
@@ -34,16 +35,29 @@ func RunServer() {
 		return
 	}
 
-	storageHandler := storage.NewSupabase(cfg)
+	storageHandler := storage.NewStorage(cfg)
 	publisherRabbitMQ := message.NewPublishRabbitMQ(cfg)
 
 	categoryRepo := repository.NewCategoryRepository(db.DB)
 	productRepo := repository.NewProductRepository(db.DB, elasticInit)
 	cartRepo := repository.NewCartRedisRepository(cfg.NewRedisClient())
+	stockMovementRepo := repository.NewStockMovementRepository(db.DB)
+	productImageRepo := repository.NewProductImageRepository(db.DB)
+	productImportRepo := repository.NewProductImportRepository(db.DB)
+	productReviewRepo := repository.NewProductReviewRepository(db.DB)
+	wishlistRepo := repository.NewWishlistRepository(db.DB)
 
 	categoryService := service.NewCategoryService(categoryRepo)
-	productService := service.NewProductService(productRepo, publisherRabbitMQ, categoryRepo)
+	productService := service.NewProductService(productRepo, publisherRabbitMQ, categoryRepo, storageHandler)
 	cartService := service.NewCartService(cartRepo)
+	stockMovementService := service.NewStockMovementService(stockMovementRepo, publisherRabbitMQ)
+	productImportService := service.NewProductImportService(productImportRepo, productRepo, categoryRepo, productService)
+	if err := productImportService.FailInterruptedJobs(context.Background()); err != nil {
+		log.Errorf("[RunServer-4] %v", err)
+	}
+	productImageService := service.NewProductImageService(productImageRepo, productService, publisherRabbitMQ, storageHandler)
+	wishlistService := service.NewWishlistService(wishlistRepo, productService, cartService)
+	productReviewService := service.NewProductReviewService(productReviewRepo, productService, cfg, httpclient.NewHttpClient(cfg), publisherRabbitMQ, storageHandler)
 
 	e := echo.New()
 	e.Use(middleware.CORS())
@@ -59,7 +73,15 @@ func RunServer() {
 	handlers.NewCategoryHandler(e, categoryService, cfg)
 	handlers.NewProductHandler(e, cfg, productService)
 	handlers.NewUploadImage(e, cfg, storageHandler)
+	if localStorage, ok := storageHandler.(storage.LocalStorageInterface); ok {
+		handlers.NewStorageHandler(e, localStorage)
+	}
 	handlers.NewCartHandler(e, cfg, cartService, productService)
+	handlers.NewStockMovementHandler(e, cfg, stockMovementService)
+	handlers.NewProductImageHandler(e, cfg, productImageService)
+	handlers.NewProductImportHandler(e, cfg, productImportService)
+	handlers.NewProductReviewHandler(e, cfg, productReviewService)
+	handlers.NewWishlistHandler(e, cfg, wishlistService)
 
 	go func() {
 		if cfg.App.AppPort == "" {
@@ -2,5 +2,6 @@ package entity
 
 type CartItem struct {
 	ProductID int64 `json:"product_id"`
+	VariantID int64 `json:"variant_id,omitempty"`
 	Quantity  int64 `json:"quantity"`
 }
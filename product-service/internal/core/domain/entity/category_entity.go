@@ -8,6 +8,7 @@ type CategoryEntity struct {
 	Status      string          `json:"status"`
 	Slug        string          `json:"slug"`
 	Description string          `json:"description"`
+	TaxRate     float64         `json:"tax_rate"`
 	Products    []ProductEntity `json:"products"`
 }
 
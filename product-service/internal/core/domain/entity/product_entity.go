@@ -3,22 +3,37 @@ package entity
 import "time"
 
 type ProductEntity struct {
-	ID           int64           `json:"id"`
-	CategorySlug string          `json:"category_slug"`
-	ParentID     *int64          `json:"parent_id"`
-	Name         string          `json:"name"`
-	Image        string          `json:"image"`
-	Description  string          `json:"description"`
-	RegulerPrice float64         `json:"reguler_price"`
-	SalePrice    float64         `json:"sale_price"`
-	Unit         string          `json:"unit"`
-	Weight       int             `json:"weight"`
-	Stock        int             `json:"stock"`
-	Variant      int             `json:"variant"`
-	Status       string          `json:"status"`
-	CategoryName string          `json:"category_name"`
-	Child        []ProductEntity `json:"child"`
-	CreatedAt    time.Time       `json:"created_at"`
+	ID                int64                 `json:"id"`
+	CategorySlug      string                `json:"category_slug"`
+	ParentID          *int64                `json:"parent_id"`
+	Name              string                `json:"name"`
+	SKU               string                `json:"sku"`
+	Image             string                `json:"image"`
+	Description       string                `json:"description"`
+	RegulerPrice      float64               `json:"reguler_price"`
+	SalePrice         float64               `json:"sale_price"`
+	Unit              string                `json:"unit"`
+	Weight            int                   `json:"weight"`
+	Stock             int                   `json:"stock"`
+	LowStockThreshold int                   `json:"low_stock_threshold"`
+	Variant           int                   `json:"variant"`
+	Status            string                `json:"status"`
+	CategoryName      string                `json:"category_name"`
+	TaxRate           float64               `json:"tax_rate"`
+	RatingAvg         float64               `json:"rating_avg"`
+	RatingCount       int                   `json:"rating_count"`
+	Child             []ProductEntity       `json:"child"`
+	Options           []ProductOptionEntity `json:"options"`
+	OptionValues      []string              `json:"option_values"`
+	Images            []ProductImageEntity  `json:"images"`
+	CreatedAt         time.Time             `json:"created_at"`
+	UpdatedBy         int64                 `json:"-"`
+}
+
+type ProductOptionEntity struct {
+	ID     int64    `json:"id"`
+	Name   string   `json:"name"`
+	Values []string `json:"values"`
 }
 
 type QueryStringProduct struct {
@@ -31,9 +46,12 @@ type QueryStringProduct struct {
 	StartPrice   int64
 	EndPrice     int64
 	Status       string
+	MinRating    float64
 }
 
 type PublishOrderItemEntity struct {
-	ProductID int64 `json:"product_id"`
-	Quantity  int64 `json:"quantity"`
+	ProductID    int64  `json:"product_id"`
+	Quantity     int64  `json:"quantity"`
+	MovementType string `json:"movement_type"`
+	Reference    string `json:"reference"`
 }
@@ -0,0 +1,12 @@
+package entity
+
+type ProductImageEntity struct {
+	ID           int64  `json:"id"`
+	ProductID    int64  `json:"product_id"`
+	URL          string `json:"url"`
+	ThumbnailURL string `json:"thumbnail_url"`
+	MediumURL    string `json:"medium_url"`
+	AltText      string `json:"alt_text"`
+	Position     int    `json:"position"`
+	IsPrimary    bool   `json:"is_primary"`
+}
@@ -0,0 +1,49 @@
+package entity
+
+import "time"
+
+type ProductImportJobEntity struct {
+	ID          int64
+	FileName    string
+	Status      string
+	TotalRows   int
+	SuccessRows int
+	FailedRows  int
+	Errors      []ProductImportErrorEntity
+	CreatedBy   int64
+	CreatedAt   time.Time
+	FinishedAt  *time.Time
+}
+
+type ProductImportErrorEntity struct {
+	Row     int    `json:"row"`
+	SKU     string `json:"sku"`
+	Message string `json:"message"`
+}
+
+// ProductImportItemEntity adalah satu produk beserta variannya hasil pengelompokan baris file,
+// Rows berisi nomor baris asal untuk laporan error.
+type ProductImportItemEntity struct {
+	Rows    []int
+	Product ProductEntity
+}
+
+// ProductImportRowEntity adalah satu baris file import, baris dengan ParentSKU adalah varian dari produk parent-nya.
+type ProductImportRowEntity struct {
+	SKU                string
+	ParentSKU          string
+	ProductName        string
+	CategorySlug       string
+	Unit               string
+	ProductDescription string
+	Status             string
+	Variant            int
+	Options            string
+	OptionValues       string
+	ProductImage       string
+	Stock              int
+	LowStockThreshold  int
+	Weight             int
+	RegulerPrice       int64
+	SalePrice          int64
+}
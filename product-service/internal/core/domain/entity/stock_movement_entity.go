@@ -0,0 +1,47 @@
+package entity
+
+import "time"
+
+type StockMovementEntity struct {
+	ID          int64
+	ProductID   int64
+	ProductName string
+	Type        string
+	Quantity    int
+	StockAfter  int
+	ActorType   string
+	ActorID     int64
+	Reference   string
+	Note        string
+	CreatedAt   time.Time
+
+	LowStockThreshold int
+}
+
+type QueryStringStockMovement struct {
+	Page  int
+	Limit int
+	Type  string
+}
+
+type StockReconciliationEntity struct {
+	ProductID   int64
+	Stock       int
+	LedgerStock int
+	Difference  int
+}
+
+type PublishStockAlertEntity struct {
+	ProductID         int64  `json:"product_id"`
+	ProductName       string `json:"product_name"`
+	Stock             int    `json:"stock"`
+	LowStockThreshold int    `json:"low_stock_threshold"`
+	AlertType         string `json:"alert_type"`
+}
+
+type PublishStockFailedEntity struct {
+	ProductID int64  `json:"product_id"`
+	Quantity  int64  `json:"quantity"`
+	Reference string `json:"reference"`
+	Reason    string `json:"reason"`
+}
@@ -0,0 +1,35 @@
+package entity
+
+import "time"
+
+// WishlistEntity membawa harga dan stok terkini dari produk atau varian yang disimpan.
+type WishlistEntity struct {
+	ID            int64     `json:"id"`
+	UserID        int64     `json:"user_id"`
+	ProductID     int64     `json:"product_id"`
+	VariantID     int64     `json:"variant_id"`
+	SKU           string    `json:"sku"`
+	ProductName   string    `json:"product_name"`
+	ProductImage  string    `json:"product_image"`
+	ProductStatus string    `json:"product_status"`
+	CategoryName  string    `json:"category_name"`
+	RegulerPrice  float64   `json:"reguler_price"`
+	SalePrice     float64   `json:"sale_price"`
+	Stock         int       `json:"stock"`
+	HasVariant    bool      `json:"has_variant"`
+	CreatedAt     time.Time `json:"created_at"`
+}
+
+type WishlistCountEntity struct {
+	ProductID     int64  `json:"product_id"`
+	ProductName   string `json:"product_name"`
+	ProductImage  string `json:"product_image"`
+	ProductStatus string `json:"product_status"`
+	WishlistCount int64  `json:"wishlist_count"`
+}
+
+type QueryStringWishlist struct {
+	Search string
+	Page   int
+	Limit  int
+}
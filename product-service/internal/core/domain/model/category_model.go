@@ -14,6 +14,7 @@ type Category struct {
 	Status      bool           `gorm:"column:status;default:true"`
 	Slug        string         `gorm:"column:slug;unique"`
 	Description string         `gorm:"column:description"`
+	TaxRate     float64        `gorm:"column:tax_rate;default:0"`
 	CreatedAt   time.Time      `gorm:"column:created_at;default:CURRENT_TIMESTAMP"`
 	UpdatedAt   *time.Time     `gorm:"column:updated_at"`
 	DeletedAt   gorm.DeletedAt `gorm:"column:deleted_at;index"`
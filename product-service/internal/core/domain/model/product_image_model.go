@@ -0,0 +1,17 @@
+package model
+
+import "time"
+
+// ProductImage adalah galeri gambar produk, gambar primary selalu disalin ke Product.Image.
+type ProductImage struct {
+	ID        int64  `gorm:"primaryKey"`
+	ProductID int64  `gorm:"column:product_id;not null;index"`
+	URL       string `gorm:"column:url;not null"`
+	// URL rendition hasil upload, URL menyimpan rendition large
+	ThumbnailURL string    `gorm:"column:thumbnail_url"`
+	MediumURL    string    `gorm:"column:medium_url"`
+	AltText      string    `gorm:"column:alt_text;size:255"`
+	Position     int       `gorm:"column:position;not null;default:0"`
+	IsPrimary    bool      `gorm:"column:is_primary;not null;default:false"`
+	CreatedAt    time.Time `gorm:"column:created_at;default:CURRENT_TIMESTAMP"`
+}
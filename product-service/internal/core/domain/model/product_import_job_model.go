@@ -0,0 +1,18 @@
+package model
+
+import "time"
+
+// ProductImportJob mencatat progres import produk dari CSV/XLSX yang diproses di background.
+// Errors menyimpan laporan error per baris dalam bentuk JSON.
+type ProductImportJob struct {
+	ID          int64      `gorm:"primaryKey"`
+	FileName    string     `gorm:"column:file_name;size:255"`
+	Status      string     `gorm:"column:status;not null;size:20;default:'pending'"`
+	TotalRows   int        `gorm:"column:total_rows;not null;default:0"`
+	SuccessRows int        `gorm:"column:success_rows;not null;default:0"`
+	FailedRows  int        `gorm:"column:failed_rows;not null;default:0"`
+	Errors      string     `gorm:"column:errors;type:jsonb;not null;default:'[]'"`
+	CreatedBy   int64      `gorm:"column:created_by"`
+	CreatedAt   time.Time  `gorm:"column:created_at;default:CURRENT_TIMESTAMP"`
+	FinishedAt  *time.Time `gorm:"column:finished_at"`
+}
@@ -0,0 +1,25 @@
+package model
+
+// ProductOption adalah tipe opsi varian milik produk parent, misalnya berat atau jenis.
+type ProductOption struct {
+	ID        int64                `gorm:"primaryKey"`
+	ProductID int64                `gorm:"column:product_id;not null;index"`
+	Name      string               `gorm:"column:name;not null;size:50"`
+	Position  int                  `gorm:"column:position;not null;default:0"`
+	Values    []ProductOptionValue `gorm:"foreignKey:OptionID;references:ID;constraint:OnDelete:CASCADE"`
+}
+
+type ProductOptionValue struct {
+	ID       int64  `gorm:"primaryKey"`
+	OptionID int64  `gorm:"column:option_id;not null;index"`
+	Value    string `gorm:"column:value;not null;size:50"`
+	Position int    `gorm:"column:position;not null;default:0"`
+}
+
+// ProductVariantValue menghubungkan baris produk (parent atau child) dengan nilai opsi yang dimilikinya.
+type ProductVariantValue struct {
+	ID            int64              `gorm:"primaryKey"`
+	ProductID     int64              `gorm:"column:product_id;not null;uniqueIndex:idx_product_variant_values_product_value,priority:1"`
+	OptionValueID int64              `gorm:"column:option_value_id;not null;uniqueIndex:idx_product_variant_values_product_value,priority:2"`
+	OptionValue   ProductOptionValue `gorm:"foreignKey:OptionValueID;references:ID;constraint:OnDelete:CASCADE"`
+}
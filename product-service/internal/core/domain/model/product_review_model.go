@@ -0,0 +1,27 @@
+package model
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// ProductReview menyimpan ulasan customer untuk produk parent, satu customer hanya boleh memberi satu ulasan per produk.
+// Photos menyimpan daftar URL foto dalam bentuk JSON.
+type ProductReview struct {
+	ID          int64          `gorm:"primaryKey"`
+	ProductID   int64          `gorm:"column:product_id;not null;uniqueIndex:idx_product_reviews_product_user,where:deleted_at IS NULL"`
+	UserID      int64          `gorm:"column:user_id;not null;uniqueIndex:idx_product_reviews_product_user,where:deleted_at IS NULL"`
+	UserName    string         `gorm:"column:user_name;size:255"`
+	OrderID     int64          `gorm:"column:order_id;not null"`
+	Rating      int            `gorm:"column:rating;not null"`
+	Comment     string         `gorm:"column:comment"`
+	Photos      string         `gorm:"column:photos;type:jsonb;not null;default:'[]'"`
+	Status      string         `gorm:"column:status;not null;size:20;default:'PENDING';index"`
+	ModeratedBy *int64         `gorm:"column:moderated_by"`
+	ModeratedAt *time.Time     `gorm:"column:moderated_at"`
+	CreatedAt   time.Time      `gorm:"column:created_at;default:CURRENT_TIMESTAMP"`
+	UpdatedAt   *time.Time     `gorm:"column:updated_at"`
+	DeletedAt   gorm.DeletedAt `gorm:"column:deleted_at;index"`
+	Product     Product        `gorm:"foreignKey:ProductID;references:ID"`
+}
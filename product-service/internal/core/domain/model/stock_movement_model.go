@@ -0,0 +1,18 @@
+package model
+
+import "time"
+
+// StockMovement adalah ledger stok yang hanya boleh ditambah, tidak pernah diubah atau dihapus.
+// Movement dari consumer (actor system) unik per produk, tipe dan order sehingga pesan duplikat tidak diterapkan dua kali.
+type StockMovement struct {
+	ID         int64     `gorm:"primaryKey"`
+	ProductID  int64     `gorm:"column:product_id;not null;index;uniqueIndex:idx_stock_movements_system_reference,priority:1,where:actor_type = 'system'"`
+	Type       string    `gorm:"column:type;not null;size:30;uniqueIndex:idx_stock_movements_system_reference,priority:2"`
+	Quantity   int       `gorm:"column:quantity;not null"`
+	StockAfter int       `gorm:"column:stock_after;not null"`
+	ActorType  string    `gorm:"column:actor_type;not null;size:20"`
+	ActorID    *int64    `gorm:"column:actor_id"`
+	Reference  string    `gorm:"column:reference;size:100;index;uniqueIndex:idx_stock_movements_system_reference,priority:3"`
+	Note       string    `gorm:"column:note"`
+	CreatedAt  time.Time `gorm:"column:created_at;default:CURRENT_TIMESTAMP;index"`
+}
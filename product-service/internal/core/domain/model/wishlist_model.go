@@ -0,0 +1,14 @@
+package model
+
+import "time"
+
+// Wishlist menyimpan produk yang disimpan customer untuk dibeli nanti.
+// VariantID bernilai 0 jika customer belum memilih varian, sama seperti item keranjang.
+type Wishlist struct {
+	ID        int64     `gorm:"primaryKey"`
+	UserID    int64     `gorm:"column:user_id;not null;uniqueIndex:idx_wishlists_user_product_variant"`
+	ProductID int64     `gorm:"column:product_id;not null;index;uniqueIndex:idx_wishlists_user_product_variant"`
+	VariantID int64     `gorm:"column:variant_id;not null;default:0;uniqueIndex:idx_wishlists_user_product_variant"`
+	CreatedAt time.Time `gorm:"column:created_at;default:CURRENT_TIMESTAMP"`
+	Product   Product   `gorm:"foreignKey:ProductID;references:ID"`
+}
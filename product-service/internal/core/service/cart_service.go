@@ -12,7 +12,7 @@ import (
 type CartServiceInterface interface {
 	AddToCart(ctx context.Context, userID int64, req entity.CartItem) error
 	GetCartByUserID(ctx context.Context, userID int64) ([]entity.CartItem, error)
-	RemoveFromCart(ctx context.Context, userID int64, productID int64) error
+	RemoveFromCart(ctx context.Context, userID int64, productID int64, variantID int64) error
 	RemoveAllCart(ctx context.Context, userID int64) error
 }
 
@@ -26,8 +26,8 @@ func (c *cartService) RemoveAllCart(ctx context.Context, userID int64) error {
 }
 
 // RemoveFromCart implements CartServiceInterface.
-func (c *cartService) RemoveFromCart(ctx context.Context, userID int64, productID int64) error {
-	return c.cartRepository.RemoveFromCart(ctx, userID, productID)
+func (c *cartService) RemoveFromCart(ctx context.Context, userID int64, productID int64, variantID int64) error {
+	return c.cartRepository.RemoveFromCart(ctx, userID, productID, variantID)
 }
 
 // AddToCart implements CartServiceInterface.
@@ -40,7 +40,7 @@ func (c *cartService) AddToCart(ctx context.Context, userID int64, req entity.Ca
 
 	found := false
 	for i, item := range cart {
-		if item.ProductID == req.ProductID {
+		if item.ProductID == req.ProductID && item.VariantID == req.VariantID {
 			cart[i].Quantity += req.Quantity
 			found = true
 			break
@@ -0,0 +1,109 @@
+package service
+
+import (
+	"context"
+	"product-service/internal/adapter/message"
+	"product-service/internal/adapter/repository"
+	"product-service/internal/adapter/storage"
+	"product-service/internal/core/domain/entity"
+
+	"github.com/labstack/gommon/log"
+)
+
+type ProductImageServiceInterface interface {
+	GetByProductID(ctx context.Context, productID int64) ([]entity.ProductImageEntity, error)
+	Attach(ctx context.Context, req entity.ProductImageEntity) (*entity.ProductImageEntity, error)
+	Reorder(ctx context.Context, productID int64, imageIDs []int64) error
+	SetPrimary(ctx context.Context, productID int64, imageID int64) error
+	Remove(ctx context.Context, productID int64, imageID int64) error
+}
+
+type productImageService struct {
+	repo              repository.ProductImageRepositoryInterface
+	productService    ProductServiceInterface
+	publisherRabbitMQ message.PublishRabbitMQInterface
+	storageHandler    storage.StorageInterface
+}
+
+// GetByProductID implements ProductImageServiceInterface.
+func (p *productImageService) GetByProductID(ctx context.Context, productID int64) ([]entity.ProductImageEntity, error) {
+	if _, err := p.productService.GetByID(ctx, productID); err != nil {
+		log.Errorf("[ProductImageService-1] GetByProductID: %v", err)
+		return nil, err
+	}
+
+	return p.repo.GetByProductID(ctx, productID)
+}
+
+// Attach implements ProductImageServiceInterface.
+func (p *productImageService) Attach(ctx context.Context, req entity.ProductImageEntity) (*entity.ProductImageEntity, error) {
+	result, err := p.repo.Attach(ctx, req)
+	if err != nil {
+		log.Errorf("[ProductImageService-1] Attach: %v", err)
+		return nil, err
+	}
+
+	p.reindexProduct(ctx, req.ProductID)
+	return result, nil
+}
+
+// Reorder implements ProductImageServiceInterface.
+func (p *productImageService) Reorder(ctx context.Context, productID int64, imageIDs []int64) error {
+	if err := p.repo.Reorder(ctx, productID, imageIDs); err != nil {
+		log.Errorf("[ProductImageService-1] Reorder: %v", err)
+		return err
+	}
+
+	p.reindexProduct(ctx, productID)
+	return nil
+}
+
+// SetPrimary implements ProductImageServiceInterface.
+func (p *productImageService) SetPrimary(ctx context.Context, productID int64, imageID int64) error {
+	if err := p.repo.SetPrimary(ctx, productID, imageID); err != nil {
+		log.Errorf("[ProductImageService-1] SetPrimary: %v", err)
+		return err
+	}
+
+	p.reindexProduct(ctx, productID)
+	return nil
+}
+
+// Remove implements ProductImageServiceInterface.
+func (p *productImageService) Remove(ctx context.Context, productID int64, imageID int64) error {
+	removed, err := p.repo.Remove(ctx, productID, imageID)
+	if err != nil {
+		log.Errorf("[ProductImageService-1] Remove: %v", err)
+		return err
+	}
+
+	p.reindexProduct(ctx, productID)
+	deleteStoredImages(p.storageHandler, removed.URL)
+	return nil
+}
+
+// reindexProduct mengirim ulang produk ke queue agar galeri di Elasticsearch ikut diperbarui.
+// Hanya produk parent yang diindeks, perubahan galeri child mengirim ulang parent-nya.
+func (p *productImageService) reindexProduct(ctx context.Context, productID int64) {
+	product, err := p.productService.GetByID(ctx, productID)
+	if err != nil {
+		log.Errorf("[ProductImageService-1] reindexProduct: %v", err)
+		return
+	}
+
+	if product.ParentID != nil {
+		product, err = p.productService.GetByID(ctx, *product.ParentID)
+		if err != nil {
+			log.Errorf("[ProductImageService-2] reindexProduct: %v", err)
+			return
+		}
+	}
+
+	if err := p.publisherRabbitMQ.PublishProductToQueue(*product); err != nil {
+		log.Errorf("[ProductImageService-3] reindexProduct: %v", err)
+	}
+}
+
+func NewProductImageService(repo repository.ProductImageRepositoryInterface, productService ProductServiceInterface, publisherRabbitMQ message.PublishRabbitMQInterface, storageHandler storage.StorageInterface) ProductImageServiceInterface {
+	return &productImageService{repo: repo, productService: productService, publisherRabbitMQ: publisherRabbitMQ, storageHandler: storageHandler}
+}
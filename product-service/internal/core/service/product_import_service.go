@@ -0,0 +1,504 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"product-service/internal/adapter/repository"
+	"product-service/internal/core/domain/entity"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/labstack/gommon/log"
+)
+
+// productImportRequiredColumns wajib ada di header file import.
+var productImportRequiredColumns = []string{"sku", "product_image", "weight", "reguler_price"}
+
+type ProductImportServiceInterface interface {
+	StartImport(ctx context.Context, job entity.ProductImportJobEntity, rows [][]string) (*entity.ProductImportJobEntity, error)
+	GetJobByID(ctx context.Context, jobID int64) (*entity.ProductImportJobEntity, error)
+	Export(ctx context.Context) ([]entity.ProductEntity, error)
+	FailInterruptedJobs(ctx context.Context) error
+}
+
+type productImportGroup struct {
+	rows     []int
+	parent   entity.ProductImportRowEntity
+	variants []entity.ProductImportRowEntity
+}
+
+type productImportService struct {
+	repo           repository.ProductImportRepositoryInterface
+	productRepo    repository.ProductRepositoryInterface
+	repoCat        repository.CategoryRepositoryInterface
+	productService ProductServiceInterface
+}
+
+// StartImport implements ProductImportServiceInterface.
+// rows[0] adalah header, baris divalidasi dan dikelompokkan di sini lalu job disimpan
+// dan produk diproses di background, progres dipantau lewat GetJobByID.
+func (p *productImportService) StartImport(ctx context.Context, job entity.ProductImportJobEntity, rows [][]string) (*entity.ProductImportJobEntity, error) {
+	if len(rows) < 2 {
+		err := errors.New("422")
+		log.Errorf("[ProductImportService-1] StartImport: file has no product row")
+		return nil, err
+	}
+
+	header := map[string]int{}
+	for i, column := range rows[0] {
+		header[strings.ToLower(strings.TrimSpace(column))] = i
+	}
+
+	for _, column := range productImportRequiredColumns {
+		if _, ok := header[column]; !ok {
+			log.Errorf("[ProductImportService-2] StartImport: missing column %s", column)
+			return nil, errors.New("422")
+		}
+	}
+
+	items, importErrors, totalRows := buildImportItems(header, rows[1:])
+
+	failedRows := map[int]bool{}
+	for _, val := range importErrors {
+		failedRows[val.Row] = true
+	}
+
+	job.TotalRows = totalRows
+	job.FailedRows = len(failedRows)
+	job.Errors = importErrors
+	job.Status = "pending"
+	job.CreatedAt = time.Now()
+
+	jobID, err := p.repo.CreateJob(ctx, job)
+	if err != nil {
+		log.Errorf("[ProductImportService-3] StartImport: %v", err)
+		return nil, err
+	}
+	job.ID = jobID
+
+	go p.runImport(job, items)
+
+	return &job, nil
+}
+
+// GetJobByID implements ProductImportServiceInterface.
+func (p *productImportService) GetJobByID(ctx context.Context, jobID int64) (*entity.ProductImportJobEntity, error) {
+	return p.repo.GetJobByID(ctx, jobID)
+}
+
+// Export implements ProductImportServiceInterface.
+func (p *productImportService) Export(ctx context.Context) ([]entity.ProductEntity, error) {
+	return p.productRepo.GetAllForExport(ctx)
+}
+
+// FailInterruptedJobs implements ProductImportServiceInterface.
+// Job berjalan di goroutine proses ini sehingga job yang masih pending atau processing saat service
+// baru dijalankan pasti terhenti oleh restart, job tersebut ditandai failed agar tidak menggantung.
+func (p *productImportService) FailInterruptedJobs(ctx context.Context) error {
+	total, err := p.repo.FailUnfinishedJobs(ctx, "import interrupted by service restart, please upload the file again")
+	if err != nil {
+		log.Errorf("[ProductImportService-1] FailInterruptedJobs: %v", err)
+		return err
+	}
+
+	if total > 0 {
+		log.Infof("[ProductImportService-2] FailInterruptedJobs: %d import jobs marked as failed", total)
+	}
+
+	return nil
+}
+
+// runImport membuat atau memperbarui produk berdasarkan SKU parent, varian ikut diganti seperti form edit produk.
+func (p *productImportService) runImport(job entity.ProductImportJobEntity, items []entity.ProductImportItemEntity) {
+	ctx := context.Background()
+
+	job.Status = "processing"
+	if err := p.repo.UpdateJob(ctx, job); err != nil {
+		log.Errorf("[ProductImportService-1] runImport: %v", err)
+	}
+
+	categories := map[string]bool{}
+	for _, item := range items {
+		item.Product.UpdatedBy = job.CreatedBy
+		err := p.importItem(ctx, item.Product, categories)
+		if err == nil {
+			job.SuccessRows += len(item.Rows)
+		} else {
+			log.Errorf("[ProductImportService-2] runImport: sku %s: %v", item.Product.SKU, err)
+			job.FailedRows += len(item.Rows)
+			for _, row := range item.Rows {
+				job.Errors = append(job.Errors, entity.ProductImportErrorEntity{
+					Row:     row,
+					SKU:     item.Product.SKU,
+					Message: err.Error(),
+				})
+			}
+		}
+
+		if err := p.repo.UpdateJob(ctx, job); err != nil {
+			log.Errorf("[ProductImportService-3] runImport: %v", err)
+		}
+	}
+
+	finishedAt := time.Now()
+	job.Status = "completed"
+	job.FinishedAt = &finishedAt
+	if err := p.repo.UpdateJob(ctx, job); err != nil {
+		log.Errorf("[ProductImportService-4] runImport: %v", err)
+	}
+}
+
+func (p *productImportService) importItem(ctx context.Context, product entity.ProductEntity, categories map[string]bool) error {
+	if _, ok := categories[product.CategorySlug]; !ok {
+		_, err := p.repoCat.GetBySlug(ctx, product.CategorySlug)
+		if err != nil && err.Error() != "404" {
+			return err
+		}
+		categories[product.CategorySlug] = err == nil
+	}
+
+	if !categories[product.CategorySlug] {
+		return fmt.Errorf("category %s not found", product.CategorySlug)
+	}
+
+	existing, err := p.productRepo.GetBySKU(ctx, product.SKU)
+	if err != nil && err.Error() != "404" {
+		return err
+	}
+
+	if existing == nil {
+		err = p.productService.Create(ctx, product)
+	} else if existing.ParentID != nil {
+		return fmt.Errorf("sku %s belongs to a variant of another product", product.SKU)
+	} else {
+		product.ID = existing.ID
+		err = p.productService.Update(ctx, product)
+	}
+
+	if err != nil && err.Error() == "409" {
+		return errors.New("SKU already exists on another product")
+	}
+
+	return err
+}
+
+// buildImportItems mengelompokkan baris varian ke produk parent-nya, nomor baris dihitung dari baris header = 1.
+func buildImportItems(header map[string]int, rows [][]string) ([]entity.ProductImportItemEntity, []entity.ProductImportErrorEntity, int) {
+	importErrors := []entity.ProductImportErrorEntity{}
+	addError := func(row int, sku string, message string) {
+		importErrors = append(importErrors, entity.ProductImportErrorEntity{Row: row, SKU: sku, Message: message})
+	}
+
+	totalRows := 0
+	groups := []*productImportGroup{}
+	parents := map[string]*productImportGroup{}
+	invalidParents := map[string]bool{}
+	variantRows := []int{}
+	variants := []entity.ProductImportRowEntity{}
+
+	for i, row := range rows {
+		rowNumber := i + 2
+		if strings.TrimSpace(strings.Join(row, "")) == "" {
+			continue
+		}
+		totalRows++
+
+		req, err := parseImportRow(header, row)
+		if err == nil {
+			err = validateImportRow(req)
+		}
+		if err != nil {
+			addError(rowNumber, req.SKU, err.Error())
+			if req.ParentSKU == "" {
+				invalidParents[req.SKU] = true
+			}
+			continue
+		}
+
+		if req.ParentSKU != "" {
+			variantRows = append(variantRows, rowNumber)
+			variants = append(variants, req)
+			continue
+		}
+
+		if _, ok := parents[req.SKU]; ok {
+			addError(rowNumber, req.SKU, "duplicate sku in file")
+			continue
+		}
+
+		group := &productImportGroup{rows: []int{rowNumber}, parent: req}
+		parents[req.SKU] = group
+		groups = append(groups, group)
+	}
+
+	for i, variant := range variants {
+		group, ok := parents[variant.ParentSKU]
+		if !ok {
+			message := fmt.Sprintf("parent_sku %s not found in file", variant.ParentSKU)
+			if invalidParents[variant.ParentSKU] {
+				message = fmt.Sprintf("parent row %s is invalid", variant.ParentSKU)
+			}
+			addError(variantRows[i], variant.SKU, message)
+			continue
+		}
+
+		group.rows = append(group.rows, variantRows[i])
+		group.variants = append(group.variants, variant)
+	}
+
+	items := []entity.ProductImportItemEntity{}
+	for _, group := range groups {
+		item, err := productImportGroupToItem(*group)
+		if err != nil {
+			for _, row := range group.rows {
+				addError(row, group.parent.SKU, err.Error())
+			}
+			continue
+		}
+		items = append(items, item)
+	}
+
+	return items, importErrors, totalRows
+}
+
+func productImportGroupToItem(group productImportGroup) (entity.ProductImportItemEntity, error) {
+	options, err := parseImportOptions(group.parent.Options)
+	if err != nil {
+		return entity.ProductImportItemEntity{}, err
+	}
+
+	parent := group.parent
+	product := entity.ProductEntity{
+		CategorySlug:      parent.CategorySlug,
+		Name:              parent.ProductName,
+		SKU:               parent.SKU,
+		Image:             parent.ProductImage,
+		Description:       parent.ProductDescription,
+		RegulerPrice:      float64(parent.RegulerPrice),
+		SalePrice:         float64(parent.SalePrice),
+		Unit:              parent.Unit,
+		Weight:            parent.Weight,
+		Stock:             parent.Stock,
+		LowStockThreshold: parent.LowStockThreshold,
+		OptionValues:      splitImportList(parent.OptionValues, ";"),
+		Options:           options,
+		Variant:           parent.Variant,
+		Status:            parent.Status,
+	}
+
+	for _, val := range group.variants {
+		product.Child = append(product.Child, entity.ProductEntity{
+			SKU:               val.SKU,
+			Image:             val.ProductImage,
+			RegulerPrice:      float64(val.RegulerPrice),
+			SalePrice:         float64(val.SalePrice),
+			Weight:            val.Weight,
+			Stock:             val.Stock,
+			LowStockThreshold: val.LowStockThreshold,
+			OptionValues:      splitImportList(val.OptionValues, ";"),
+		})
+	}
+
+	if product.Variant == 0 {
+		product.Variant = len(product.Child) + 1
+	}
+
+	if err := validateImportVariants(product); err != nil {
+		return entity.ProductImportItemEntity{}, err
+	}
+
+	return entity.ProductImportItemEntity{Rows: group.rows, Product: product}, nil
+}
+
+func parseImportRow(header map[string]int, row []string) (entity.ProductImportRowEntity, error) {
+	value := func(column string) string {
+		index, ok := header[column]
+		if !ok || index >= len(row) {
+			return ""
+		}
+		return strings.TrimSpace(row[index])
+	}
+
+	req := entity.ProductImportRowEntity{
+		SKU:                value("sku"),
+		ParentSKU:          value("parent_sku"),
+		ProductName:        value("product_name"),
+		CategorySlug:       value("category_slug"),
+		Unit:               value("unit"),
+		ProductDescription: value("product_description"),
+		Status:             value("status"),
+		Options:            value("options"),
+		OptionValues:       value("option_values"),
+		ProductImage:       value("product_image"),
+	}
+
+	var variant, stock, lowStockThreshold, weight int64
+	numbers := []struct {
+		column string
+		target *int64
+	}{
+		{"variant", &variant},
+		{"stock", &stock},
+		{"low_stock_threshold", &lowStockThreshold},
+		{"weight", &weight},
+		{"reguler_price", &req.RegulerPrice},
+		{"sale_price", &req.SalePrice},
+	}
+
+	for _, number := range numbers {
+		raw := value(number.column)
+		if raw == "" {
+			continue
+		}
+
+		// Sel numerik XLSX bisa tersimpan sebagai "15000.0"
+		parsed, err := strconv.ParseFloat(raw, 64)
+		if err != nil {
+			return req, fmt.Errorf("%s must be a number", number.column)
+		}
+		*number.target = int64(parsed)
+	}
+
+	req.Variant = int(variant)
+	req.Stock = int(stock)
+	req.LowStockThreshold = int(lowStockThreshold)
+	req.Weight = int(weight)
+
+	return req, nil
+}
+
+// validateImportRow memeriksa satu baris, kolom deskriptif produk hanya wajib untuk baris parent.
+func validateImportRow(req entity.ProductImportRowEntity) error {
+	required := []struct {
+		column string
+		value  string
+	}{
+		{"sku", req.SKU},
+		{"product_image", req.ProductImage},
+	}
+	if req.ParentSKU == "" {
+		required = append(required, []struct {
+			column string
+			value  string
+		}{
+			{"product_name", req.ProductName},
+			{"category_slug", req.CategorySlug},
+			{"unit", req.Unit},
+			{"product_description", req.ProductDescription},
+			{"status", req.Status},
+		}...)
+	}
+
+	for _, val := range required {
+		if val.value == "" {
+			return fmt.Errorf("%s is a required field", val.column)
+		}
+	}
+
+	if len(req.SKU) > 64 || len(req.ParentSKU) > 64 {
+		return errors.New("sku must be a maximum of 64 characters in length")
+	}
+
+	if imageURL, err := url.ParseRequestURI(req.ProductImage); err != nil || imageURL.Scheme == "" || imageURL.Host == "" {
+		return errors.New("product_image must be a valid URL")
+	}
+
+	minimums := []struct {
+		column string
+		value  int64
+		min    int64
+	}{
+		{"variant", int64(req.Variant), 0},
+		{"stock", int64(req.Stock), 0},
+		{"low_stock_threshold", int64(req.LowStockThreshold), 0},
+		{"weight", int64(req.Weight), 1},
+		{"reguler_price", req.RegulerPrice, 1},
+		{"sale_price", req.SalePrice, 0},
+	}
+
+	for _, val := range minimums {
+		if val.value < val.min {
+			return fmt.Errorf("%s must be %d or greater", val.column, val.min)
+		}
+	}
+
+	return nil
+}
+
+// validateImportVariants memastikan SKU unik dan setiap baris memilih kombinasi nilai option yang valid dan berbeda.
+func validateImportVariants(product entity.ProductEntity) error {
+	skus := map[string]bool{}
+	combinations := map[string]bool{}
+	for _, variant := range append([]entity.ProductEntity{product}, product.Child...) {
+		if skus[variant.SKU] {
+			return fmt.Errorf("duplicate sku %s", variant.SKU)
+		}
+		skus[variant.SKU] = true
+
+		if len(variant.OptionValues) != len(product.Options) {
+			return fmt.Errorf("sku %s: option_values must have %d values", variant.SKU, len(product.Options))
+		}
+
+		if len(product.Options) == 0 {
+			continue
+		}
+
+		for j, value := range variant.OptionValues {
+			if !slices.Contains(product.Options[j].Values, value) {
+				return fmt.Errorf("sku %s: %s is not a value of option %s", variant.SKU, value, product.Options[j].Name)
+			}
+		}
+
+		key := strings.Join(variant.OptionValues, "|")
+		if combinations[key] {
+			return fmt.Errorf("sku %s: duplicate option combination %s", variant.SKU, strings.Join(variant.OptionValues, " / "))
+		}
+		combinations[key] = true
+	}
+
+	return nil
+}
+
+func parseImportOptions(raw string) ([]entity.ProductOptionEntity, error) {
+	options := []entity.ProductOptionEntity{}
+	for _, part := range splitImportList(raw, ";") {
+		name, values, ok := strings.Cut(part, ":")
+		if !ok || strings.TrimSpace(name) == "" {
+			return nil, fmt.Errorf("invalid option %q, expected format Name: value1, value2", part)
+		}
+
+		option := entity.ProductOptionEntity{
+			Name:   strings.TrimSpace(name),
+			Values: splitImportList(values, ","),
+		}
+		if len(option.Values) == 0 {
+			return nil, fmt.Errorf("option %s has no values", option.Name)
+		}
+		options = append(options, option)
+	}
+
+	if len(options) > 3 {
+		return nil, fmt.Errorf("a product can have at most 3 options")
+	}
+
+	return options, nil
+}
+
+func splitImportList(raw string, separator string) []string {
+	results := []string{}
+	for _, val := range strings.Split(raw, separator) {
+		if val = strings.TrimSpace(val); val != "" {
+			results = append(results, val)
+		}
+	}
+
+	return results
+}
+
+func NewProductImportService(repo repository.ProductImportRepositoryInterface, productRepo repository.ProductRepositoryInterface, repoCat repository.CategoryRepositoryInterface, productService ProductServiceInterface) ProductImportServiceInterface {
+	return &productImportService{repo: repo, productRepo: productRepo, repoCat: repoCat, productService: productService}
+}
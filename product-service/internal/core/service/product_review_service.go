@@ -0,0 +1,190 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"product-service/config"
+	httpclient "product-service/internal/adapter/http_client"
+	"product-service/internal/adapter/message"
+	"product-service/internal/adapter/repository"
+	"product-service/internal/adapter/storage"
+	"product-service/internal/core/domain/entity"
+	"strconv"
+	"strings"
+
+	"github.com/labstack/gommon/log"
+)
+
+type ProductReviewServiceInterface interface {
+	Create(ctx context.Context, req entity.ProductReviewEntity, accessToken string) (*entity.ProductReviewEntity, error)
+	GetByProductID(ctx context.Context, query entity.QueryStringProductReview) ([]entity.ProductReviewEntity, int64, int64, error)
+	GetAllAdmin(ctx context.Context, query entity.QueryStringProductReview) ([]entity.ProductReviewEntity, int64, int64, error)
+	Moderate(ctx context.Context, reviewID int64, status string, moderatedBy int64) (*entity.ProductReviewEntity, error)
+	Delete(ctx context.Context, reviewID int64) error
+}
+
+type productReviewService struct {
+	repo              repository.ProductReviewRepositoryInterface
+	productService    ProductServiceInterface
+	cfg               *config.Config
+	httpClient        httpclient.HttpClient
+	publisherRabbitMQ message.PublishRabbitMQInterface
+	storageHandler    storage.StorageInterface
+}
+
+// Create implements ProductReviewServiceInterface.
+// Ulasan selalu disimpan pada produk parent, customer harus memiliki order Done yang berisi produk atau variannya.
+func (p *productReviewService) Create(ctx context.Context, req entity.ProductReviewEntity, accessToken string) (*entity.ProductReviewEntity, error) {
+	product, err := p.productService.GetByID(ctx, req.ProductID)
+	if err != nil {
+		log.Errorf("[ProductReviewService-1] Create: %v", err)
+		return nil, err
+	}
+
+	if product.ParentID != nil {
+		product, err = p.productService.GetByID(ctx, *product.ParentID)
+		if err != nil {
+			log.Errorf("[ProductReviewService-2] Create: %v", err)
+			return nil, err
+		}
+	}
+
+	productIDs := []int64{product.ID}
+	for _, val := range product.Child {
+		productIDs = append(productIDs, val.ID)
+	}
+
+	orderID, err := p.httpClientOrderService(productIDs, accessToken)
+	if err != nil {
+		log.Errorf("[ProductReviewService-3] Create: %v", err)
+		return nil, err
+	}
+
+	req.ProductID = product.ID
+	req.OrderID = orderID
+	result, err := p.repo.Create(ctx, req)
+	if err != nil {
+		log.Errorf("[ProductReviewService-4] Create: %v", err)
+		return nil, err
+	}
+
+	result.ProductName = product.Name
+	return result, nil
+}
+
+// GetByProductID implements ProductReviewServiceInterface.
+// Hanya ulasan yang sudah disetujui yang ditampilkan ke publik.
+func (p *productReviewService) GetByProductID(ctx context.Context, query entity.QueryStringProductReview) ([]entity.ProductReviewEntity, int64, int64, error) {
+	product, err := p.productService.GetByID(ctx, query.ProductID)
+	if err != nil {
+		log.Errorf("[ProductReviewService-1] GetByProductID: %v", err)
+		return nil, 0, 0, err
+	}
+
+	if product.ParentID != nil {
+		query.ProductID = *product.ParentID
+	}
+
+	query.Status = "APPROVED"
+	return p.repo.GetAll(ctx, query)
+}
+
+// GetAllAdmin implements ProductReviewServiceInterface.
+func (p *productReviewService) GetAllAdmin(ctx context.Context, query entity.QueryStringProductReview) ([]entity.ProductReviewEntity, int64, int64, error) {
+	return p.repo.GetAll(ctx, query)
+}
+
+// Moderate implements ProductReviewServiceInterface.
+func (p *productReviewService) Moderate(ctx context.Context, reviewID int64, status string, moderatedBy int64) (*entity.ProductReviewEntity, error) {
+	result, err := p.repo.Moderate(ctx, reviewID, status, moderatedBy)
+	if err != nil {
+		log.Errorf("[ProductReviewService-1] Moderate: %v", err)
+		return nil, err
+	}
+
+	p.reindexProduct(ctx, result.ProductID)
+	return result, nil
+}
+
+// Delete implements ProductReviewServiceInterface.
+func (p *productReviewService) Delete(ctx context.Context, reviewID int64) error {
+	removed, err := p.repo.Delete(ctx, reviewID)
+	if err != nil {
+		log.Errorf("[ProductReviewService-1] Delete: %v", err)
+		return err
+	}
+
+	if removed.Status == "APPROVED" {
+		p.reindexProduct(ctx, removed.ProductID)
+	}
+	deleteStoredImages(p.storageHandler, removed.Photos...)
+	return nil
+}
+
+// reindexProduct mengirim ulang produk ke queue agar rating di Elasticsearch ikut diperbarui.
+func (p *productReviewService) reindexProduct(ctx context.Context, productID int64) {
+	product, err := p.productService.GetByID(ctx, productID)
+	if err != nil {
+		log.Errorf("[ProductReviewService-1] reindexProduct: %v", err)
+		return
+	}
+
+	if err := p.publisherRabbitMQ.PublishProductToQueue(*product); err != nil {
+		log.Errorf("[ProductReviewService-2] reindexProduct: %v", err)
+	}
+}
+
+// httpClientOrderService mencari order Done milik customer yang berisi salah satu productIDs.
+// Customer yang belum pernah menyelesaikan order produk mendapat error 403.
+func (p *productReviewService) httpClientOrderService(productIDs []int64, accessToken string) (int64, error) {
+	ids := []string{}
+	for _, val := range productIDs {
+		ids = append(ids, strconv.FormatInt(val, 10))
+	}
+
+	baseUrlOrder := fmt.Sprintf("%s/%s", p.cfg.App.OrderServiceUrl, "auth/orders/completed?product_ids="+strings.Join(ids, ","))
+	header := map[string]string{
+		"Authorization": "Bearer " + accessToken,
+		"Accept":        "application/json",
+	}
+	dataOrder, err := p.httpClient.CallURL("GET", baseUrlOrder, header, nil)
+	if err != nil {
+		log.Errorf("[ProductReviewService-1] httpClientOrderService: %v", err)
+		return 0, err
+	}
+
+	defer dataOrder.Body.Close()
+
+	if dataOrder.StatusCode == http.StatusNotFound {
+		return 0, errors.New("403")
+	}
+
+	body, err := io.ReadAll(dataOrder.Body)
+	if err != nil {
+		log.Errorf("[ProductReviewService-2] httpClientOrderService: %v", err)
+		return 0, err
+	}
+
+	if dataOrder.StatusCode != http.StatusOK {
+		err = fmt.Errorf("order service returned status %d: %s", dataOrder.StatusCode, string(body))
+		log.Errorf("[ProductReviewService-3] httpClientOrderService: %v", err)
+		return 0, err
+	}
+
+	var orderResponse entity.CompletedOrderHttpClientResponse
+	err = json.Unmarshal(body, &orderResponse)
+	if err != nil {
+		log.Errorf("[ProductReviewService-4] httpClientOrderService: %v", err)
+		return 0, err
+	}
+
+	return orderResponse.Data.OrderID, nil
+}
+
+func NewProductReviewService(repo repository.ProductReviewRepositoryInterface, productService ProductServiceInterface, cfg *config.Config, httpClient httpclient.HttpClient, publisherRabbitMQ message.PublishRabbitMQInterface, storageHandler storage.StorageInterface) ProductReviewServiceInterface {
+	return &productReviewService{repo: repo, productService: productService, cfg: cfg, httpClient: httpClient, publisherRabbitMQ: publisherRabbitMQ, storageHandler: storageHandler}
+}
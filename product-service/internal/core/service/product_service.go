@@ -5,6 +5,7 @@ import (
 	"errors"
 	"product-service/internal/adapter/message"
 	"product-service/internal/adapter/repository"
+	"product-service/internal/adapter/storage"
 	"product-service/internal/core/domain/entity"
 
 	"github.com/labstack/gommon/log"
@@ -17,12 +18,19 @@ type ProductServiceInterface interface {
 	Update(ctx context.Context, req entity.ProductEntity) error
 	Delete(ctx context.Context, productID int64) error
 	SearchProducts(ctx context.Context, query entity.QueryStringProduct) ([]entity.ProductEntity, int64, int64, error)
+	GetLowStock(ctx context.Context, query entity.QueryStringProduct) ([]entity.ProductEntity, int64, int64, error)
 }
 
 type productService struct {
 	repo              repository.ProductRepositoryInterface
 	publisherRabbitMQ message.PublishRabbitMQInterface
 	repoCat           repository.CategoryRepositoryInterface
+	storageHandler    storage.StorageInterface
+}
+
+// GetLowStock implements ProductServiceInterface.
+func (p *productService) GetLowStock(ctx context.Context, query entity.QueryStringProduct) ([]entity.ProductEntity, int64, int64, error) {
+	return p.repo.GetLowStock(ctx, query)
 }
 
 // SearchProducts implements ProductServiceInterface.
@@ -87,29 +95,50 @@ func (p *productService) GetByID(ctx context.Context, productID int64) (*entity.
 		return nil, errors.New("category not found")
 	}
 	result.CategoryName = resultCat.Name
+	result.TaxRate = resultCat.TaxRate
+	for key := range result.Child {
+		result.Child[key].TaxRate = resultCat.TaxRate
+	}
 	return result, nil
 }
 
 // Update implements ProductServiceInterface.
 func (p *productService) Update(ctx context.Context, req entity.ProductEntity) error {
-	err := p.repo.Update(ctx, req)
+	before, err := p.repo.GetByID(ctx, req.ID)
 	if err != nil {
 		log.Errorf("[ProductService-1] Update: %v", err)
 		return err
 	}
 
-	getProductByID, err := p.GetByID(ctx, req.ID)
+	movements, err := p.repo.Update(ctx, req)
 	if err != nil {
 		log.Errorf("[ProductService-2] Update: %v", err)
+		return err
+	}
+
+	for _, movement := range movements {
+		if err := p.publisherRabbitMQ.PublishStockAlert(movement); err != nil {
+			log.Errorf("[ProductService-3] Update: %v", err)
+		}
+	}
+
+	getProductByID, err := p.GetByID(ctx, req.ID)
+	if err != nil {
+		log.Errorf("[ProductService-4] Update: %v", err)
 	}
 
 	if err := p.publisherRabbitMQ.PublishProductToQueue(*getProductByID); err != nil {
-		log.Errorf("[ProductService-3] Update: %v", err)
+		log.Errorf("[ProductService-5] Update: %v", err)
+	}
+
+	// Gambar lama yang sudah tidak dipakai produk, child maupun galeri dihapus dari storage
+	if getProductByID != nil {
+		deleteStoredImages(p.storageHandler, replacedImageURLs(before, getProductByID)...)
 	}
 
 	return nil
 }
 
-func NewProductService(repo repository.ProductRepositoryInterface, publisherRabbitMQ message.PublishRabbitMQInterface, repoCat repository.CategoryRepositoryInterface) ProductServiceInterface {
-	return &productService{repo: repo, publisherRabbitMQ: publisherRabbitMQ, repoCat: repoCat}
+func NewProductService(repo repository.ProductRepositoryInterface, publisherRabbitMQ message.PublishRabbitMQInterface, repoCat repository.CategoryRepositoryInterface, storageHandler storage.StorageInterface) ProductServiceInterface {
+	return &productService{repo: repo, publisherRabbitMQ: publisherRabbitMQ, repoCat: repoCat, storageHandler: storageHandler}
 }
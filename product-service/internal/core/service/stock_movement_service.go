@@ -0,0 +1,80 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"product-service/internal/adapter/message"
+	"product-service/internal/adapter/repository"
+	"product-service/internal/core/domain/entity"
+
+	"github.com/labstack/gommon/log"
+)
+
+type StockMovementServiceInterface interface {
+	GetByProductID(ctx context.Context, productID int64, query entity.QueryStringStockMovement) ([]entity.StockMovementEntity, int64, int64, error)
+	PostMovement(ctx context.Context, req entity.StockMovementEntity) (*entity.StockMovementEntity, error)
+	GetReconciliation(ctx context.Context, productID int64) (*entity.StockReconciliationEntity, error)
+	Reconcile(ctx context.Context, productID int64, actorID int64) (*entity.StockReconciliationEntity, error)
+}
+
+type stockMovementService struct {
+	repo              repository.StockMovementRepositoryInterface
+	publisherRabbitMQ message.PublishRabbitMQInterface
+}
+
+// GetByProductID implements StockMovementServiceInterface.
+func (s *stockMovementService) GetByProductID(ctx context.Context, productID int64, query entity.QueryStringStockMovement) ([]entity.StockMovementEntity, int64, int64, error) {
+	return s.repo.GetByProductID(ctx, productID, query)
+}
+
+// PostMovement implements StockMovementServiceInterface.
+// Restock dan return selalu menambah stok, spoilage selalu mengurangi stok, adjustment mengikuti tanda quantity.
+func (s *stockMovementService) PostMovement(ctx context.Context, req entity.StockMovementEntity) (*entity.StockMovementEntity, error) {
+	if req.Quantity == 0 {
+		log.Errorf("[StockMovementService-1] PostMovement: %s", "quantity is zero")
+		return nil, errors.New("422")
+	}
+
+	quantity := req.Quantity
+	if quantity < 0 {
+		quantity = -quantity
+	}
+
+	switch req.Type {
+	case "restock", "return":
+		req.Quantity = quantity
+	case "spoilage":
+		req.Quantity = -quantity
+	case "adjustment":
+	default:
+		log.Errorf("[StockMovementService-2] PostMovement: invalid type %s", req.Type)
+		return nil, errors.New("422")
+	}
+
+	req.ActorType = "admin"
+	result, err := s.repo.RecordMovement(ctx, req)
+	if err != nil {
+		log.Errorf("[StockMovementService-3] PostMovement: %v", err)
+		return nil, err
+	}
+
+	if err := s.publisherRabbitMQ.PublishStockAlert(*result); err != nil {
+		log.Errorf("[StockMovementService-4] PostMovement: %v", err)
+	}
+
+	return result, nil
+}
+
+// GetReconciliation implements StockMovementServiceInterface.
+func (s *stockMovementService) GetReconciliation(ctx context.Context, productID int64) (*entity.StockReconciliationEntity, error) {
+	return s.repo.GetReconciliation(ctx, productID)
+}
+
+// Reconcile implements StockMovementServiceInterface.
+func (s *stockMovementService) Reconcile(ctx context.Context, productID int64, actorID int64) (*entity.StockReconciliationEntity, error) {
+	return s.repo.Reconcile(ctx, productID, actorID)
+}
+
+func NewStockMovementService(repo repository.StockMovementRepositoryInterface, publisherRabbitMQ message.PublishRabbitMQInterface) StockMovementServiceInterface {
+	return &stockMovementService{repo: repo, publisherRabbitMQ: publisherRabbitMQ}
+}
@@ -0,0 +1,59 @@
+package service
+
+import (
+	"product-service/internal/adapter/storage"
+	"product-service/internal/core/domain/entity"
+	"shared/imageproc"
+
+	"github.com/labstack/gommon/log"
+)
+
+// deleteStoredImages menghapus file beserta seluruh rendition-nya, URL dari luar storage diabaikan.
+// Kegagalan hanya dicatat karena data produk sudah tersimpan.
+func deleteStoredImages(storageHandler storage.StorageInterface, urls ...string) {
+	for _, url := range urls {
+		path := storageHandler.PathFromURL(url)
+		if path == "" {
+			continue
+		}
+
+		for _, renditionPath := range imageproc.RenditionPaths(path) {
+			if err := storageHandler.DeleteFile(renditionPath); err != nil {
+				log.Errorf("[StorageCleanup-1] deleteStoredImages: %v", err)
+			}
+		}
+	}
+}
+
+// productImageURLs mengumpulkan URL gambar produk, child dan galerinya.
+func productImageURLs(product *entity.ProductEntity) map[string]bool {
+	results := map[string]bool{}
+	if product == nil {
+		return results
+	}
+
+	results[product.Image] = true
+	for _, val := range product.Child {
+		results[val.Image] = true
+	}
+	for _, val := range product.Images {
+		results[val.URL] = true
+	}
+	delete(results, "")
+
+	return results
+}
+
+// replacedImageURLs berisi URL yang dipakai sebelum perubahan tetapi tidak lagi dipakai sesudahnya.
+func replacedImageURLs(before, after *entity.ProductEntity) []string {
+	afterURLs := productImageURLs(after)
+
+	results := []string{}
+	for url := range productImageURLs(before) {
+		if !afterURLs[url] {
+			results = append(results, url)
+		}
+	}
+
+	return results
+}
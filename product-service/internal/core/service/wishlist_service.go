@@ -0,0 +1,113 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"product-service/internal/adapter/repository"
+	"product-service/internal/core/domain/entity"
+
+	"github.com/labstack/gommon/log"
+)
+
+type WishlistServiceInterface interface {
+	GetByUserID(ctx context.Context, userID int64) ([]entity.WishlistEntity, error)
+	Add(ctx context.Context, userID int64, productID int64, variantID int64) (*entity.WishlistEntity, error)
+	Remove(ctx context.Context, userID int64, wishlistID int64) error
+	MoveToCart(ctx context.Context, userID int64, wishlistID int64, quantity int64) error
+	GetCountsAdmin(ctx context.Context, query entity.QueryStringWishlist) ([]entity.WishlistCountEntity, int64, int64, error)
+}
+
+type wishlistService struct {
+	repo           repository.WishlistRepositoryInterface
+	productService ProductServiceInterface
+	cartService    CartServiceInterface
+}
+
+// GetByUserID implements WishlistServiceInterface.
+func (w *wishlistService) GetByUserID(ctx context.Context, userID int64) ([]entity.WishlistEntity, error) {
+	return w.repo.GetByUserID(ctx, userID)
+}
+
+// Add implements WishlistServiceInterface.
+// Wishlist selalu disimpan pada produk parent, ID varian yang dikirim sebagai productID ikut dinormalisasi.
+func (w *wishlistService) Add(ctx context.Context, userID int64, productID int64, variantID int64) (*entity.WishlistEntity, error) {
+	product, err := w.productService.GetByID(ctx, productID)
+	if err != nil {
+		log.Errorf("[WishlistService-1] Add: %v", err)
+		return nil, err
+	}
+
+	if product.ParentID != nil {
+		variantID = product.ID
+		productID = *product.ParentID
+	}
+
+	if variantID != 0 && variantID != productID {
+		variant, err := w.productService.GetByID(ctx, variantID)
+		if err != nil || variant.ParentID == nil || *variant.ParentID != productID {
+			log.Errorf("[WishlistService-2] Add: variant %d does not belong to product %d", variantID, productID)
+			return nil, errors.New("422")
+		}
+	}
+
+	if variantID == productID {
+		variantID = 0
+	}
+
+	return w.repo.Add(ctx, entity.WishlistEntity{
+		UserID:    userID,
+		ProductID: productID,
+		VariantID: variantID,
+	})
+}
+
+// Remove implements WishlistServiceInterface.
+func (w *wishlistService) Remove(ctx context.Context, userID int64, wishlistID int64) error {
+	return w.repo.Remove(ctx, userID, wishlistID)
+}
+
+// MoveToCart implements WishlistServiceInterface.
+// Produk bervarian harus sudah memilih varian, item dihapus dari wishlist setelah masuk keranjang.
+func (w *wishlistService) MoveToCart(ctx context.Context, userID int64, wishlistID int64, quantity int64) error {
+	item, err := w.repo.GetByID(ctx, userID, wishlistID)
+	if err != nil {
+		log.Errorf("[WishlistService-1] MoveToCart: %v", err)
+		return err
+	}
+
+	if item.HasVariant && item.VariantID == 0 {
+		log.Errorf("[WishlistService-2] MoveToCart: product %d requires a variant", item.ProductID)
+		return errors.New("422")
+	}
+
+	if item.ProductStatus != "ACTIVE" || int64(item.Stock) < quantity {
+		log.Errorf("[WishlistService-3] MoveToCart: product %d is not available", item.ProductID)
+		return errors.New("409")
+	}
+
+	err = w.cartService.AddToCart(ctx, userID, entity.CartItem{
+		ProductID: item.ProductID,
+		VariantID: item.VariantID,
+		Quantity:  quantity,
+	})
+	if err != nil {
+		log.Errorf("[WishlistService-4] MoveToCart: %v", err)
+		return err
+	}
+
+	if err := w.repo.Remove(ctx, userID, wishlistID); err != nil {
+		log.Errorf("[WishlistService-5] MoveToCart: %v", err)
+		return err
+	}
+
+	return nil
+}
+
+// GetCountsAdmin implements WishlistServiceInterface.
+func (w *wishlistService) GetCountsAdmin(ctx context.Context, query entity.QueryStringWishlist) ([]entity.WishlistCountEntity, int64, int64, error) {
+	return w.repo.GetCounts(ctx, query)
+}
+
+func NewWishlistService(repo repository.WishlistRepositoryInterface, productService ProductServiceInterface, cartService CartServiceInterface) WishlistServiceInterface {
+	return &wishlistService{repo: repo, productService: productService, cartService: cartService}
+}
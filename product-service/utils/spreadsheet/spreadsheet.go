@@ -0,0 +1,321 @@
+package spreadsheet
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/csv"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"strconv"
+	"strings"
+)
+
+var ErrUnsupportedFile = errors.New("415")
+
+const (
+	// maxXLSXEntrySize membatasi ukuran satu file XML di dalam XLSX setelah didekompresi agar zip bomb tidak menghabiskan memori.
+	maxXLSXEntrySize = 50 << 20
+	// maxXLSXColumns adalah jumlah kolom maksimum sheet Excel (XFD).
+	maxXLSXColumns = 16384
+)
+
+// Read membaca file CSV atau XLSX (sheet pertama) menjadi baris-baris string.
+// Format ditentukan dari isi file, XLSX selalu diawali signature zip "PK".
+func Read(data []byte) ([][]string, error) {
+	if bytes.HasPrefix(data, []byte("PK\x03\x04")) {
+		return readXLSX(data)
+	}
+
+	reader := csv.NewReader(bytes.NewReader(bytes.TrimPrefix(data, []byte("\xef\xbb\xbf"))))
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	rows, err := reader.ReadAll()
+	if err != nil {
+		return nil, ErrUnsupportedFile
+	}
+
+	return rows, nil
+}
+
+// WriteCSV menulis baris ke w dalam format CSV.
+func WriteCSV(w io.Writer, rows [][]string) error {
+	writer := csv.NewWriter(w)
+	if err := writer.WriteAll(rows); err != nil {
+		return err
+	}
+
+	return writer.Error()
+}
+
+type xlsxRelationships struct {
+	Relationships []struct {
+		ID     string `xml:"Id,attr"`
+		Target string `xml:"Target,attr"`
+	} `xml:"Relationship"`
+}
+
+type xlsxWorkbook struct {
+	Sheets []struct {
+		RelationID string `xml:"http://schemas.openxmlformats.org/officeDocument/2006/relationships id,attr"`
+	} `xml:"sheets>sheet"`
+}
+
+type xlsxText struct {
+	Text string `xml:"t"`
+	Runs []struct {
+		Text string `xml:"t"`
+	} `xml:"r"`
+}
+
+func (x xlsxText) String() string {
+	if len(x.Runs) == 0 {
+		return x.Text
+	}
+
+	var builder strings.Builder
+	for _, run := range x.Runs {
+		builder.WriteString(run.Text)
+	}
+	return builder.String()
+}
+
+type xlsxSheet struct {
+	Rows []struct {
+		Cells []struct {
+			Ref    string   `xml:"r,attr"`
+			Type   string   `xml:"t,attr"`
+			Value  string   `xml:"v"`
+			Inline xlsxText `xml:"is"`
+		} `xml:"c"`
+	} `xml:"sheetData>row"`
+}
+
+func readXLSX(data []byte) ([][]string, error) {
+	archive, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		return nil, ErrUnsupportedFile
+	}
+
+	files := map[string]*zip.File{}
+	for _, file := range archive.File {
+		files[file.Name] = file
+	}
+
+	sheetPath, err := firstSheetPath(files)
+	if err != nil {
+		return nil, err
+	}
+
+	sharedStrings := []string{}
+	if file, ok := files["xl/sharedStrings.xml"]; ok {
+		var table struct {
+			Items []xlsxText `xml:"si"`
+		}
+		if err := decodeZipXML(file, &table); err != nil {
+			return nil, err
+		}
+		for _, item := range table.Items {
+			sharedStrings = append(sharedStrings, item.String())
+		}
+	}
+
+	file, ok := files[sheetPath]
+	if !ok {
+		return nil, ErrUnsupportedFile
+	}
+
+	var sheet xlsxSheet
+	if err := decodeZipXML(file, &sheet); err != nil {
+		return nil, err
+	}
+
+	rows := [][]string{}
+	for _, row := range sheet.Rows {
+		values := []string{}
+		for i, cell := range row.Cells {
+			column := i
+			if cell.Ref != "" {
+				column = columnIndex(cell.Ref)
+			}
+			if column < 0 || column >= maxXLSXColumns {
+				return nil, ErrUnsupportedFile
+			}
+			for len(values) <= column {
+				values = append(values, "")
+			}
+
+			switch cell.Type {
+			case "s":
+				index, err := strconv.Atoi(cell.Value)
+				if err != nil || index < 0 || index >= len(sharedStrings) {
+					return nil, ErrUnsupportedFile
+				}
+				values[column] = sharedStrings[index]
+			case "inlineStr":
+				values[column] = cell.Inline.String()
+			default:
+				values[column] = cell.Value
+			}
+		}
+		rows = append(rows, values)
+	}
+
+	return rows, nil
+}
+
+// firstSheetPath mencari file sheet pertama melalui workbook.xml dan relasinya.
+func firstSheetPath(files map[string]*zip.File) (string, error) {
+	workbookFile, ok := files["xl/workbook.xml"]
+	if !ok {
+		return "", ErrUnsupportedFile
+	}
+
+	var workbook xlsxWorkbook
+	if err := decodeZipXML(workbookFile, &workbook); err != nil || len(workbook.Sheets) == 0 {
+		return "", ErrUnsupportedFile
+	}
+
+	relsFile, ok := files["xl/_rels/workbook.xml.rels"]
+	if !ok {
+		return "xl/worksheets/sheet1.xml", nil
+	}
+
+	var rels xlsxRelationships
+	if err := decodeZipXML(relsFile, &rels); err != nil {
+		return "", err
+	}
+
+	for _, rel := range rels.Relationships {
+		if rel.ID != workbook.Sheets[0].RelationID {
+			continue
+		}
+
+		if strings.HasPrefix(rel.Target, "/") {
+			return strings.TrimPrefix(rel.Target, "/"), nil
+		}
+		return path.Join("xl", rel.Target), nil
+	}
+
+	return "", ErrUnsupportedFile
+}
+
+// decodeZipXML membaca paling banyak maxXLSXEntrySize byte, ukuran di header zip bisa dipalsukan
+// sehingga pembacaan tetap dibatasi dengan LimitReader.
+func decodeZipXML(file *zip.File, v any) error {
+	if file.UncompressedSize64 > maxXLSXEntrySize {
+		return ErrUnsupportedFile
+	}
+
+	reader, err := file.Open()
+	if err != nil {
+		return ErrUnsupportedFile
+	}
+	defer reader.Close()
+
+	if err := xml.NewDecoder(io.LimitReader(reader, maxXLSXEntrySize)).Decode(v); err != nil {
+		return ErrUnsupportedFile
+	}
+
+	return nil
+}
+
+// columnIndex mengubah referensi sel seperti "AB12" menjadi index kolom berbasis 0.
+func columnIndex(ref string) int {
+	index := 0
+	for _, char := range ref {
+		if char < 'A' || char > 'Z' {
+			break
+		}
+		index = index*26 + int(char-'A'+1)
+	}
+
+	return index - 1
+}
+
+func columnName(index int) string {
+	name := ""
+	for index >= 0 {
+		name = string(rune('A'+index%26)) + name
+		index = index/26 - 1
+	}
+
+	return name
+}
+
+// WriteXLSX menulis baris ke w sebagai workbook XLSX dengan satu sheet.
+// Angka bulat ditulis sebagai sel numerik, selain itu sebagai inline string.
+func WriteXLSX(w io.Writer, sheetName string, rows [][]string) error {
+	archive := zip.NewWriter(w)
+
+	staticFiles := []struct {
+		name    string
+		content string
+	}{
+		{"[Content_Types].xml", `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Types xmlns="http://schemas.openxmlformats.org/package/2006/content-types"><Default Extension="rels" ContentType="application/vnd.openxmlformats-package.relationships+xml"/><Default Extension="xml" ContentType="application/xml"/><Override PartName="/xl/workbook.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.sheet.main+xml"/><Override PartName="/xl/worksheets/sheet1.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.worksheet+xml"/></Types>`},
+		{"_rels/.rels", `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships"><Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/officeDocument" Target="xl/workbook.xml"/></Relationships>`},
+		{"xl/_rels/workbook.xml.rels", `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships"><Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/worksheet" Target="worksheets/sheet1.xml"/></Relationships>`},
+		{"xl/workbook.xml", fmt.Sprintf(`<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<workbook xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships"><sheets><sheet name="%s" sheetId="1" r:id="rId1"/></sheets></workbook>`, escapeXML(sheetName))},
+	}
+
+	for _, file := range staticFiles {
+		writer, err := archive.Create(file.name)
+		if err != nil {
+			return err
+		}
+		if _, err := io.WriteString(writer, file.content); err != nil {
+			return err
+		}
+	}
+
+	writer, err := archive.Create("xl/worksheets/sheet1.xml")
+	if err != nil {
+		return err
+	}
+
+	var builder strings.Builder
+	builder.WriteString(`<?xml version="1.0" encoding="UTF-8" standalone="yes"?>` + "\n")
+	builder.WriteString(`<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main"><sheetData>`)
+	for i, row := range rows {
+		fmt.Fprintf(&builder, `<row r="%d">`, i+1)
+		for j, value := range row {
+			ref := fmt.Sprintf("%s%d", columnName(j), i+1)
+			if isPlainInteger(value) {
+				fmt.Fprintf(&builder, `<c r="%s"><v>%s</v></c>`, ref, value)
+				continue
+			}
+			fmt.Fprintf(&builder, `<c r="%s" t="inlineStr"><is><t xml:space="preserve">%s</t></is></c>`, ref, escapeXML(value))
+		}
+		builder.WriteString(`</row>`)
+	}
+	builder.WriteString(`</sheetData></worksheet>`)
+
+	if _, err := io.WriteString(writer, builder.String()); err != nil {
+		return err
+	}
+
+	return archive.Close()
+}
+
+// isPlainInteger menjaga nilai seperti SKU "00123" tetap string agar nol di depan tidak hilang.
+func isPlainInteger(value string) bool {
+	if value == "" || len(value) >= 16 || (len(value) > 1 && value[0] == '0') {
+		return false
+	}
+
+	_, err := strconv.ParseInt(value, 10, 64)
+	return err == nil
+}
+
+func escapeXML(value string) string {
+	var buf bytes.Buffer
+	xml.EscapeText(&buf, []byte(value))
+	return buf.String()
+}
@@ -0,0 +1,16 @@
+module shared
+
+go 1.21.6
+
+require (
+	github.com/labstack/gommon v0.4.2
+	github.com/supabase-community/storage-go v0.7.0
+)
+
+require (
+	github.com/mattn/go-colorable v0.1.13 // indirect
+	github.com/mattn/go-isatty v0.0.20 // indirect
+	github.com/valyala/bytebufferpool v1.0.0 // indirect
+	github.com/valyala/fasttemplate v1.2.2 // indirect
+	golang.org/x/sys v0.15.0 // indirect
+)
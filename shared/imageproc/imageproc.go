@@ -0,0 +1,279 @@
+package imageproc
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"image"
+	"image/draw"
+	_ "image/gif"
+	"image/jpeg"
+	_ "image/png"
+	"net/http"
+	"shared/webp"
+	"strings"
+)
+
+const (
+	// Batas jumlah piksel untuk mencegah decompression bomb
+	maxPixels   = 40_000_000
+	jpegQuality = 85
+)
+
+var ErrUnsupportedImage = errors.New("415")
+
+// allowedTypes berisi MIME hasil sniffing yang bisa di-decode oleh library standar.
+var allowedTypes = map[string]bool{
+	"image/jpeg": true,
+	"image/png":  true,
+	"image/gif":  true,
+}
+
+type Rendition struct {
+	Name        string
+	Width       int
+	Height      int
+	ContentType string
+	Extension   string
+	Data        []byte
+}
+
+type renditionSize struct {
+	name     string
+	maxWidth int
+}
+
+var renditionSizes = []renditionSize{
+	{name: "thumbnail", maxWidth: 200},
+	{name: "medium", maxWidth: 600},
+	{name: "large", maxWidth: 1200},
+}
+
+// Process memvalidasi file berdasarkan isi (bukan ekstensi), lalu menghasilkan rendition thumbnail, medium dan large.
+// Image dengan transparansi di-encode ke WebP lossless agar alpha tetap utuh, image tanpa transparansi (foto)
+// di-encode ke JPEG karena WebP lossless untuk foto jauh lebih besar. Metadata EXIF ikut terbuang saat encode ulang.
+func Process(data []byte) ([]Rendition, error) {
+	if !allowedTypes[http.DetectContentType(data)] {
+		return nil, ErrUnsupportedImage
+	}
+
+	cfg, _, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil || cfg.Width*cfg.Height > maxPixels {
+		return nil, ErrUnsupportedImage
+	}
+
+	src, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, ErrUnsupportedImage
+	}
+
+	// Orientasi dari EXIF diterapkan dulu karena EXIF tidak ikut tersimpan di hasil encode
+	img := applyOrientation(toRGBA(src), exifOrientation(data))
+	transparent := !img.Opaque()
+
+	results := []Rendition{}
+	for _, size := range renditionSizes {
+		resized := resize(img, size.maxWidth)
+		rendition := Rendition{
+			Name:        size.name,
+			Width:       resized.Bounds().Dx(),
+			Height:      resized.Bounds().Dy(),
+			ContentType: "image/jpeg",
+			Extension:   ".jpg",
+		}
+
+		buf := new(bytes.Buffer)
+		if transparent {
+			rendition.ContentType = "image/webp"
+			rendition.Extension = ".webp"
+			err = webp.Encode(buf, resized)
+		} else {
+			err = jpeg.Encode(buf, resized, &jpeg.Options{Quality: jpegQuality})
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		rendition.Data = buf.Bytes()
+		results = append(results, rendition)
+	}
+
+	return results, nil
+}
+
+// toRGBA menyalin image ke RGBA berukuran sama dengan titik awal (0, 0), piksel transparan tetap transparan.
+func toRGBA(src image.Image) *image.RGBA {
+	bounds := src.Bounds()
+	dst := image.NewRGBA(image.Rect(0, 0, bounds.Dx(), bounds.Dy()))
+	draw.Draw(dst, dst.Bounds(), src, bounds.Min, draw.Src)
+	return dst
+}
+
+// resize mengecilkan image dengan rata-rata area (box filter), image yang lebih kecil dari maxWidth tidak diperbesar.
+func resize(src *image.RGBA, maxWidth int) *image.RGBA {
+	srcW, srcH := src.Bounds().Dx(), src.Bounds().Dy()
+	if srcW <= maxWidth {
+		return src
+	}
+
+	dstW := maxWidth
+	dstH := srcH * maxWidth / srcW
+	if dstH < 1 {
+		dstH = 1
+	}
+
+	dst := image.NewRGBA(image.Rect(0, 0, dstW, dstH))
+	for y := 0; y < dstH; y++ {
+		y0 := y * srcH / dstH
+		y1 := max((y+1)*srcH/dstH, y0+1)
+		for x := 0; x < dstW; x++ {
+			x0 := x * srcW / dstW
+			x1 := max((x+1)*srcW/dstW, x0+1)
+
+			var r, g, b, a, n uint32
+			for sy := y0; sy < y1; sy++ {
+				offset := sy*src.Stride + x0*4
+				for sx := x0; sx < x1; sx++ {
+					r += uint32(src.Pix[offset])
+					g += uint32(src.Pix[offset+1])
+					b += uint32(src.Pix[offset+2])
+					a += uint32(src.Pix[offset+3])
+					offset += 4
+					n++
+				}
+			}
+
+			i := y*dst.Stride + x*4
+			dst.Pix[i] = uint8(r / n)
+			dst.Pix[i+1] = uint8(g / n)
+			dst.Pix[i+2] = uint8(b / n)
+			dst.Pix[i+3] = uint8(a / n)
+		}
+	}
+
+	return dst
+}
+
+// applyOrientation memutar/membalik image sesuai tag orientasi EXIF (1-8).
+func applyOrientation(src *image.RGBA, orientation int) *image.RGBA {
+	if orientation < 2 || orientation > 8 {
+		return src
+	}
+
+	w, h := src.Bounds().Dx(), src.Bounds().Dy()
+	dstW, dstH := w, h
+	if orientation >= 5 {
+		dstW, dstH = h, w
+	}
+
+	dst := image.NewRGBA(image.Rect(0, 0, dstW, dstH))
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			var dx, dy int
+			switch orientation {
+			case 2:
+				dx, dy = w-1-x, y
+			case 3:
+				dx, dy = w-1-x, h-1-y
+			case 4:
+				dx, dy = x, h-1-y
+			case 5:
+				dx, dy = y, x
+			case 6:
+				dx, dy = h-1-y, x
+			case 7:
+				dx, dy = h-1-y, w-1-x
+			case 8:
+				dx, dy = y, w-1-x
+			}
+
+			si := y*src.Stride + x*4
+			di := dy*dst.Stride + dx*4
+			copy(dst.Pix[di:di+4], src.Pix[si:si+4])
+		}
+	}
+
+	return dst
+}
+
+// exifOrientation membaca tag orientasi (0x0112) dari segmen APP1 JPEG, mengembalikan 1 bila tidak ada.
+func exifOrientation(data []byte) int {
+	if len(data) < 4 || data[0] != 0xFF || data[1] != 0xD8 {
+		return 1
+	}
+
+	pos := 2
+	for pos+4 <= len(data) {
+		if data[pos] != 0xFF {
+			return 1
+		}
+
+		marker := data[pos+1]
+		length := int(binary.BigEndian.Uint16(data[pos+2:]))
+		if marker == 0xDA || length < 2 || pos+2+length > len(data) {
+			return 1
+		}
+
+		segment := data[pos+4 : pos+2+length]
+		if marker == 0xE1 && len(segment) > 14 && string(segment[:6]) == "Exif\x00\x00" {
+			return readOrientation(segment[6:])
+		}
+
+		pos += 2 + length
+	}
+
+	return 1
+}
+
+func readOrientation(tiff []byte) int {
+	var order binary.ByteOrder
+	switch string(tiff[:2]) {
+	case "II":
+		order = binary.LittleEndian
+	case "MM":
+		order = binary.BigEndian
+	default:
+		return 1
+	}
+
+	ifd := int(order.Uint32(tiff[4:]))
+	if ifd+2 > len(tiff) {
+		return 1
+	}
+
+	entries := int(order.Uint16(tiff[ifd:]))
+	for i := 0; i < entries; i++ {
+		entry := ifd + 2 + i*12
+		if entry+12 > len(tiff) {
+			return 1
+		}
+
+		if order.Uint16(tiff[entry:]) == 0x0112 {
+			return int(order.Uint16(tiff[entry+8:]))
+		}
+	}
+
+	return 1
+}
+
+// RenditionPaths mengembalikan path semua rendition dari path salah satu rendition ({nama}_{rendition}.jpg atau .webp).
+// Path yang bukan hasil Process dikembalikan apa adanya.
+func RenditionPaths(path string) []string {
+	for _, extension := range []string{".jpg", ".webp"} {
+		for _, size := range renditionSizes {
+			suffix := "_" + size.name + extension
+			if !strings.HasSuffix(path, suffix) {
+				continue
+			}
+
+			base := strings.TrimSuffix(path, suffix)
+			results := []string{}
+			for _, val := range renditionSizes {
+				results = append(results, base+"_"+val.name+extension)
+			}
+			return results
+		}
+	}
+
+	return []string{path}
+}
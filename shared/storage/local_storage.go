@@ -0,0 +1,132 @@
+package storage
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/labstack/gommon/log"
+)
+
+// LocalStorageInterface menyimpan file di filesystem dan disajikan sendiri oleh service melalui route /storage.
+type LocalStorageInterface interface {
+	StorageInterface
+	FilePath(path string) (string, error)
+	VerifySignature(path string, expires int64, signature string) bool
+}
+
+type localStorage struct {
+	root       string
+	baseURL    string
+	signingKey string
+}
+
+// UploadFile implements StorageInterface.
+func (l *localStorage) UploadFile(path string, contentType string, file io.Reader) (string, error) {
+	fullPath, err := l.FilePath(path)
+	if err != nil {
+		log.Errorf("Error uploading file: %v", err)
+		return "", err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(fullPath), 0o755); err != nil {
+		log.Errorf("Error uploading file: %v", err)
+		return "", err
+	}
+
+	dst, err := os.Create(fullPath)
+	if err != nil {
+		log.Errorf("Error uploading file: %v", err)
+		return "", err
+	}
+	defer dst.Close()
+
+	if _, err := io.Copy(dst, file); err != nil {
+		log.Errorf("Error uploading file: %v", err)
+		return "", err
+	}
+
+	return l.baseURL + "/" + path, nil
+}
+
+// DeleteFile implements StorageInterface.
+func (l *localStorage) DeleteFile(path string) error {
+	fullPath, err := l.FilePath(path)
+	if err != nil {
+		return err
+	}
+
+	if err := os.Remove(fullPath); err != nil && !errors.Is(err, os.ErrNotExist) {
+		log.Errorf("Error deleting file: %v", err)
+		return err
+	}
+
+	return nil
+}
+
+// SignedURL implements StorageInterface.
+func (l *localStorage) SignedURL(path string, expiresIn time.Duration) (string, error) {
+	if _, err := l.FilePath(path); err != nil {
+		return "", err
+	}
+
+	expires := time.Now().Add(expiresIn).Unix()
+	return fmt.Sprintf("%s/%s?expires=%d&signature=%s", l.baseURL, path, expires, l.sign(path, expires)), nil
+}
+
+// PathFromURL implements StorageInterface.
+func (l *localStorage) PathFromURL(url string) string {
+	return trimURLPrefix(url, l.baseURL)
+}
+
+// FilePath implements LocalStorageInterface.
+// Path yang keluar dari root storage (path traversal) ditolak.
+func (l *localStorage) FilePath(path string) (string, error) {
+	cleanPath := filepath.Clean("/" + path)
+	if cleanPath == "/" {
+		return "", errors.New("404")
+	}
+
+	return filepath.Join(l.root, cleanPath), nil
+}
+
+// VerifySignature implements LocalStorageInterface.
+func (l *localStorage) VerifySignature(path string, expires int64, signature string) bool {
+	if expires < time.Now().Unix() {
+		return false
+	}
+
+	return hmac.Equal([]byte(l.sign(path, expires)), []byte(signature))
+}
+
+func (l *localStorage) sign(path string, expires int64) string {
+	mac := hmac.New(sha256.New, []byte(l.signingKey))
+	mac.Write([]byte(fmt.Sprintf("%s\n%d", path, expires)))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// NewLocalStorage membutuhkan SigningKey, service mengisinya dengan JWT secret bila LOCAL_STORAGE_SIGNING_KEY kosong.
+func NewLocalStorage(cfg LocalConfig) LocalStorageInterface {
+	root := cfg.Path
+	if root == "" {
+		root = "./storage"
+	}
+
+	baseURL := strings.TrimSuffix(cfg.BaseURL, "/")
+	if baseURL == "" {
+		baseURL = "/storage"
+	}
+
+	if cfg.SigningKey == "" {
+		log.Fatalf("[NewLocalStorage-1] local storage signing key is empty")
+	}
+
+	return &localStorage{root: root, baseURL: baseURL, signingKey: cfg.SigningKey}
+}
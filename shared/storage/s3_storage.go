@@ -0,0 +1,228 @@
+package storage
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/labstack/gommon/log"
+)
+
+const s3DateFormat = "20060102T150405Z"
+
+// s3Storage berbicara langsung dengan API S3 (AWS Signature V4, path-style) sehingga bisa dipakai untuk MinIO.
+type s3Storage struct {
+	cfg        S3Config
+	endpoint   *url.URL
+	httpClient *http.Client
+}
+
+// UploadFile implements StorageInterface.
+func (s *s3Storage) UploadFile(path string, contentType string, file io.Reader) (string, error) {
+	body, err := io.ReadAll(file)
+	if err != nil {
+		log.Errorf("Error uploading file: %v", err)
+		return "", err
+	}
+
+	req, err := http.NewRequest(http.MethodPut, s.objectURL(path).String(), bytes.NewReader(body))
+	if err != nil {
+		log.Errorf("Error uploading file: %v", err)
+		return "", err
+	}
+	req.Header.Set("Content-Type", contentType)
+
+	if err := s.do(req, body); err != nil {
+		log.Errorf("Error uploading file: %v", err)
+		return "", err
+	}
+
+	return s.publicURL(path), nil
+}
+
+// DeleteFile implements StorageInterface.
+func (s *s3Storage) DeleteFile(path string) error {
+	req, err := http.NewRequest(http.MethodDelete, s.objectURL(path).String(), nil)
+	if err != nil {
+		return err
+	}
+
+	if err := s.do(req, nil); err != nil {
+		log.Errorf("Error deleting file: %v", err)
+		return err
+	}
+
+	return nil
+}
+
+// SignedURL implements StorageInterface.
+// URL presigned GET dengan payload UNSIGNED-PAYLOAD sesuai spesifikasi query string SigV4.
+func (s *s3Storage) SignedURL(path string, expiresIn time.Duration) (string, error) {
+	now := time.Now().UTC()
+	objectURL := s.objectURL(path)
+
+	query := url.Values{}
+	query.Set("X-Amz-Algorithm", "AWS4-HMAC-SHA256")
+	query.Set("X-Amz-Credential", s.cfg.AccessKey+"/"+s.scope(now))
+	query.Set("X-Amz-Date", now.Format(s3DateFormat))
+	query.Set("X-Amz-Expires", fmt.Sprintf("%d", int64(expiresIn.Seconds())))
+	query.Set("X-Amz-SignedHeaders", "host")
+
+	canonicalRequest := strings.Join([]string{
+		http.MethodGet,
+		objectURL.EscapedPath(),
+		canonicalQuery(query),
+		"host:" + objectURL.Host + "\n",
+		"host",
+		"UNSIGNED-PAYLOAD",
+	}, "\n")
+
+	query.Set("X-Amz-Signature", s.signature(now, canonicalRequest))
+	objectURL.RawQuery = canonicalQuery(query)
+
+	return objectURL.String(), nil
+}
+
+// PathFromURL implements StorageInterface.
+func (s *s3Storage) PathFromURL(url string) string {
+	return trimURLPrefix(url, s.publicURL(""))
+}
+
+func (s *s3Storage) objectURL(path string) *url.URL {
+	objectURL := *s.endpoint
+	objectURL.Path = strings.TrimSuffix(objectURL.Path, "/") + "/" + s.cfg.Bucket + "/" + strings.TrimPrefix(path, "/")
+	return &objectURL
+}
+
+func (s *s3Storage) publicURL(path string) string {
+	if s.cfg.PublicURL != "" {
+		return strings.TrimSuffix(s.cfg.PublicURL, "/") + "/" + path
+	}
+
+	return s.objectURL(path).String()
+}
+
+func (s *s3Storage) do(req *http.Request, body []byte) error {
+	now := time.Now().UTC()
+	payloadHash := sha256Hex(body)
+
+	req.Header.Set("Host", req.URL.Host)
+	req.Header.Set("X-Amz-Date", now.Format(s3DateFormat))
+	req.Header.Set("X-Amz-Content-Sha256", payloadHash)
+
+	signedHeaders := []string{"host", "x-amz-content-sha256", "x-amz-date"}
+	if req.Header.Get("Content-Type") != "" {
+		signedHeaders = append([]string{"content-type"}, signedHeaders...)
+	}
+
+	canonicalHeaders := ""
+	for _, name := range signedHeaders {
+		value := req.Header.Get(name)
+		if name == "host" {
+			value = req.URL.Host
+		}
+		canonicalHeaders += name + ":" + strings.TrimSpace(value) + "\n"
+	}
+
+	canonicalRequest := strings.Join([]string{
+		req.Method,
+		req.URL.EscapedPath(),
+		canonicalQuery(req.URL.Query()),
+		canonicalHeaders,
+		strings.Join(signedHeaders, ";"),
+		payloadHash,
+	}, "\n")
+
+	req.Header.Set("Authorization", fmt.Sprintf("AWS4-HMAC-SHA256 Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		s.cfg.AccessKey, s.scope(now), strings.Join(signedHeaders, ";"), s.signature(now, canonicalRequest)))
+
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= http.StatusBadRequest {
+		message, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return fmt.Errorf("s3 %s %s: %d %s", req.Method, req.URL.Path, resp.StatusCode, string(message))
+	}
+
+	return nil
+}
+
+func (s *s3Storage) scope(now time.Time) string {
+	return fmt.Sprintf("%s/%s/s3/aws4_request", now.Format("20060102"), s.region())
+}
+
+func (s *s3Storage) signature(now time.Time, canonicalRequest string) string {
+	stringToSign := strings.Join([]string{
+		"AWS4-HMAC-SHA256",
+		now.Format(s3DateFormat),
+		s.scope(now),
+		sha256Hex([]byte(canonicalRequest)),
+	}, "\n")
+
+	key := hmacSHA256([]byte("AWS4"+s.cfg.SecretKey), now.Format("20060102"))
+	key = hmacSHA256(key, s.region())
+	key = hmacSHA256(key, "s3")
+	key = hmacSHA256(key, "aws4_request")
+
+	return hex.EncodeToString(hmacSHA256(key, stringToSign))
+}
+
+func (s *s3Storage) region() string {
+	if s.cfg.Region == "" {
+		return "us-east-1"
+	}
+	return s.cfg.Region
+}
+
+// canonicalQuery mengurutkan query dan memakai encoding %20 seperti yang diminta SigV4.
+func canonicalQuery(query url.Values) string {
+	keys := make([]string, 0, len(query))
+	for key := range query {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	pairs := []string{}
+	for _, key := range keys {
+		for _, value := range query[key] {
+			pairs = append(pairs, s3Escape(key)+"="+s3Escape(value))
+		}
+	}
+
+	return strings.Join(pairs, "&")
+}
+
+func s3Escape(value string) string {
+	return strings.ReplaceAll(url.QueryEscape(value), "+", "%20")
+}
+
+func sha256Hex(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(data))
+	return mac.Sum(nil)
+}
+
+func NewS3Storage(cfg S3Config) StorageInterface {
+	endpoint, err := url.Parse(cfg.Endpoint)
+	if err != nil {
+		log.Fatalf("[NewS3Storage-1] invalid S3_ENDPOINT: %v", err)
+	}
+
+	return &s3Storage{cfg: cfg, endpoint: endpoint, httpClient: &http.Client{Timeout: 30 * time.Second}}
+}
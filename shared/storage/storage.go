@@ -0,0 +1,70 @@
+package storage
+
+import (
+	"io"
+	"strings"
+	"time"
+)
+
+type StorageInterface interface {
+	UploadFile(path, contentType string, file io.Reader) (string, error)
+	DeleteFile(path string) error
+	SignedURL(path string, expiresIn time.Duration) (string, error)
+	// PathFromURL mengembalikan path object dari URL publik, string kosong bila URL bukan milik storage ini
+	PathFromURL(url string) string
+}
+
+// Config berisi pengaturan semua driver, tiap service mengisinya dari env masing-masing.
+type Config struct {
+	Driver   string
+	Supabase SupabaseConfig
+	Local    LocalConfig
+	S3       S3Config
+}
+
+type SupabaseConfig struct {
+	URL    string
+	Key    string
+	Bucket string
+}
+
+type LocalConfig struct {
+	Path       string
+	BaseURL    string
+	SigningKey string
+}
+
+type S3Config struct {
+	Endpoint  string
+	Region    string
+	AccessKey string
+	SecretKey string
+	Bucket    string
+	PublicURL string
+}
+
+// NewStorage memilih driver berdasarkan Driver (STORAGE_DRIVER): supabase (default), local atau s3.
+func NewStorage(cfg Config) StorageInterface {
+	switch strings.ToLower(cfg.Driver) {
+	case "local":
+		return NewLocalStorage(cfg.Local)
+	case "s3":
+		return NewS3Storage(cfg.S3)
+	default:
+		return NewSupabase(cfg.Supabase)
+	}
+}
+
+func trimURLPrefix(url, prefix string) string {
+	prefix = strings.TrimSuffix(prefix, "/") + "/"
+	if prefix == "/" || !strings.HasPrefix(url, prefix) {
+		return ""
+	}
+
+	path := strings.TrimPrefix(url, prefix)
+	if i := strings.IndexAny(path, "?#"); i >= 0 {
+		path = path[:i]
+	}
+
+	return path
+}
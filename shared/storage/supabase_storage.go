@@ -0,0 +1,64 @@
+package storage
+
+import (
+	"io"
+	"time"
+
+	"github.com/labstack/gommon/log"
+
+	storage_go "github.com/supabase-community/storage-go"
+)
+
+type supabaseStruct struct {
+	cfg SupabaseConfig
+}
+
+// UploadFile implements StorageInterface.
+func (s *supabaseStruct) UploadFile(path string, contentType string, file io.Reader) (string, error) {
+	client := storage_go.NewClient(s.cfg.URL, s.cfg.Key, map[string]string{"Content-Type": contentType})
+
+	_, err := client.UploadFile(s.cfg.Bucket, path, file)
+	if err != nil {
+		log.Errorf("Error uploading file: %v", err)
+		return "", err
+	}
+
+	result := client.GetPublicUrl(s.cfg.Bucket, path)
+
+	return result.SignedURL, nil
+}
+
+// DeleteFile implements StorageInterface.
+func (s *supabaseStruct) DeleteFile(path string) error {
+	client := storage_go.NewClient(s.cfg.URL, s.cfg.Key, nil)
+
+	if _, err := client.RemoveFile(s.cfg.Bucket, []string{path}); err != nil {
+		log.Errorf("Error deleting file: %v", err)
+		return err
+	}
+
+	return nil
+}
+
+// SignedURL implements StorageInterface.
+func (s *supabaseStruct) SignedURL(path string, expiresIn time.Duration) (string, error) {
+	client := storage_go.NewClient(s.cfg.URL, s.cfg.Key, nil)
+
+	result, err := client.CreateSignedUrl(s.cfg.Bucket, path, int(expiresIn.Seconds()))
+	if err != nil {
+		log.Errorf("Error signing file url: %v", err)
+		return "", err
+	}
+
+	return result.SignedURL, nil
+}
+
+// PathFromURL implements StorageInterface.
+func (s *supabaseStruct) PathFromURL(url string) string {
+	client := storage_go.NewClient(s.cfg.URL, s.cfg.Key, nil)
+	return trimURLPrefix(url, client.GetPublicUrl(s.cfg.Bucket, "").SignedURL)
+}
+
+func NewSupabase(cfg SupabaseConfig) StorageInterface {
+	return &supabaseStruct{cfg: cfg}
+}
@@ -0,0 +1,420 @@
+package webp
+
+import (
+	"math/bits"
+	"sort"
+)
+
+// codeLengthCodeOrder adalah urutan penulisan panjang code-length code pada header prefix code.
+var codeLengthCodeOrder = [19]int{17, 18, 0, 1, 2, 3, 4, 5, 16, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15}
+
+// token adalah satu literal ARGB atau satu backward reference (length > 0).
+type token struct {
+	argb     uint32
+	length   int
+	distance int
+}
+
+// encodeImage menulis image ber-entropy-code: tanpa color cache, satu grup prefix code,
+// dan bit meta prefix hanya untuk image utama.
+func encodeImage(bw *bitWriter, pix []uint32, width int, isMain bool) {
+	tokens := backwardReferences(pix, width)
+
+	histograms := [5][]int{
+		make([]int, numLiterals+numLengthCodes),
+		make([]int, numLiterals),
+		make([]int, numLiterals),
+		make([]int, numLiterals),
+		make([]int, numDistanceCode),
+	}
+	for _, val := range tokens {
+		if val.length == 0 {
+			histograms[0][(val.argb>>8)&0xff]++
+			histograms[1][(val.argb>>16)&0xff]++
+			histograms[2][val.argb&0xff]++
+			histograms[3][val.argb>>24]++
+			continue
+		}
+
+		lengthCode, _, _ := prefixEncode(val.length)
+		distanceCode, _, _ := prefixEncode(val.distance)
+		histograms[0][numLiterals+lengthCode]++
+		histograms[4][distanceCode]++
+	}
+
+	bw.write(0, 1)
+	if isMain {
+		bw.write(0, 1)
+	}
+
+	var codes [5]prefixCode
+	for i, histogram := range histograms {
+		codes[i] = writePrefixCode(bw, histogram)
+	}
+
+	for _, val := range tokens {
+		if val.length == 0 {
+			codes[0].write(bw, int(val.argb>>8)&0xff)
+			codes[1].write(bw, int(val.argb>>16)&0xff)
+			codes[2].write(bw, int(val.argb)&0xff)
+			codes[3].write(bw, int(val.argb>>24))
+			continue
+		}
+
+		lengthCode, lengthBits, lengthExtra := prefixEncode(val.length)
+		codes[0].write(bw, numLiterals+lengthCode)
+		bw.write(lengthExtra, lengthBits)
+
+		distanceCode, distanceBits, distanceExtra := prefixEncode(val.distance)
+		codes[4].write(bw, distanceCode)
+		bw.write(distanceExtra, distanceBits)
+	}
+}
+
+// backwardReferences mencari pengulangan piksel secara greedy dengan hash chain.
+// Jarak disimpan dalam bentuk distance code VP8L: 1 = satu baris di atas, 2 = piksel kiri, selain itu jarak + 120.
+func backwardReferences(pix []uint32, width int) []token {
+	head := make([]int32, 1<<hashBits)
+	for i := range head {
+		head[i] = -1
+	}
+	chain := make([]int32, len(pix))
+
+	insert := func(pos int) {
+		if pos+1 >= len(pix) {
+			return
+		}
+		hash := hashPixels(pix[pos], pix[pos+1])
+		chain[pos] = head[hash]
+		head[hash] = int32(pos)
+	}
+
+	tokens := []token{}
+	for i := 0; i < len(pix); {
+		bestLength, bestDistance := 0, 0
+		if i+1 < len(pix) {
+			limit := min(maxMatch, len(pix)-i)
+			candidate := head[hashPixels(pix[i], pix[i+1])]
+			for tries := 0; candidate >= 0 && tries < maxChain && i-int(candidate) <= maxDistance; tries++ {
+				length := 0
+				for length < limit && pix[int(candidate)+length] == pix[i+length] {
+					length++
+				}
+				if length > bestLength {
+					bestLength, bestDistance = length, i-int(candidate)
+					if length == limit {
+						break
+					}
+				}
+				candidate = chain[candidate]
+			}
+		}
+
+		if bestLength < minMatch {
+			tokens = append(tokens, token{argb: pix[i]})
+			insert(i)
+			i++
+			continue
+		}
+
+		distanceCode := bestDistance + 120
+		switch bestDistance {
+		case width:
+			distanceCode = 1
+		case 1:
+			distanceCode = 2
+		}
+
+		tokens = append(tokens, token{length: bestLength, distance: distanceCode})
+		for j := i; j < i+bestLength; j++ {
+			insert(j)
+		}
+		i += bestLength
+	}
+
+	return tokens
+}
+
+func hashPixels(a, b uint32) uint32 {
+	return (a*0x9e3779b1 ^ b*0x85ebca6b) >> (32 - hashBits)
+}
+
+// prefixEncode memecah nilai length/distance (mulai dari 1) menjadi prefix code dan extra bits.
+func prefixEncode(value int) (int, uint, uint32) {
+	value--
+	if value < 4 {
+		return value, 0, 0
+	}
+
+	highest := bits.Len(uint(value)) - 1
+	second := (value >> (highest - 1)) & 1
+	extraBits := highest - 1
+	return 2*highest + second, uint(extraBits), uint32(value & (1<<extraBits - 1))
+}
+
+type prefixCode struct {
+	lengths []uint8
+	codes   []uint16
+}
+
+func (p prefixCode) write(bw *bitWriter, symbol int) {
+	bw.write(uint32(p.codes[symbol]), uint(p.lengths[symbol]))
+}
+
+// writePrefixCode menulis prefix code untuk histogram, memakai simple code bila simbol yang terpakai
+// paling banyak dua dan bernilai di bawah 256.
+func writePrefixCode(bw *bitWriter, histogram []int) prefixCode {
+	symbols := []int{}
+	for symbol, count := range histogram {
+		if count > 0 {
+			symbols = append(symbols, symbol)
+		}
+	}
+	if len(symbols) == 0 {
+		symbols = append(symbols, 0)
+	}
+
+	if len(symbols) <= 2 && symbols[len(symbols)-1] < numLiterals {
+		lengths := make([]uint8, len(histogram))
+		bw.write(1, 1)
+		bw.write(uint32(len(symbols)-1), 1)
+		if symbols[0] < 2 {
+			bw.write(0, 1)
+			bw.write(uint32(symbols[0]), 1)
+		} else {
+			bw.write(1, 1)
+			bw.write(uint32(symbols[0]), 8)
+		}
+
+		// Satu simbol dikodekan dengan 0 bit
+		if len(symbols) == 2 {
+			bw.write(uint32(symbols[1]), 8)
+			lengths[symbols[0]] = 1
+			lengths[symbols[1]] = 1
+		}
+		return prefixCode{lengths: lengths, codes: canonicalCodes(lengths)}
+	}
+
+	lengths := huffmanLengths(histogram, 15)
+	bw.write(0, 1)
+	writeCodeLengths(bw, lengths)
+
+	return prefixCode{lengths: lengths, codes: canonicalCodes(lengths)}
+}
+
+// writeCodeLengths menulis panjang kode dengan run-length code 16 (ulang panjang sebelumnya),
+// 17 dan 18 (deretan nol), lalu dikodekan lagi dengan code-length code.
+func writeCodeLengths(bw *bitWriter, lengths []uint8) {
+	type lengthToken struct {
+		symbol    int
+		extraBits uint
+		extra     uint32
+	}
+
+	tokens := []lengthToken{}
+	previous := uint8(8)
+	for i := 0; i < len(lengths); {
+		value := lengths[i]
+		run := 1
+		for i+run < len(lengths) && lengths[i+run] == value {
+			run++
+		}
+		i += run
+
+		if value == 0 {
+			for run > 0 {
+				switch {
+				case run < 3:
+					for ; run > 0; run-- {
+						tokens = append(tokens, lengthToken{symbol: 0})
+					}
+				case run <= 10:
+					tokens = append(tokens, lengthToken{symbol: 17, extraBits: 3, extra: uint32(run - 3)})
+					run = 0
+				default:
+					repeat := min(run, 138)
+					tokens = append(tokens, lengthToken{symbol: 18, extraBits: 7, extra: uint32(repeat - 11)})
+					run -= repeat
+				}
+			}
+			continue
+		}
+
+		if value != previous {
+			tokens = append(tokens, lengthToken{symbol: int(value)})
+			previous = value
+			run--
+		}
+		for run > 0 {
+			if run < 3 {
+				for ; run > 0; run-- {
+					tokens = append(tokens, lengthToken{symbol: int(value)})
+				}
+				break
+			}
+			repeat := min(run, 6)
+			tokens = append(tokens, lengthToken{symbol: 16, extraBits: 2, extra: uint32(repeat - 3)})
+			run -= repeat
+		}
+	}
+
+	histogram := make([]int, len(codeLengthCodeOrder))
+	for _, val := range tokens {
+		histogram[val.symbol]++
+	}
+	codeLengths := huffmanLengths(histogram, 7)
+	code := prefixCode{lengths: codeLengths, codes: canonicalCodes(codeLengths)}
+
+	count := len(codeLengthCodeOrder)
+	for count > 4 && codeLengths[codeLengthCodeOrder[count-1]] == 0 {
+		count--
+	}
+	bw.write(uint32(count-4), 4)
+	for _, symbol := range codeLengthCodeOrder[:count] {
+		bw.write(uint32(codeLengths[symbol]), 3)
+	}
+
+	// max_symbol tidak dipakai, semua panjang kode ditulis
+	bw.write(0, 1)
+	for _, val := range tokens {
+		code.write(bw, val.symbol)
+		bw.write(val.extra, val.extraBits)
+	}
+}
+
+// huffmanLengths membangun panjang kode Huffman dengan batas maxLength. Bila terlampaui,
+// frekuensi kecil dinaikkan bertahap sampai pohonnya cukup dangkal.
+// Histogram dengan satu simbol diberi pasangan dummy agar kodenya tetap lengkap.
+func huffmanLengths(histogram []int, maxLength int) []uint8 {
+	lengths := make([]uint8, len(histogram))
+	symbols := []int{}
+	for symbol, count := range histogram {
+		if count > 0 {
+			symbols = append(symbols, symbol)
+		}
+	}
+
+	if len(symbols) < 2 {
+		dummy := 0
+		if len(symbols) == 1 && symbols[0] == 0 {
+			dummy = 1
+		}
+		lengths[dummy] = 1
+		if len(symbols) == 1 {
+			lengths[symbols[0]] = 1
+		}
+		return lengths
+	}
+
+	for minCount := 1; ; minCount *= 2 {
+		if buildHuffmanLengths(histogram, symbols, minCount, maxLength, lengths) {
+			return lengths
+		}
+	}
+}
+
+func buildHuffmanLengths(histogram []int, symbols []int, minCount, maxLength int, lengths []uint8) bool {
+	type node struct {
+		weight int
+		parent int
+	}
+
+	leaves := append([]int{}, symbols...)
+	weight := func(symbol int) int {
+		return max(histogram[symbol], minCount)
+	}
+	sort.SliceStable(leaves, func(i, j int) bool {
+		return weight(leaves[i]) < weight(leaves[j])
+	})
+
+	// Dua antrean: daun terurut dan node internal yang terbentuk dengan bobot tidak menurun
+	nodes := make([]node, 0, 2*len(leaves)-1)
+	for _, symbol := range leaves {
+		nodes = append(nodes, node{weight: weight(symbol), parent: -1})
+	}
+
+	nextLeaf, nextInternal := 0, len(leaves)
+	take := func() int {
+		if nextLeaf < len(leaves) && (nextInternal >= len(nodes) || nodes[nextLeaf].weight <= nodes[nextInternal].weight) {
+			nextLeaf++
+			return nextLeaf - 1
+		}
+		nextInternal++
+		return nextInternal - 1
+	}
+
+	for i := 1; i < len(leaves); i++ {
+		a, b := take(), take()
+		nodes = append(nodes, node{weight: nodes[a].weight + nodes[b].weight, parent: -1})
+		nodes[a].parent = len(nodes) - 1
+		nodes[b].parent = len(nodes) - 1
+	}
+
+	depths := make([]int, len(nodes))
+	for i := len(nodes) - 2; i >= 0; i-- {
+		depths[i] = depths[nodes[i].parent] + 1
+	}
+
+	for i, symbol := range leaves {
+		if depths[i] > maxLength {
+			return false
+		}
+		lengths[symbol] = uint8(depths[i])
+	}
+
+	return true
+}
+
+// canonicalCodes menyusun kode Huffman kanonik. Kode dibaca decoder mulai dari bit teratas,
+// sedangkan bitWriter menulis dari bit terbawah, sehingga kode dibalik.
+func canonicalCodes(lengths []uint8) []uint16 {
+	var counts [16]int
+	for _, length := range lengths {
+		if length > 0 {
+			counts[length]++
+		}
+	}
+
+	var next [16]int
+	code := 0
+	for length := 1; length < 16; length++ {
+		code = (code + counts[length-1]) << 1
+		next[length] = code
+	}
+
+	codes := make([]uint16, len(lengths))
+	for symbol, length := range lengths {
+		if length == 0 {
+			continue
+		}
+		codes[symbol] = bits.Reverse16(uint16(next[length])) >> (16 - length)
+		next[length]++
+	}
+
+	return codes
+}
+
+// bitWriter menulis bit dengan urutan LSB lebih dulu sesuai format VP8L.
+type bitWriter struct {
+	buf   []byte
+	acc   uint64
+	count uint
+}
+
+func (b *bitWriter) write(value uint32, n uint) {
+	b.acc |= uint64(value) << b.count
+	b.count += n
+	for b.count >= 8 {
+		b.buf = append(b.buf, byte(b.acc))
+		b.acc >>= 8
+		b.count -= 8
+	}
+}
+
+func (b *bitWriter) bytes() []byte {
+	if b.count > 0 {
+		b.buf = append(b.buf, byte(b.acc))
+		b.acc, b.count = 0, 0
+	}
+	return b.buf
+}
@@ -0,0 +1,293 @@
+// Package webp meng-encode image ke WebP lossless (VP8L) hanya dengan library standar.
+// Encoder memakai transform subtract green dan predictor, backward reference LZ77 dan prefix code Huffman,
+// tanpa color cache maupun meta prefix code.
+package webp
+
+import (
+	"encoding/binary"
+	"errors"
+	"image"
+	"image/color"
+	"io"
+)
+
+const (
+	maxDimension = 1 << 14
+
+	// predictorBits menentukan ukuran blok predictor, 1<<4 = 16x16 piksel
+	predictorBits = 4
+	numPredictors = 14
+
+	minMatch    = 3
+	maxMatch    = 4096
+	maxChain    = 32
+	hashBits    = 16
+	maxDistance = 1<<20 - 120
+
+	numLiterals     = 256
+	numLengthCodes  = 24
+	numDistanceCode = 40
+)
+
+var ErrInvalidSize = errors.New("webp: image width and height must be between 1 and 16384")
+
+// Encode menulis img ke w sebagai file WebP lossless.
+func Encode(w io.Writer, img image.Image) error {
+	bounds := img.Bounds()
+	width, height := bounds.Dx(), bounds.Dy()
+	if width < 1 || height < 1 || width > maxDimension || height > maxDimension {
+		return ErrInvalidSize
+	}
+
+	pix, hasAlpha := toARGB(img)
+
+	bw := &bitWriter{}
+	bw.write(0x2f, 8)
+	bw.write(uint32(width-1), 14)
+	bw.write(uint32(height-1), 14)
+	if hasAlpha {
+		bw.write(1, 1)
+	} else {
+		bw.write(0, 1)
+	}
+	bw.write(0, 3)
+
+	// Transform ditulis sesuai urutan penerapan, decoder membaliknya dari yang terakhir
+	subtractGreen(pix)
+	bw.write(1, 1)
+	bw.write(2, 2)
+
+	modes, blocksWidth := choosePredictors(pix, width, height)
+	residuals := applyPredictors(pix, modes, width, height, blocksWidth)
+	bw.write(1, 1)
+	bw.write(0, 2)
+	bw.write(predictorBits-2, 3)
+	encodeImage(bw, modes, blocksWidth, false)
+
+	bw.write(0, 1)
+	encodeImage(bw, residuals, width, true)
+
+	data := bw.bytes()
+	chunkSize := len(data)
+	padding := chunkSize & 1
+
+	header := make([]byte, 20)
+	copy(header[0:], "RIFF")
+	binary.LittleEndian.PutUint32(header[4:], uint32(4+8+chunkSize+padding))
+	copy(header[8:], "WEBPVP8L")
+	binary.LittleEndian.PutUint32(header[16:], uint32(chunkSize))
+
+	if _, err := w.Write(header); err != nil {
+		return err
+	}
+	if _, err := w.Write(data); err != nil {
+		return err
+	}
+	if padding == 1 {
+		if _, err := w.Write([]byte{0}); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// toARGB mengubah image menjadi piksel ARGB non-premultiplied, piksel transparan penuh dinolkan agar mudah dikompresi.
+func toARGB(img image.Image) ([]uint32, bool) {
+	bounds := img.Bounds()
+	width, height := bounds.Dx(), bounds.Dy()
+	pix := make([]uint32, 0, width*height)
+	hasAlpha := false
+
+	for y := bounds.Min.Y; y < bounds.Max.Y; y++ {
+		for x := bounds.Min.X; x < bounds.Max.X; x++ {
+			c := color.NRGBAModel.Convert(img.At(x, y)).(color.NRGBA)
+			if c.A != 0xff {
+				hasAlpha = true
+			}
+			if c.A == 0 {
+				pix = append(pix, 0)
+				continue
+			}
+			pix = append(pix, uint32(c.A)<<24|uint32(c.R)<<16|uint32(c.G)<<8|uint32(c.B))
+		}
+	}
+
+	return pix, hasAlpha
+}
+
+func subtractGreen(pix []uint32) {
+	for i, argb := range pix {
+		green := (argb >> 8) & 0xff
+		red := ((argb >> 16) - green) & 0xff
+		blue := (argb - green) & 0xff
+		pix[i] = argb&0xff00ff00 | red<<16 | blue
+	}
+}
+
+// choosePredictors memilih mode predictor per blok dengan total residual terkecil.
+// Mode disimpan di kanal hijau sub-image sesuai format VP8L.
+func choosePredictors(pix []uint32, width, height int) ([]uint32, int) {
+	blockSize := 1 << predictorBits
+	blocksWidth := (width + blockSize - 1) >> predictorBits
+	blocksHeight := (height + blockSize - 1) >> predictorBits
+	modes := make([]uint32, blocksWidth*blocksHeight)
+
+	for by := 0; by < blocksHeight; by++ {
+		for bx := 0; bx < blocksWidth; bx++ {
+			bestMode, bestCost := 0, -1
+			for mode := 0; mode < numPredictors; mode++ {
+				cost := 0
+				for y := max(by*blockSize, 1); y < min((by+1)*blockSize, height); y++ {
+					for x := max(bx*blockSize, 1); x < min((bx+1)*blockSize, width); x++ {
+						i := y*width + x
+						cost += residualCost(subPixels(pix[i], predict(mode, pix, i, width)))
+					}
+				}
+				if bestCost < 0 || cost < bestCost {
+					bestMode, bestCost = mode, cost
+				}
+			}
+			modes[by*blocksWidth+bx] = uint32(bestMode) << 8
+		}
+	}
+
+	return modes, blocksWidth
+}
+
+func applyPredictors(pix []uint32, modes []uint32, width, height, blocksWidth int) []uint32 {
+	residuals := make([]uint32, len(pix))
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			i := y*width + x
+
+			var prediction uint32
+			switch {
+			case x == 0 && y == 0:
+				prediction = 0xff000000
+			case y == 0:
+				prediction = pix[i-1]
+			case x == 0:
+				prediction = pix[i-width]
+			default:
+				mode := int(modes[(y>>predictorBits)*blocksWidth+(x>>predictorBits)]>>8) & 0xf
+				prediction = predict(mode, pix, i, width)
+			}
+
+			residuals[i] = subPixels(pix[i], prediction)
+		}
+	}
+
+	return residuals
+}
+
+// predict menghitung prediksi piksel i (bukan baris atau kolom pertama).
+// Untuk kolom paling kanan, TR jatuh ke piksel paling kiri baris saat ini sesuai spesifikasi.
+func predict(mode int, pix []uint32, i, width int) uint32 {
+	left, top := pix[i-1], pix[i-width]
+	topLeft, topRight := pix[i-width-1], pix[i-width+1]
+
+	switch mode {
+	case 0:
+		return 0xff000000
+	case 1:
+		return left
+	case 2:
+		return top
+	case 3:
+		return topRight
+	case 4:
+		return topLeft
+	case 5:
+		return average2(average2(left, topRight), top)
+	case 6:
+		return average2(left, topLeft)
+	case 7:
+		return average2(left, top)
+	case 8:
+		return average2(topLeft, top)
+	case 9:
+		return average2(top, topRight)
+	case 10:
+		return average2(average2(left, topLeft), average2(top, topRight))
+	case 11:
+		return selectPixel(left, top, topLeft)
+	case 12:
+		return clampAddSubtractFull(left, top, topLeft)
+	default:
+		return clampAddSubtractHalf(average2(left, top), topLeft)
+	}
+}
+
+func channel(argb uint32, shift uint) int {
+	return int((argb >> shift) & 0xff)
+}
+
+func average2(a, b uint32) uint32 {
+	return (((a ^ b) & 0xfefefefe) >> 1) + (a & b)
+}
+
+func selectPixel(left, top, topLeft uint32) uint32 {
+	distLeft, distTop := 0, 0
+	for shift := uint(0); shift < 32; shift += 8 {
+		estimate := channel(left, shift) + channel(top, shift) - channel(topLeft, shift)
+		distLeft += abs(estimate - channel(left, shift))
+		distTop += abs(estimate - channel(top, shift))
+	}
+
+	if distLeft < distTop {
+		return left
+	}
+	return top
+}
+
+func clampAddSubtractFull(a, b, c uint32) uint32 {
+	var out uint32
+	for shift := uint(0); shift < 32; shift += 8 {
+		out |= clamp(channel(a, shift)+channel(b, shift)-channel(c, shift)) << shift
+	}
+	return out
+}
+
+func clampAddSubtractHalf(a, b uint32) uint32 {
+	var out uint32
+	for shift := uint(0); shift < 32; shift += 8 {
+		out |= clamp(channel(a, shift)+(channel(a, shift)-channel(b, shift))/2) << shift
+	}
+	return out
+}
+
+func subPixels(a, b uint32) uint32 {
+	var out uint32
+	for shift := uint(0); shift < 32; shift += 8 {
+		out |= ((a>>shift - b>>shift) & 0xff) << shift
+	}
+	return out
+}
+
+// residualCost memperkirakan biaya residual, nilai dekat 0 atau 255 (selisih kecil) dianggap murah.
+func residualCost(residual uint32) int {
+	cost := 0
+	for shift := uint(0); shift < 32; shift += 8 {
+		value := channel(residual, shift)
+		cost += min(value, 256-value)
+	}
+	return cost
+}
+
+func clamp(value int) uint32 {
+	if value < 0 {
+		return 0
+	}
+	if value > 255 {
+		return 255
+	}
+	return uint32(value)
+}
+
+func abs(value int) int {
+	if value < 0 {
+		return -value
+	}
+	return value
+}
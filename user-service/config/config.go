@@ -36,17 +36,35 @@ type Supabase struct {
 	Bucket string `json:"bucket"`
 }
 
+type LocalStorage struct {
+	Path       string `json:"path"`
+	BaseURL    string `json:"base_url"`
+	SigningKey string `json:"signing_key"`
+}
+
+type S3Storage struct {
+	Endpoint  string `json:"endpoint"`
+	Region    string `json:"region"`
+	AccessKey string `json:"access_key"`
+	SecretKey string `json:"secret_key"`
+	Bucket    string `json:"bucket"`
+	PublicURL string `json:"public_url"`
+}
+
 type Redis struct {
 	Host string `json:"host"`
 	Port string `json:"port"`
 }
 
 type Config struct {
-	App      App      `json:"app"`
-	Psql     PsqlDB   `json:"psql"`
-	RabbitMQ RabbitMQ `json:"rabbitmq"`
-	Storage  Supabase `json:"storage"`
-	Redis    Redis    `json:"redis"`
+	App           App          `json:"app"`
+	Psql          PsqlDB       `json:"psql"`
+	RabbitMQ      RabbitMQ     `json:"rabbitmq"`
+	StorageDriver string       `json:"storage_driver"`
+	Storage       Supabase     `json:"storage"`
+	LocalStorage  LocalStorage `json:"local_storage"`
+	S3Storage     S3Storage    `json:"s3_storage"`
+	Redis         Redis        `json:"redis"`
 }
 
 func NewConfig() *Config {
@@ -81,6 +99,20 @@ func NewConfig() *Config {
 			Key:    viper.GetString("SUPABASE_STORAGE_KEY"),
 			Bucket: viper.GetString("SUPABASE_STORAGE_BUCKET"),
 		},
+		StorageDriver: viper.GetString("STORAGE_DRIVER"),
+		LocalStorage: LocalStorage{
+			Path:       viper.GetString("LOCAL_STORAGE_PATH"),
+			BaseURL:    viper.GetString("LOCAL_STORAGE_URL"),
+			SigningKey: viper.GetString("LOCAL_STORAGE_SIGNING_KEY"),
+		},
+		S3Storage: S3Storage{
+			Endpoint:  viper.GetString("S3_ENDPOINT"),
+			Region:    viper.GetString("S3_REGION"),
+			AccessKey: viper.GetString("S3_ACCESS_KEY"),
+			SecretKey: viper.GetString("S3_SECRET_KEY"),
+			Bucket:    viper.GetString("S3_BUCKET"),
+			PublicURL: viper.GetString("S3_PUBLIC_URL"),
+		},
 		Redis: Redis{
 			Host: viper.GetString("REDIS_HOST"),
 			Port: viper.GetString("REDIS_PORT"),
@@ -15,6 +15,9 @@ func SeedRole(db *gorm.DB) {
 		{
 			Name: "Customer",
 		},
+		{
+			Name: "Courier",
+		},
 	}
 
 	for _, role := range roles {
@@ -23,11 +23,12 @@ type UpdatePasswordRequest struct {
 }
 
 type UpdateDataUserRequest struct {
-	Name    string `json:"name" validate:"required"`
-	Email   string `json:"email" validate:"email,required"`
-	Phone   string `json:"phone" validate:"required"`
-	Address string `json:"address" validate:"required"`
-	Lat     string `json:"lat" validate:"required"`
-	Lng     string `json:"lng" validate:"required"`
-	Photo   string `json:"photo" validate:"required"`
+	Name           string `json:"name" validate:"required"`
+	Email          string `json:"email" validate:"email,required"`
+	Phone          string `json:"phone" validate:"required"`
+	Address        string `json:"address" validate:"required"`
+	Lat            string `json:"lat" validate:"required"`
+	Lng            string `json:"lng" validate:"required"`
+	Photo          string `json:"photo" validate:"required"`
+	PhotoThumbnail string `json:"photo_thumbnail" validate:"omitempty,url"`
 }
@@ -12,15 +12,17 @@ type SignInResponse struct {
 }
 
 type ProfileResponse struct {
-	RoleName string `json:"role"`
-	ID       int64  `json:"id"`
-	Name     string `json:"name"`
-	Email    string `json:"email"`
-	Phone    string `json:"phone"`
-	Lat      string `json:"lat"`
-	Lng      string `json:"lng"`
-	Address  string `json:"address"`
-	Photo    string `json:"photo"`
+	RoleName       string `json:"role"`
+	ID             int64  `json:"id"`
+	Name           string `json:"name"`
+	Email          string `json:"email"`
+	Phone          string `json:"phone"`
+	Lat            string `json:"lat"`
+	Lng            string `json:"lng"`
+	Address        string `json:"address"`
+	Photo          string `json:"photo"`
+	PhotoThumbnail string `json:"photo_thumbnail"`
+	CreatedAt      string `json:"created_at"`
 }
 
 type CustomerListResponse struct {
@@ -32,14 +34,15 @@ type CustomerListResponse struct {
 }
 
 type CustomerResponse struct {
-	RoleName string `json:"role,omitempty"`
-	RoleID   int64  `json:"role_id"`
-	ID       int64  `json:"id"`
-	Name     string `json:"name"`
-	Email    string `json:"email"`
-	Phone    string `json:"phone"`
-	Lat      string `json:"lat"`
-	Lng      string `json:"lng"`
-	Address  string `json:"address"`
-	Photo    string `json:"photo"`
+	RoleName  string `json:"role,omitempty"`
+	RoleID    int64  `json:"role_id"`
+	ID        int64  `json:"id"`
+	Name      string `json:"name"`
+	Email     string `json:"email"`
+	Phone     string `json:"phone"`
+	Lat       string `json:"lat"`
+	Lng       string `json:"lng"`
+	Address   string `json:"address"`
+	Photo     string `json:"photo"`
+	CreatedAt string `json:"created_at"`
 }
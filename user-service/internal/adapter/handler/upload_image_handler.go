@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"shared/imageproc"
 	"time"
 	"user-service/config"
 	"user-service/internal/adapter"
@@ -17,15 +18,18 @@ import (
 	"github.com/labstack/gommon/log"
 )
 
+const maxUploadImageSize = 5 << 20
+
 type UploadImageInterface interface {
 	UploadImage(c echo.Context) error
 }
 
 type uploadImage struct {
-	storageHandler storage.SupabaseInterface
+	storageHandler storage.StorageInterface
 }
 
 // UploadImage implements UploadImageInterface.
+// File diproses menjadi rendition thumbnail, medium dan large, image_url berisi rendition medium untuk foto profil.
 func (u *uploadImage) UploadImage(c echo.Context) error {
 	var resp = response.DefaultResponse{}
 
@@ -37,9 +41,16 @@ func (u *uploadImage) UploadImage(c echo.Context) error {
 		return c.JSON(http.StatusUnprocessableEntity, resp)
 	}
 
+	if file.Size > maxUploadImageSize {
+		log.Errorf("[UploadImage-2] UploadImage: file size %d exceeds limit", file.Size)
+		resp.Message = "image must not exceed 5MB"
+		resp.Data = nil
+		return c.JSON(http.StatusRequestEntityTooLarge, resp)
+	}
+
 	src, err := file.Open()
 	if err != nil {
-		log.Errorf("[UploadImage-2] UploadImage: %v", err)
+		log.Errorf("[UploadImage-3] UploadImage: %v", err)
 		resp.Message = err.Error()
 		resp.Data = nil
 		return c.JSON(http.StatusBadRequest, resp)
@@ -48,40 +59,58 @@ func (u *uploadImage) UploadImage(c echo.Context) error {
 	defer src.Close()
 
 	fileBuffer := new(bytes.Buffer)
-	_, err = io.Copy(fileBuffer, src)
+	_, err = io.Copy(fileBuffer, io.LimitReader(src, maxUploadImageSize))
 	if err != nil {
-		log.Errorf("[UploadImage-3] UploadImage: %v", err)
+		log.Errorf("[UploadImage-4] UploadImage: %v", err)
 		resp.Message = err.Error()
 		resp.Data = nil
 		return c.JSON(http.StatusInternalServerError, resp)
 	}
 
-	newFileName := fmt.Sprintf("%s_%d%s", uuid.New().String(), time.Now().Unix(), getExtension(file.Filename))
-
-	uploadPath := fmt.Sprintf("public/uploads/%s", newFileName)
-	url, err := u.storageHandler.UploadFile(uploadPath, fileBuffer)
+	renditions, err := imageproc.Process(fileBuffer.Bytes())
 	if err != nil {
-		log.Errorf("[UploadImage-4] UploadImage: %v", err)
+		log.Errorf("[UploadImage-5] UploadImage: %v", err)
+		if err == imageproc.ErrUnsupportedImage {
+			resp.Message = "image must be a JPEG, PNG or GIF file"
+			resp.Data = nil
+			return c.JSON(http.StatusUnsupportedMediaType, resp)
+		}
 		resp.Message = err.Error()
 		resp.Data = nil
 		return c.JSON(http.StatusInternalServerError, resp)
 	}
 
+	fileName := fmt.Sprintf("%s_%d", uuid.New().String(), time.Now().Unix())
+	urls := map[string]string{}
+	uploadedPaths := []string{}
+	for _, rendition := range renditions {
+		uploadPath := fmt.Sprintf("public/uploads/%s_%s%s", fileName, rendition.Name, rendition.Extension)
+		url, err := u.storageHandler.UploadFile(uploadPath, rendition.ContentType, bytes.NewReader(rendition.Data))
+		if err != nil {
+			log.Errorf("[UploadImage-6] UploadImage: %v", err)
+			// Rendition yang sudah terunggah dihapus agar tidak menjadi file yatim
+			for _, path := range uploadedPaths {
+				if err := u.storageHandler.DeleteFile(path); err != nil {
+					log.Errorf("[UploadImage-7] UploadImage: %v", err)
+				}
+			}
+			resp.Message = err.Error()
+			resp.Data = nil
+			return c.JSON(http.StatusInternalServerError, resp)
+		}
+
+		uploadedPaths = append(uploadedPaths, uploadPath)
+		urls[rendition.Name+"_url"] = url
+	}
+	urls["image_url"] = urls["medium_url"]
+
 	resp.Message = "Success"
-	resp.Data = map[string]string{"image_url": url}
+	resp.Data = urls
 
 	return c.JSON(http.StatusOK, resp)
 }
 
-func getExtension(fileName string) string {
-	ext := "." + fileName[len(fileName)-3:] // Ambil 3 karakter terakhir untuk ekstensi
-	if len(fileName) > 4 && fileName[len(fileName)-4] == '.' {
-		ext = "." + fileName[len(fileName)-4:]
-	}
-	return ext
-}
-
-func NewUploadImage(e *echo.Echo, cfg *config.Config, storageHandler storage.SupabaseInterface, jwtService service.JwtServiceInterface) UploadImageInterface {
+func NewUploadImage(e *echo.Echo, cfg *config.Config, storageHandler storage.StorageInterface, jwtService service.JwtServiceInterface) UploadImageInterface {
 	res := &uploadImage{
 		storageHandler: storageHandler,
 	}
@@ -299,6 +299,7 @@ func (u *userHandler) GetCustomerByID(c echo.Context) error {
 	respUser.Photo = result.Photo
 	respUser.Lat = result.Lat
 	respUser.Lng = result.Lng
+	respUser.CreatedAt = result.CreatedAt
 
 	resp.Data = respUser
 	resp.Pagination = nil
@@ -436,14 +437,15 @@ func (u *userHandler) UpdateDataUser(c echo.Context) error {
 	}
 
 	reqEntity := entity.UserEntity{
-		ID:      userID,
-		Name:    req.Name,
-		Email:   req.Email,
-		Address: req.Address,
-		Lat:     req.Lat,
-		Lng:     req.Lng,
-		Phone:   req.Phone,
-		Photo:   req.Photo,
+		ID:             userID,
+		Name:           req.Name,
+		Email:          req.Email,
+		Address:        req.Address,
+		Lat:            req.Lat,
+		Lng:            req.Lng,
+		Phone:          req.Phone,
+		Photo:          req.Photo,
+		PhotoThumbnail: req.PhotoThumbnail,
 	}
 
 	err = u.userService.UpdateDataUser(ctx, reqEntity)
@@ -512,7 +514,9 @@ func (u *userHandler) GetProfileUser(c echo.Context) error {
 	respProfile.Lng = dataUser.Lng
 	respProfile.Phone = dataUser.Phone
 	respProfile.Photo = dataUser.Photo
+	respProfile.PhotoThumbnail = dataUser.PhotoThumbnail
 	respProfile.RoleName = dataUser.RoleName
+	respProfile.CreatedAt = dataUser.CreatedAt
 
 	resp.Message = "success"
 	resp.Data = respProfile
@@ -154,15 +154,16 @@ func (u *userRepository) GetCustomerByID(ctx context.Context, customerID int64)
 	}
 
 	return &entity.UserEntity{
-		ID:      customerID,
-		Name:    modelUser.Name,
-		Email:   modelUser.Email,
-		RoleID:  int64(roleID),
-		Address: modelUser.Address,
-		Lat:     modelUser.Lat,
-		Lng:     modelUser.Lng,
-		Phone:   modelUser.Phone,
-		Photo:   modelUser.Photo,
+		ID:        customerID,
+		Name:      modelUser.Name,
+		Email:     modelUser.Email,
+		RoleID:    int64(roleID),
+		Address:   modelUser.Address,
+		Lat:       modelUser.Lat,
+		Lng:       modelUser.Lng,
+		Phone:     modelUser.Phone,
+		Photo:     modelUser.Photo,
+		CreatedAt: modelUser.CreatedAt.Format("2006-01-02 15:04:05"),
 	}, nil
 }
 
@@ -237,6 +238,8 @@ func (u *userRepository) UpdateDataUser(ctx context.Context, req entity.UserEnti
 	modelUser.Lng = req.Lng
 	modelUser.Address = req.Address
 	modelUser.Phone = req.Phone
+	modelUser.Photo = req.Photo
+	modelUser.PhotoThumbnail = req.PhotoThumbnail
 
 	if err := u.db.UpdateColumns(&modelUser).Error; err != nil {
 		log.Errorf("[UserRepository-3] UpdateDataUser: %v", err)
@@ -261,15 +264,17 @@ func (u *userRepository) GetUserByID(ctx context.Context, userID int64) (*entity
 	}
 
 	return &entity.UserEntity{
-		ID:       modelUser.ID,
-		Email:    modelUser.Email,
-		Name:     modelUser.Name,
-		RoleName: modelUser.Roles[0].Name,
-		Lat:      modelUser.Lat,
-		Lng:      modelUser.Lng,
-		Address:  modelUser.Address,
-		Phone:    modelUser.Phone,
-		Photo:    modelUser.Photo,
+		ID:             modelUser.ID,
+		Email:          modelUser.Email,
+		Name:           modelUser.Name,
+		RoleName:       modelUser.Roles[0].Name,
+		Lat:            modelUser.Lat,
+		Lng:            modelUser.Lng,
+		Address:        modelUser.Address,
+		Phone:          modelUser.Phone,
+		Photo:          modelUser.Photo,
+		PhotoThumbnail: modelUser.PhotoThumbnail,
+		CreatedAt:      modelUser.CreatedAt.Format("2006-01-02 15:04:05"),
 	}, nil
 }
 
@@ -0,0 +1,42 @@
+package storage
+
+import (
+	sharedstorage "shared/storage"
+	"user-service/config"
+)
+
+// Driver storage ada di module shared, package ini hanya memetakan konfigurasi service.
+type (
+	StorageInterface      = sharedstorage.StorageInterface
+	LocalStorageInterface = sharedstorage.LocalStorageInterface
+)
+
+// NewStorage memilih driver berdasarkan STORAGE_DRIVER: supabase (default), local atau s3.
+func NewStorage(cfg *config.Config) StorageInterface {
+	signingKey := cfg.LocalStorage.SigningKey
+	if signingKey == "" {
+		signingKey = cfg.App.JwtSecretKey
+	}
+
+	return sharedstorage.NewStorage(sharedstorage.Config{
+		Driver: cfg.StorageDriver,
+		Supabase: sharedstorage.SupabaseConfig{
+			URL:    cfg.Storage.URL,
+			Key:    cfg.Storage.Key,
+			Bucket: cfg.Storage.Bucket,
+		},
+		Local: sharedstorage.LocalConfig{
+			Path:       cfg.LocalStorage.Path,
+			BaseURL:    cfg.LocalStorage.BaseURL,
+			SigningKey: signingKey,
+		},
+		S3: sharedstorage.S3Config{
+			Endpoint:  cfg.S3Storage.Endpoint,
+			Region:    cfg.S3Storage.Region,
+			AccessKey: cfg.S3Storage.AccessKey,
+			SecretKey: cfg.S3Storage.SecretKey,
+			Bucket:    cfg.S3Storage.Bucket,
+			PublicURL: cfg.S3Storage.PublicURL,
+		},
+	})
+}
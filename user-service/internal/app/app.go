@@ -27,14 +27,14 @@ func RunServer() {
 		return
 	}
 
-	storageHandler := storage.NewSupabase(cfg)
+	storageHandler := storage.NewStorage(cfg)
 
 	userRepo := repository.NewUserRepository(db.DB)
 	tokenRepo := repository.NewVerificationTokenRepository(db.DB)
 	roleRepo := repository.NewRoleRepository(db.DB)
 
 	jwtService := service.NewJwtService(cfg)
-	userService := service.NewUserService(userRepo, cfg, jwtService, tokenRepo)
+	userService := service.NewUserService(userRepo, cfg, jwtService, tokenRepo, storageHandler)
 	roleService := service.NewRoleService(roleRepo)
 
 	e := echo.New()
@@ -50,6 +50,9 @@ func RunServer() {
 
 	handler.NewUserHandler(e, userService, cfg, jwtService)
 	handler.NewUploadImage(e, cfg, storageHandler, jwtService)
+	if localStorage, ok := storageHandler.(storage.LocalStorageInterface); ok {
+		handler.NewStorageHandler(e, localStorage)
+	}
 	handler.NewRoleHandler(e, roleService, cfg, jwtService)
 
 	go func() {
@@ -5,10 +5,12 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"shared/imageproc"
 	"time"
 	"user-service/config"
 	"user-service/internal/adapter/message"
 	"user-service/internal/adapter/repository"
+	"user-service/internal/adapter/storage"
 	"user-service/internal/core/domain/entity"
 	"user-service/utils"
 	"user-service/utils/conv"
@@ -39,6 +41,7 @@ type userService struct {
 	cfg        *config.Config
 	jwtService JwtServiceInterface
 	repoToken  repository.VerificationTokenRepositoryInterface
+	storage    storage.StorageInterface
 }
 
 // DeleteCustomer implements UserServiceInterface.
@@ -116,7 +119,44 @@ func (u *userService) GetCustomerAll(ctx context.Context, query entity.QueryStri
 
 // UpdateDataUser implements UserServiceInterface.
 func (u *userService) UpdateDataUser(ctx context.Context, req entity.UserEntity) error {
-	return u.repo.UpdateDataUser(ctx, req)
+	before, err := u.repo.GetUserByID(ctx, req.ID)
+	if err != nil {
+		log.Errorf("[UserService-1] UpdateDataUser: %v", err)
+		return err
+	}
+
+	if err := u.repo.UpdateDataUser(ctx, req); err != nil {
+		log.Errorf("[UserService-2] UpdateDataUser: %v", err)
+		return err
+	}
+
+	// Foto lama yang diganti dihapus dari storage beserta seluruh rendition-nya
+	if before.Photo != req.Photo {
+		u.deletePhotos(before.Photo, before.PhotoThumbnail)
+	}
+
+	return nil
+}
+
+func (u *userService) deletePhotos(urls ...string) {
+	deleted := map[string]bool{}
+	for _, url := range urls {
+		path := u.storage.PathFromURL(url)
+		if path == "" {
+			continue
+		}
+
+		for _, renditionPath := range imageproc.RenditionPaths(path) {
+			if deleted[renditionPath] {
+				continue
+			}
+			deleted[renditionPath] = true
+
+			if err := u.storage.DeleteFile(renditionPath); err != nil {
+				log.Errorf("[UserService-1] deletePhotos: %v", err)
+			}
+		}
+	}
 }
 
 // GetProfileUser implements UserServiceInterface.
@@ -311,11 +351,12 @@ func (u *userService) SignIn(ctx context.Context, req entity.UserEntity) (*entit
 	return user, token, nil
 }
 
-func NewUserService(repo repository.UserRepositoryInterface, cfg *config.Config, jwtService JwtServiceInterface, repoToken repository.VerificationTokenRepositoryInterface) UserServiceInterface {
+func NewUserService(repo repository.UserRepositoryInterface, cfg *config.Config, jwtService JwtServiceInterface, repoToken repository.VerificationTokenRepositoryInterface, storageHandler storage.StorageInterface) UserServiceInterface {
 	return &userService{
 		repo:       repo,
 		cfg:        cfg,
 		jwtService: jwtService,
 		repoToken:  repoToken,
+		storage:    storageHandler,
 	}
 }